@@ -45,6 +45,24 @@ func (b *Broadcaster) Send(v interface{}) {
 	}
 }
 
+// TrySend broadcasts a message to the channel without blocking,
+// dropping it for any listener whose buffer is full.
+func (b *Broadcaster) TrySend(v interface{}) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	if b.closed {
+		log.Warning("send on closed channel")
+		return
+	}
+	for _, l := range b.listeners {
+		select {
+		case l <- v:
+		default:
+			log.Debug("listener is full, dropping message")
+		}
+	}
+}
+
 // Close closes the channel, disabling the sending of further messages.
 func (b *Broadcaster) Close() {
 	b.m.Lock()
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/textileio/textile-go/core"
 )
@@ -17,8 +18,9 @@ func init() {
 }
 
 type blocksCmd struct {
-	List lsBlocksCmd  `command:"ls" description:"Paginate thread blocks"`
-	Get  getBlocksCmd `command:"get" description:"Get a thread block"`
+	List    lsBlocksCmd      `command:"ls" description:"Paginate thread blocks"`
+	Get     getBlocksCmd     `command:"get" description:"Get a thread block"`
+	Restore restoreBlocksCmd `command:"restore" description:"Restore a thread block you ignored"`
 }
 
 func (x *blocksCmd) Name() string {
@@ -44,36 +46,78 @@ There are several block types:
 -  FILES:    File(s) added.
 -  MESSAGE:  Text message added.
 -  COMMENT:  Comment added to another block.
--  LIKE:     Like added to another block.
+-  LIKE:     Like or reaction added to another block.
 -  MERGE:    3-way merge added.
 -  IGNORE:   Another block was ignored.
 -  FLAG:     A flag was added to another block.
+-  UNDO:     An ignore, like, or flag was undone by its author.
+-  CHECKPOINT: Snapshot of members and write state for fast joins.
   
-Use this command to get and list blocks in a thread.
+Use this command to get, list, and restore blocks in a thread.
 `
 }
 
+// QueryOptions filter and sort block listings
+type QueryOptions struct {
+	Author  []string `short:"a" long:"author" description:"An author peer ID to filter for. Omit for all."`
+	Since   string   `long:"since" description:"Only list blocks on or after this RFC3339 date."`
+	Until   string   `long:"until" description:"Only list blocks on or before this RFC3339 date."`
+	Dir     string   `long:"dir" description:"Sort direction, desc or asc." default:"desc"`
+	Ignored bool     `long:"ignored" description:"Include ignored and undone blocks."`
+	Hidden  bool     `long:"hidden" description:"Include blocks hidden by flags."`
+}
+
+// addTo adds query options to request opts
+func (q QueryOptions) addTo(opts map[string]string) map[string]string {
+	opts["author"] = strings.Join(q.Author, "|")
+	opts["since"] = q.Since
+	opts["until"] = q.Until
+	opts["dir"] = q.Dir
+	opts["ignored"] = strconv.FormatBool(q.Ignored)
+	opts["hidden"] = strconv.FormatBool(q.Hidden)
+	return opts
+}
+
+// nextPage returns a copy of list opts starting after offset
+func nextPage(opts map[string]string, offset string) map[string]string {
+	next := make(map[string]string)
+	for k, v := range opts {
+		next[k] = v
+	}
+	next["offset"] = offset
+	return next
+}
+
 type lsBlocksCmd struct {
 	Client ClientOptions `group:"Client Options"`
+	Query  QueryOptions  `group:"Query Options"`
 	Thread string        `short:"t" long:"thread" description:"Thread ID. Omit for default."`
+	Type   []string      `short:"k" long:"type" description:"A block type to filter for. Omit for all."`
+	Target string        `long:"target" description:"Only list blocks with this target."`
 	Offset string        `short:"o" long:"offset" description:"Offset ID to start listing from."`
 	Limit  int           `short:"l" long:"limit" description:"List page size." default:"5"`
+	Order  string        `long:"order" description:"List order, date or causal." default:"date"`
 }
 
 func (x *lsBlocksCmd) Usage() string {
 	return `
 
 Paginates blocks in a thread.
+Use the --type option to limit the output to specific block type(s).
+This option can be used multiple times, e.g., --type message --type comment.
 `
 }
 
 func (x *lsBlocksCmd) Execute(args []string) error {
 	setApi(x.Client)
-	opts := map[string]string{
+	opts := x.Query.addTo(map[string]string{
 		"thread": x.Thread,
+		"type":   strings.Join(x.Type, "|"),
+		"target": x.Target,
 		"offset": x.Offset,
 		"limit":  strconv.Itoa(x.Limit),
-	}
+		"order":  x.Order,
+	})
 	return callLsBlocks(opts)
 }
 
@@ -104,11 +148,7 @@ func callLsBlocks(opts map[string]string) error {
 		return err
 	}
 
-	return callLsBlocks(map[string]string{
-		"thread": opts["thread"],
-		"offset": list[len(list)-1].Id,
-		"limit":  opts["limit"],
-	})
+	return callLsBlocks(nextPage(opts, list[len(list)-1].Id))
 }
 
 type getBlocksCmd struct {
@@ -136,6 +176,32 @@ func (x *getBlocksCmd) Execute(args []string) error {
 	return nil
 }
 
+type restoreBlocksCmd struct {
+	Client ClientOptions `group:"Client Options"`
+}
+
+func (x *restoreBlocksCmd) Usage() string {
+	return `
+
+Restores a thread block you ignored by its ID.
+This adds an "undo" thread block targeted at your ignore.
+`
+}
+
+func (x *restoreBlocksCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if len(args) == 0 {
+		return errMissingBlockId
+	}
+	var info *core.BlockInfo
+	res, err := executeJsonCmd(POST, "blocks/"+args[0]+"/restore", params{}, &info)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
+
 func callRmBlocks(args []string) error {
 	if len(args) == 0 {
 		return errMissingBlockId
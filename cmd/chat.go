@@ -27,6 +27,7 @@ func (x *chatCmd) Long() string {
 	return `
 Starts an interactive chat session in a thread.
 Omit the --thread option to use the default thread (if selected).
+Reply to a message or comment with "/reply <block id> <message>".
 `
 }
 
@@ -65,7 +66,11 @@ func (x *chatCmd) Execute(args []string) error {
 					if last {
 						println()
 					}
-					println(Cyan(update.Block.Username) + "  " + Grey(update.Block.Body))
+					body := update.Block.Body
+					if update.Block.Target != "" {
+						body = "↳ " + body
+					}
+					println(Cyan(update.Block.Username) + "  " + Grey(body))
 					last = false
 				}
 			}
@@ -87,8 +92,17 @@ func (x *chatCmd) Execute(args []string) error {
 }
 
 func handleLine(line string, threadId string) error {
+	var replyTo string
+	if strings.HasPrefix(line, "/reply ") {
+		parts := strings.SplitN(strings.TrimPrefix(line, "/reply "), " ", 2)
+		if len(parts) < 2 {
+			println(Yellow("usage: /reply <block id> <message>"))
+			return nil
+		}
+		replyTo, line = parts[0], parts[1]
+	}
 	if strings.TrimSpace(line) != "" {
-		if _, err := callAddMessages(threadId, line); err != nil {
+		if _, err := callAddMessages(threadId, line, replyTo, ""); err != nil {
 			return err
 		}
 	}
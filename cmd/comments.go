@@ -37,14 +37,16 @@ Use this command to add, list, get, and ignore comments.
 }
 
 type addCommentsCmd struct {
-	Client ClientOptions `group:"Client Options"`
-	Block  string        `required:"true" short:"b" long:"block" description:"Thread block ID. Usually a file(s) block."`
+	Client  ClientOptions `group:"Client Options"`
+	Block   string        `required:"true" short:"b" long:"block" description:"Thread block ID. Usually a file(s) block."`
+	ReplyTo string        `short:"r" long:"reply-to" description:"Message or comment block ID to reply to."`
 }
 
 func (x *addCommentsCmd) Usage() string {
 	return `
 
-Adds a comment to a thread block.`
+Adds a comment to a thread block.
+Use the --reply-to option to reply to a message or comment.`
 }
 
 func (x *addCommentsCmd) Execute(args []string) error {
@@ -56,6 +58,7 @@ func (x *addCommentsCmd) Execute(args []string) error {
 	var info *core.ThreadCommentInfo
 	res, err := executeJsonCmd(POST, "blocks/"+x.Block+"/comments", params{
 		args: args,
+		opts: map[string]string{"reply_to": x.ReplyTo},
 	}, &info)
 	if err != nil {
 		return err
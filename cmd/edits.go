@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/textileio/textile-go/core"
+)
+
+var errMissingEditBody = errors.New("missing edit body")
+
+func init() {
+	register(&editsCmd{})
+}
+
+type editsCmd struct {
+	Add  addEditsCmd `command:"add" description:"Edit a thread message, comment, or files caption"`
+	List lsEditsCmd  `command:"ls" description:"List thread block edits"`
+}
+
+func (x *editsCmd) Name() string {
+	return "edits"
+}
+
+func (x *editsCmd) Short() string {
+	return "Manage thread block edits"
+}
+
+func (x *editsCmd) Long() string {
+	return `
+Edits are added as blocks in a thread, which target
+a message, comment, or files block written by the same author.
+Use this command to add and list edits.
+`
+}
+
+type addEditsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Block  string        `required:"true" short:"b" long:"block" description:"Thread block ID. A message, comment, or files block."`
+}
+
+func (x *addEditsCmd) Usage() string {
+	return `
+
+Replaces the body of a thread block you authored.
+Likes and comments on the block are kept.`
+}
+
+func (x *addEditsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if len(args) == 0 {
+		return errMissingEditBody
+	}
+	var info *core.BlockInfo
+	res, err := executeJsonCmd(POST, "blocks/"+x.Block+"/edits", params{
+		args: []string{args[0]},
+	}, &info)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
+
+type lsEditsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Block  string        `required:"true" short:"b" long:"block" description:"Thread block ID. A message, comment, or files block."`
+}
+
+func (x *lsEditsCmd) Usage() string {
+	return `
+
+Lists the edit history of a thread block, oldest first,
+starting with the original body of the block.`
+}
+
+func (x *lsEditsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	var list []core.ThreadEditInfo
+	res, err := executeJsonCmd(GET, "blocks/"+x.Block+"/edits", params{}, &list)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
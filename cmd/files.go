@@ -62,6 +62,7 @@ type addCmd struct {
 	Client  ClientOptions `group:"Client Options"`
 	Thread  string        `short:"t" long:"thread" description:"Thread ID. Omit for default."`
 	Caption string        `short:"c" long:"caption" description:"File(s) caption."`
+	Ttl     string        `long:"ttl" description:"Seconds until the file(s) expire. Omit for the thread default."`
 	Group   bool          `short:"g" long:"group" description:"Group directory files."`
 	Verbose bool          `short:"v" long:"verbose" description:"Prints files as they are milled."`
 }
@@ -80,6 +81,7 @@ Adds a file or directory of files to a thread. Files not supported
 by the thread schema are ignored. Nested directories are included.
 An existing file hash may also be used as input.
 Use the --group option to add directory files as a single object.  
+Use the --ttl option to have every peer delete the file(s) once they expire.
 Omit the --thread option to use the default thread (if selected).
 `
 }
@@ -89,6 +91,7 @@ func (x *addCmd) Execute(args []string) error {
 	opts := map[string]string{
 		"thread":  x.Thread,
 		"caption": x.Caption,
+		"ttl":     x.Ttl,
 		"group":   strconv.FormatBool(x.Group),
 		"verbose": strconv.FormatBool(x.Verbose),
 	}
@@ -183,7 +186,7 @@ func callAdd(args []string, opts map[string]string) error {
 
 					if !group {
 						caption := strings.TrimSpace(fmt.Sprintf("%s (%d)", opts["caption"], count+1))
-						block, err := add([]core.Directory{dir}, threadId, caption, verbose)
+						block, err := add([]core.Directory{dir}, threadId, caption, opts["ttl"], verbose)
 						if err != nil {
 							cerr = err
 							break loop
@@ -210,7 +213,7 @@ func callAdd(args []string, opts map[string]string) error {
 			return err
 		}
 
-		block, err := add([]core.Directory{dir}, threadId, opts["caption"], verbose)
+		block, err := add([]core.Directory{dir}, threadId, opts["caption"], opts["ttl"], verbose)
 		if err != nil {
 			return err
 		}
@@ -220,7 +223,7 @@ func callAdd(args []string, opts map[string]string) error {
 	}
 
 	if group && len(dirs) > 0 {
-		block, err := add(dirs, threadId, opts["caption"], verbose)
+		block, err := add(dirs, threadId, opts["caption"], opts["ttl"], verbose)
 		if err != nil {
 			return err
 		}
@@ -242,7 +245,7 @@ func callAdd(args []string, opts map[string]string) error {
 	return nil
 }
 
-func add(dirs []core.Directory, threadId string, caption string, verbose bool) (*core.BlockInfo, error) {
+func add(dirs []core.Directory, threadId string, caption string, ttl string, verbose bool) (*core.BlockInfo, error) {
 	data, err := json.Marshal(&dirs)
 	if err != nil {
 		return nil, err
@@ -250,7 +253,7 @@ func add(dirs []core.Directory, threadId string, caption string, verbose bool) (
 
 	var block *core.BlockInfo
 	res, err := executeJsonCmd(POST, "threads/"+threadId+"/files", params{
-		opts:    map[string]string{"caption": caption},
+		opts:    map[string]string{"caption": caption, "ttl": ttl},
 		payload: bytes.NewReader(data),
 		ctype:   "application/json",
 	}, &block)
@@ -469,9 +472,11 @@ func multipartReader(f *os.File) (io.ReadSeeker, string, error) {
 
 type lsCmd struct {
 	Client ClientOptions `group:"Client Options"`
+	Query  QueryOptions  `group:"Query Options"`
 	Thread string        `short:"t" long:"thread" description:"Thread ID. Omit for all."`
 	Offset string        `short:"o" long:"offset" description:"Offset ID to start listing from."`
 	Limit  int           `short:"l" long:"limit" description:"List page size." default:"5"`
+	Order  string        `long:"order" description:"List order, date or causal." default:"date"`
 }
 
 func (x *lsCmd) Name() string {
@@ -492,11 +497,12 @@ Specify "default" to use the default thread (if selected).
 
 func (x *lsCmd) Execute(args []string) error {
 	setApi(x.Client)
-	opts := map[string]string{
+	opts := x.Query.addTo(map[string]string{
 		"thread": x.Thread,
 		"offset": x.Offset,
 		"limit":  strconv.Itoa(x.Limit),
-	}
+		"order":  x.Order,
+	})
 	return callLs(opts)
 }
 
@@ -523,11 +529,7 @@ func callLs(opts map[string]string) error {
 		return err
 	}
 
-	return callLs(map[string]string{
-		"thread": opts["thread"],
-		"offset": list[len(list)-1].Block,
-		"limit":  opts["limit"],
-	})
+	return callLs(nextPage(opts, list[len(list)-1].Block))
 }
 
 type getCmd struct {
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/textileio/textile-go/core"
+)
+
+var errMissingFlagThreshold = errors.New("missing flag threshold")
+
+func init() {
+	register(&flagsCmd{})
+}
+
+type flagsCmd struct {
+	Add       addFlagsCmd       `command:"add" description:"Flag a thread block"`
+	List      lsFlagsCmd        `command:"ls" description:"List flags on a thread block"`
+	Remove    rmFlagsCmd        `command:"rm" description:"Remove your flag from a thread block"`
+	Review    reviewFlagsCmd    `command:"review" description:"List flagged blocks awaiting review"`
+	Threshold thresholdFlagsCmd `command:"threshold" description:"Set the number of flags that hide a block"`
+}
+
+func (x *flagsCmd) Name() string {
+	return "flags"
+}
+
+func (x *flagsCmd) Short() string {
+	return "Manage thread flags"
+}
+
+func (x *flagsCmd) Long() string {
+	return `
+Flags are added as blocks in a thread, which target another block
+that should be reviewed by moderators.
+A flagged block is hidden from listings once a moderator flags it, or once
+it has been flagged by the thread's flag threshold of distinct peers.
+Use this command to add, list, and remove flags, review flagged blocks, and set
+the flag threshold.
+`
+}
+
+type addFlagsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Block  string        `required:"true" short:"b" long:"block" description:"Thread block ID."`
+	Reason string        `short:"r" long:"reason" description:"Reason code, one of: spam, abuse, inappropriate, copyright, other."`
+}
+
+func (x *addFlagsCmd) Usage() string {
+	return `
+
+Adds a flag to a thread block, with an optional reason code.`
+}
+
+func (x *addFlagsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	var info *core.ThreadFlagInfo
+	res, err := executeJsonCmd(POST, "blocks/"+x.Block+"/flags", params{
+		opts: map[string]string{"reason": x.Reason},
+	}, &info)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
+
+type lsFlagsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Block  string        `required:"true" short:"b" long:"block" description:"Thread block ID."`
+}
+
+func (x *lsFlagsCmd) Usage() string {
+	return `
+
+Lists flags on a thread block.`
+}
+
+func (x *lsFlagsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	var list []core.ThreadFlagInfo
+	res, err := executeJsonCmd(GET, "blocks/"+x.Block+"/flags", params{}, &list)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
+
+type rmFlagsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Block  string        `required:"true" short:"b" long:"block" description:"Thread block ID."`
+}
+
+func (x *rmFlagsCmd) Usage() string {
+	return `
+
+Removes your flag from a thread block.
+This adds an "undo" thread block targeted at the flag.`
+}
+
+func (x *rmFlagsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	var info *core.BlockInfo
+	res, err := executeJsonCmd(DEL, "blocks/"+x.Block+"/flags", params{}, &info)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
+
+type reviewFlagsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Thread string        `short:"t" long:"thread" description:"Thread ID. Omit for default."`
+}
+
+func (x *reviewFlagsCmd) Usage() string {
+	return `
+
+Lists flagged blocks in a thread with their reporters and reasons,
+most recently flagged first. Includes blocks hidden by flags.
+Only the thread initiator and moderators may review flags.
+Omit the --thread option to use the default thread (if selected).`
+}
+
+func (x *reviewFlagsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if x.Thread == "" {
+		x.Thread = "default"
+	}
+	var list []core.ThreadFlaggedInfo
+	res, err := executeJsonCmd(GET, "threads/"+x.Thread+"/flags", params{}, &list)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
+
+type thresholdFlagsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Thread string        `short:"t" long:"thread" description:"Thread ID. Omit for default."`
+}
+
+func (x *thresholdFlagsCmd) Usage() string {
+	return `
+
+Sets the number of flags from distinct peers that hide a block.
+Use 0 to only hide blocks flagged by moderators.
+Only the thread initiator and moderators may set the threshold.
+Omit the --thread option to use the default thread (if selected).`
+}
+
+func (x *thresholdFlagsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if len(args) == 0 {
+		return errMissingFlagThreshold
+	}
+	if x.Thread == "" {
+		x.Thread = "default"
+	}
+	var info *core.BlockInfo
+	res, err := executeJsonCmd(PUT, "threads/"+x.Thread+"/flags", params{
+		opts: map[string]string{"threshold": args[0]},
+	}, &info)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
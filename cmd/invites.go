@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"errors"
+	"strconv"
+	"strings"
 
 	"github.com/textileio/textile-go/core"
 )
@@ -17,6 +19,7 @@ type invitesCmd struct {
 	List   lsInvitesCmd     `command:"ls" description:"List thread invites"`
 	Accept acceptInvitesCmd `command:"accept" description:"Accept an invite to a thread"`
 	Ignore ignoreInvitesCmd `command:"ignore" description:"Ignore direct invite to a thread"`
+	Revoke revokeInvitesCmd `command:"revoke" description:"Revoke an external invite to a thread"`
 }
 
 func (x *invitesCmd) Name() string {
@@ -37,7 +40,8 @@ Peer-to-peer invites are encrypted with the invitee's public key.
 External invites are encrypted with a single-use key and are useful for 
 onboarding new users. Careful though. Once an external invite and its key are
 shared, the thread should be considered public, since any number of peers
-can use it to join.
+can use it to join. Limit this with an expiry and a maximum number of uses,
+or revoke the invite once it is no longer needed.
 `
 }
 
@@ -45,6 +49,8 @@ type createInvitesCmd struct {
 	Client ClientOptions `group:"Client Options"`
 	Thread string        `short:"t" long:"thread" description:"Thread ID. Omit for default."`
 	Peer   string        `short:"p" long:"peer" description:"Peer ID. Omit to create an external invite."`
+	TTL    int64         `long:"ttl" description:"Seconds until an external invite expires. Omit for no expiry."`
+	Uses   int           `short:"u" long:"uses" description:"Maximum number of joins with an external invite. Omit for no limit."`
 }
 
 func (x *createInvitesCmd) Usage() string {
@@ -52,6 +58,9 @@ func (x *createInvitesCmd) Usage() string {
 
 Creates a direct peer-to-peer or external invite to a thread.
 Omit the --peer option to create an external invite.
+Use the --ttl and --uses options to limit an external invite.
+External invites include a single invite link and textile:// URI,
+which bundle the invite id and key for sharing.
 Omit the --thread option to use the default thread (if selected).
 `
 }
@@ -66,6 +75,8 @@ func (x *createInvitesCmd) Execute(args []string) error {
 		opts: map[string]string{
 			"thread": x.Thread,
 			"peer":   x.Peer,
+			"ttl":    strconv.FormatInt(x.TTL, 10),
+			"uses":   strconv.Itoa(x.Uses),
 		},
 	}, &result)
 	if err != nil {
@@ -77,16 +88,29 @@ func (x *createInvitesCmd) Execute(args []string) error {
 
 type lsInvitesCmd struct {
 	Client ClientOptions `group:"Client Options"`
+	Thread string        `short:"t" long:"thread" description:"Thread ID. List outstanding external invites to this thread."`
 }
 
 func (x *lsInvitesCmd) Usage() string {
 	return `
 
-Lists all pending thread invites.`
+Lists all pending thread invites.
+Use the --thread option to instead list outstanding external invites
+to a thread, along with their use counts.`
 }
 
 func (x *lsInvitesCmd) Execute(_ []string) error {
 	setApi(x.Client)
+	if x.Thread != "" {
+		var list []core.ThreadExternalInviteInfo
+		res, err := executeJsonCmd(GET, "threads/"+x.Thread+"/invites", params{}, &list)
+		if err != nil {
+			return err
+		}
+		output(res)
+		return nil
+	}
+
 	var list []core.ThreadInviteInfo
 	res, err := executeJsonCmd(GET, "invites", params{}, &list)
 	if err != nil {
@@ -105,7 +129,8 @@ func (x *acceptInvitesCmd) Usage() string {
 	return `
 
 Accepts a direct peer-to-peer or external invite to a thread.
-Use the --key option with an external invite.
+Use the --key option with an external invite id, or pass an invite
+link or textile:// URI in place of the id and key.
 `
 }
 
@@ -114,8 +139,9 @@ func (x *acceptInvitesCmd) Execute(args []string) error {
 	if len(args) == 0 {
 		return errMissingInviteId
 	}
+	id := strings.TrimPrefix(args[0], core.InviteURIPrefix)
 	var info core.BlockInfo
-	res, err := executeJsonCmd(POST, "invites/"+args[0]+"/accept", params{
+	res, err := executeJsonCmd(POST, "invites/"+id+"/accept", params{
 		args: args,
 		opts: map[string]string{
 			"key": x.Key,
@@ -153,3 +179,34 @@ func (x *ignoreInvitesCmd) Execute(args []string) error {
 	output(res)
 	return nil
 }
+
+type revokeInvitesCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Thread string        `short:"t" long:"thread" description:"Thread ID. Omit for default."`
+}
+
+func (x *revokeInvitesCmd) Usage() string {
+	return `
+
+Revokes an external invite created by this peer.
+Thread peers will reject joins with the invite from here on.
+Omit the --thread option to use the default thread (if selected).
+`
+}
+
+func (x *revokeInvitesCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if len(args) == 0 {
+		return errMissingInviteId
+	}
+	if x.Thread == "" {
+		x.Thread = "default"
+	}
+	var info core.BlockInfo
+	res, err := executeJsonCmd(DEL, "threads/"+x.Thread+"/invites/"+args[0], params{}, &info)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
@@ -13,10 +13,11 @@ func init() {
 }
 
 type likesCmd struct {
-	Add    addLikesCmd `command:"add" description:"Add a thread like"`
-	List   lsLikesCmd  `command:"ls" description:"List thread likes"`
-	Get    getLikesCmd `command:"get" description:"Get a thread like"`
-	Ignore rmLikesCmd  `command:"ignore" description:"Ignore a thread like"`
+	Add    addLikesCmd  `command:"add" description:"Add a thread like"`
+	List   lsLikesCmd   `command:"ls" description:"List thread likes"`
+	Get    getLikesCmd  `command:"get" description:"Get a thread like"`
+	Ignore rmLikesCmd   `command:"ignore" description:"Ignore a thread like"`
+	Remove undoLikesCmd `command:"rm" description:"Remove your like from a thread block"`
 }
 
 func (x *likesCmd) Name() string {
@@ -31,7 +32,7 @@ func (x *likesCmd) Long() string {
 	return `
 Likes are added as blocks in a thread, which target
 another block, usually a file(s).
-Use this command to add, list, get, and ignore likes.
+Use this command to add, list, get, ignore, and remove likes.
 `
 }
 
@@ -120,3 +121,26 @@ func (x *rmLikesCmd) Execute(args []string) error {
 	setApi(x.Client)
 	return callRmBlocks(args)
 }
+
+type undoLikesCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Block  string        `required:"true" short:"b" long:"block" description:"Thread block ID. Usually a file(s) block."`
+}
+
+func (x *undoLikesCmd) Usage() string {
+	return `
+
+Removes your like from a thread block.
+This adds an "undo" thread block targeted at the like.`
+}
+
+func (x *undoLikesCmd) Execute(args []string) error {
+	setApi(x.Client)
+	var info *core.BlockInfo
+	res, err := executeJsonCmd(DEL, "blocks/"+x.Block+"/likes", params{}, &info)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
@@ -18,10 +18,11 @@ func init() {
 }
 
 type messagesCmd struct {
-	Add    addMessagesCmd `command:"add" description:"Add a thread message"`
-	List   lsMessagesCmd  `command:"ls" description:"List thread messages"`
-	Get    getMessagesCmd `command:"get" description:"Get a thread message"`
-	Ignore rmMessagesCmd  `command:"ignore" description:"Ignore a thread message"`
+	Add     addMessagesCmd     `command:"add" description:"Add a thread message"`
+	List    lsMessagesCmd      `command:"ls" description:"List thread messages"`
+	Get     getMessagesCmd     `command:"get" description:"Get a thread message"`
+	Replies repliesMessagesCmd `command:"replies" description:"Get a thread message conversation"`
+	Ignore  rmMessagesCmd      `command:"ignore" description:"Ignore a thread message"`
 }
 
 func (x *messagesCmd) Name() string {
@@ -35,13 +36,16 @@ func (x *messagesCmd) Short() string {
 func (x *messagesCmd) Long() string {
 	return `
 Messages are added as blocks in a thread.
+Messages may reply to other messages or comments.
 Use this command to add, list, get, and ignore messages.
 `
 }
 
 type addMessagesCmd struct {
-	Client ClientOptions `group:"Client Options"`
-	Thread string        `short:"t" long:"thread" description:"Thread ID. Omit for default."`
+	Client  ClientOptions `group:"Client Options"`
+	Thread  string        `short:"t" long:"thread" description:"Thread ID. Omit for default."`
+	ReplyTo string        `short:"r" long:"reply-to" description:"Message or comment block ID to reply to."`
+	Ttl     string        `long:"ttl" description:"Seconds until the message expires. Omit for the thread default."`
 }
 
 func (x *addMessagesCmd) Usage() string {
@@ -49,6 +53,8 @@ func (x *addMessagesCmd) Usage() string {
 
 Adds a message to a thread.
 Omit the --thread option to use the default thread (if selected).
+Use the --reply-to option to reply to a message or comment.
+Use the --ttl option to have every peer delete the message once it expires.
 `
 }
 
@@ -62,7 +68,7 @@ func (x *addMessagesCmd) Execute(args []string) error {
 		x.Thread = "default"
 	}
 
-	res, err := callAddMessages(x.Thread, args[0])
+	res, err := callAddMessages(x.Thread, args[0], x.ReplyTo, x.Ttl)
 	if err != nil {
 		return err
 	}
@@ -70,10 +76,11 @@ func (x *addMessagesCmd) Execute(args []string) error {
 	return nil
 }
 
-func callAddMessages(threadId string, body string) (string, error) {
+func callAddMessages(threadId string, body string, replyTo string, ttl string) (string, error) {
 	var info *core.ThreadMessageInfo
 	res, err := executeJsonCmd(POST, "threads/"+threadId+"/messages", params{
 		args: []string{body},
+		opts: map[string]string{"reply_to": replyTo, "ttl": ttl},
 	}, &info)
 	if err != nil {
 		return "", err
@@ -83,9 +90,11 @@ func callAddMessages(threadId string, body string) (string, error) {
 
 type lsMessagesCmd struct {
 	Client ClientOptions `group:"Client Options"`
+	Query  QueryOptions  `group:"Query Options"`
 	Thread string        `short:"t" long:"thread" description:"Thread ID. Omit for all."`
 	Offset string        `short:"o" long:"offset" description:"Offset ID to start listing from."`
 	Limit  int           `short:"l" long:"limit" description:"List page size." default:"10"`
+	Order  string        `long:"order" description:"List order, date or causal." default:"date"`
 }
 
 func (x *lsMessagesCmd) Usage() string {
@@ -99,11 +108,12 @@ Specify "default" to use the default thread (if selected).
 
 func (x *lsMessagesCmd) Execute(args []string) error {
 	setApi(x.Client)
-	opts := map[string]string{
+	opts := x.Query.addTo(map[string]string{
 		"thread": x.Thread,
 		"offset": x.Offset,
 		"limit":  strconv.Itoa(x.Limit),
-	}
+		"order":  x.Order,
+	})
 	return callLsMessages(opts)
 }
 
@@ -130,11 +140,7 @@ func callLsMessages(opts map[string]string) error {
 		return err
 	}
 
-	return callLs(map[string]string{
-		"thread": opts["thread"],
-		"offset": list[len(list)-1].Id,
-		"limit":  opts["limit"],
-	})
+	return callLsMessages(nextPage(opts, list[len(list)-1].Id))
 }
 
 type getMessagesCmd struct {
@@ -161,6 +167,31 @@ func (x *getMessagesCmd) Execute(args []string) error {
 	return nil
 }
 
+type repliesMessagesCmd struct {
+	Client ClientOptions `group:"Client Options"`
+}
+
+func (x *repliesMessagesCmd) Usage() string {
+	return `
+
+Gets the conversation below a thread message or comment by block ID.
+Replies are nested, oldest first.`
+}
+
+func (x *repliesMessagesCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if len(args) == 0 {
+		return errMissingMessageId
+	}
+	var info *core.ThreadReplyInfo
+	res, err := executeJsonCmd(GET, "blocks/"+args[0]+"/replies", params{}, &info)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
+
 type rmMessagesCmd struct {
 	Client ClientOptions `group:"Client Options"`
 }
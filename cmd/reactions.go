@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/textileio/textile-go/core"
+)
+
+var errMissingReaction = errors.New("missing reaction")
+
+func init() {
+	register(&reactionsCmd{})
+}
+
+type reactionsCmd struct {
+	Add    addReactionsCmd `command:"add" description:"Add a thread reaction"`
+	List   lsReactionsCmd  `command:"ls" description:"List thread reactions"`
+	Remove rmReactionsCmd  `command:"rm" description:"Remove a thread reaction"`
+}
+
+func (x *reactionsCmd) Name() string {
+	return "reactions"
+}
+
+func (x *reactionsCmd) Short() string {
+	return "Manage thread reactions"
+}
+
+func (x *reactionsCmd) Long() string {
+	return `
+Reactions are likes with an emoji or short code, which
+can target any block, including messages and comments.
+Plain likes are listed as the "like" reaction.
+Use this command to add, list, and remove reactions.
+`
+}
+
+type addReactionsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Block  string        `required:"true" short:"b" long:"block" description:"Thread block ID."`
+}
+
+func (x *addReactionsCmd) Usage() string {
+	return `
+
+Adds a reaction to a thread block.`
+}
+
+func (x *addReactionsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if len(args) == 0 {
+		return errMissingReaction
+	}
+	var info *core.ThreadLikeInfo
+	res, err := executeJsonCmd(POST, "blocks/"+x.Block+"/reactions", params{
+		args: []string{args[0]},
+	}, &info)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
+
+type lsReactionsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Block  string        `required:"true" short:"b" long:"block" description:"Thread block ID."`
+}
+
+func (x *lsReactionsCmd) Usage() string {
+	return `
+
+Lists reaction counts on a thread block, most popular first.`
+}
+
+func (x *lsReactionsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	var list []core.ThreadReactionInfo
+	res, err := executeJsonCmd(GET, "blocks/"+x.Block+"/reactions", params{}, &list)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
+
+type rmReactionsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Block  string        `required:"true" short:"b" long:"block" description:"Thread block ID."`
+}
+
+func (x *rmReactionsCmd) Usage() string {
+	return `
+
+Removes your reaction from a thread block.
+This adds an "ignore" thread block targeted at the reaction.
+`
+}
+
+func (x *rmReactionsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if len(args) == 0 {
+		return errMissingReaction
+	}
+	var info *core.BlockInfo
+	res, err := executeJsonCmd(DEL, "blocks/"+x.Block+"/reactions/"+url.PathEscape(args[0]), params{}, &info)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
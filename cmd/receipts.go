@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/textileio/textile-go/core"
+)
+
+func init() {
+	register(&receiptsCmd{})
+}
+
+type receiptsCmd struct {
+	Add  addReceiptsCmd `command:"add" description:"Mark a thread as read up to a block"`
+	List lsReceiptsCmd  `command:"ls" description:"List thread read receipts"`
+	Off  offReceiptsCmd `command:"off" description:"Stop sending read receipts in a thread"`
+	On   onReceiptsCmd  `command:"on" description:"Resume sending read receipts in a thread"`
+}
+
+func (x *receiptsCmd) Name() string {
+	return "receipts"
+}
+
+func (x *receiptsCmd) Short() string {
+	return "Manage thread read receipts"
+}
+
+func (x *receiptsCmd) Long() string {
+	return `
+Read receipts let thread peers know the newest block you have read.
+They are sent directly to peers and are not part of the thread history.
+Use this command to add and list receipts, or to turn them off per thread.
+`
+}
+
+type addReceiptsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Thread string        `short:"t" long:"thread" description:"Thread ID. Omit for default."`
+}
+
+func (x *addReceiptsCmd) Usage() string {
+	return `
+
+Marks a thread as read up to the given block ID.
+Omit the block ID to mark the whole thread as read.
+Omit the --thread option to use the default thread (if selected).`
+}
+
+func (x *addReceiptsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if x.Thread == "" {
+		x.Thread = "default"
+	}
+	var info *core.ThreadReceiptInfo
+	res, err := executeJsonCmd(POST, "threads/"+x.Thread+"/receipts", params{
+		args: args,
+	}, &info)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
+
+type lsReceiptsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Thread string        `short:"t" long:"thread" description:"Thread ID. Omit for default."`
+}
+
+func (x *lsReceiptsCmd) Usage() string {
+	return `
+
+Lists the newest block read by each peer in a thread.
+Omit the --thread option to use the default thread (if selected).`
+}
+
+func (x *lsReceiptsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if x.Thread == "" {
+		x.Thread = "default"
+	}
+	var list []core.ThreadReceiptInfo
+	res, err := executeJsonCmd(GET, "threads/"+x.Thread+"/receipts", params{}, &list)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
+
+type offReceiptsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Thread string        `short:"t" long:"thread" description:"Thread ID. Omit for default."`
+}
+
+func (x *offReceiptsCmd) Usage() string {
+	return `
+
+Stops sending read receipts in a thread.
+Omit the --thread option to use the default thread (if selected).`
+}
+
+func (x *offReceiptsCmd) Execute(args []string) error {
+	return callSetReceiptsOff(x.Client, x.Thread, true)
+}
+
+type onReceiptsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Thread string        `short:"t" long:"thread" description:"Thread ID. Omit for default."`
+}
+
+func (x *onReceiptsCmd) Usage() string {
+	return `
+
+Resumes sending read receipts in a thread.
+Omit the --thread option to use the default thread (if selected).`
+}
+
+func (x *onReceiptsCmd) Execute(args []string) error {
+	return callSetReceiptsOff(x.Client, x.Thread, false)
+}
+
+func callSetReceiptsOff(client ClientOptions, threadId string, off bool) error {
+	setApi(client)
+	if threadId == "" {
+		threadId = "default"
+	}
+	var info *core.ThreadInfo
+	res, err := executeJsonCmd(PUT, "threads/"+threadId+"/receipts", params{
+		opts: map[string]string{"off": strconv.FormatBool(off)},
+	}, &info)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/textileio/textile-go/core"
+)
+
+func init() {
+	register(&requestsCmd{})
+}
+
+var errMissingRequestId = errors.New("missing join request id")
+
+type requestsCmd struct {
+	Add     addRequestsCmd     `command:"add" description:"Request to join a thread"`
+	List    lsRequestsCmd      `command:"ls" description:"List join requests to a thread"`
+	Approve approveRequestsCmd `command:"approve" description:"Approve a join request with an invite"`
+	Deny    denyRequestsCmd    `command:"deny" description:"Deny a join request"`
+}
+
+func (x *requestsCmd) Name() string {
+	return "requests"
+}
+
+func (x *requestsCmd) Short() string {
+	return "Manage thread join requests"
+}
+
+func (x *requestsCmd) Long() string {
+	return `
+Join requests let a peer ask a thread admin for an invite.
+Requests are sent directly to the admin, or to the admin's cafe inboxes
+if known. An approved request is answered with a direct invite.
+Decided requests are kept as an audit trail of who approved or denied them.
+`
+}
+
+type addRequestsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Thread string        `required:"true" short:"t" long:"thread" description:"Thread ID."`
+	Peer   string        `required:"true" short:"p" long:"peer" description:"Peer ID of a thread admin."`
+	Note   string        `short:"n" long:"note" description:"An optional note for the admin."`
+}
+
+func (x *addRequestsCmd) Usage() string {
+	return `
+
+Sends a request to join a thread to one of its admins.`
+}
+
+func (x *addRequestsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	res, err := executeStringCmd(POST, "threads/"+x.Thread+"/requests", params{
+		args: []string{x.Note},
+		opts: map[string]string{"peer": x.Peer},
+	})
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
+
+type lsRequestsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Thread string        `short:"t" long:"thread" description:"Thread ID. Omit for default."`
+	All    bool          `short:"a" long:"all" description:"Include approved and denied requests."`
+}
+
+func (x *lsRequestsCmd) Usage() string {
+	return `
+
+Lists pending join requests to a thread.
+Use the --all option to include decided requests.
+Omit the --thread option to use the default thread (if selected).`
+}
+
+func (x *lsRequestsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if x.Thread == "" {
+		x.Thread = "default"
+	}
+	var list []core.ThreadJoinRequestInfo
+	res, err := executeJsonCmd(GET, "threads/"+x.Thread+"/requests", params{
+		opts: map[string]string{"all": strconv.FormatBool(x.All)},
+	}, &list)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
+
+type approveRequestsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Thread string        `short:"t" long:"thread" description:"Thread ID. Omit for default."`
+}
+
+func (x *approveRequestsCmd) Usage() string {
+	return `
+
+Approves a pending join request by sending the requester a direct invite.
+Omit the --thread option to use the default thread (if selected).`
+}
+
+func (x *approveRequestsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if len(args) == 0 {
+		return errMissingRequestId
+	}
+	if x.Thread == "" {
+		x.Thread = "default"
+	}
+	var info core.ThreadJoinRequestInfo
+	res, err := executeJsonCmd(POST, "threads/"+x.Thread+"/requests/"+args[0]+"/approve", params{}, &info)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
+
+type denyRequestsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Thread string        `short:"t" long:"thread" description:"Thread ID. Omit for default."`
+	Note   string        `short:"n" long:"note" description:"An optional note for the requester."`
+}
+
+func (x *denyRequestsCmd) Usage() string {
+	return `
+
+Denies a pending join request and lets the requester know.
+Omit the --thread option to use the default thread (if selected).`
+}
+
+func (x *denyRequestsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if len(args) == 0 {
+		return errMissingRequestId
+	}
+	if x.Thread == "" {
+		x.Thread = "default"
+	}
+	var info core.ThreadJoinRequestInfo
+	res, err := executeJsonCmd(POST, "threads/"+x.Thread+"/requests/"+args[0]+"/deny", params{
+		args: []string{x.Note},
+	}, &info)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/textileio/textile-go/core"
+)
+
+var errMissingSearchQuery = errors.New("missing search query")
+
+func init() {
+	register(&searchCmd{})
+}
+
+type searchCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Thread string        `short:"t" long:"thread" description:"Thread ID. Omit for all."`
+	Author string        `short:"a" long:"author" description:"Author peer ID. Omit for all."`
+	Type   []string      `short:"k" long:"type" description:"A block type to filter for. Omit for all."`
+	Since  string        `long:"since" description:"Only match blocks on or after this RFC3339 date."`
+	Until  string        `long:"until" description:"Only match blocks on or before this RFC3339 date."`
+	Limit  int           `short:"l" long:"limit" description:"List page size." default:"10"`
+}
+
+func (x *searchCmd) Name() string {
+	return "search"
+}
+
+func (x *searchCmd) Short() string {
+	return "Search thread content"
+}
+
+func (x *searchCmd) Long() string {
+	return `
+Searches messages, comments, file captions, and file names and meta
+in all threads, best match first. Each result includes a snippet of
+the matched text.
+
+The query supports full-text syntax, e.g., "harbor tour", harbo*, or
+harbor OR lighthouse.
+
+There are several searchable block types:
+
+-  MESSAGE
+-  FILES
+-  COMMENT
+
+Use the --thread and --author options to limit results to a thread or author.
+
+Use the --type option to limit results to specific block type(s).
+This option can be used multiple times, e.g., --type message --type comment.
+
+Use the --since and --until options to limit results to a date range.
+`
+}
+
+func (x *searchCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if len(args) == 0 {
+		return errMissingSearchQuery
+	}
+	opts := map[string]string{
+		"thread": x.Thread,
+		"author": x.Author,
+		"type":   strings.Join(x.Type, "|"),
+		"since":  x.Since,
+		"until":  x.Until,
+		"limit":  strconv.Itoa(x.Limit),
+	}
+	var list []core.SearchResultInfo
+	res, err := executeJsonCmd(GET, "search?q="+url.QueryEscape(strings.Join(args, " ")), params{
+		opts: opts,
+	}, &list)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
@@ -0,0 +1,55 @@
+package cmd
+
+func init() {
+	register(&signalsCmd{})
+}
+
+type signalsCmd struct {
+	Send sendSignalsCmd `command:"send" description:"Send a signal to connected thread peers"`
+}
+
+func (x *signalsCmd) Name() string {
+	return "signals"
+}
+
+func (x *signalsCmd) Short() string {
+	return "Send ephemeral thread signals"
+}
+
+func (x *signalsCmd) Long() string {
+	return `
+Signals carry transient state, like typing indicators, presence, and live cursors.
+They are encrypted with the thread key and sent only to connected thread peers.
+Signals are never stored or queued, and are not part of the thread history.
+Use the sub command to receive signals with the SIGNAL type.
+`
+}
+
+type sendSignalsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Thread string        `short:"t" long:"thread" description:"Thread ID. Omit for default."`
+	Type   string        `long:"type" description:"Signal type: typing, presence, or cursor." default:"typing"`
+}
+
+func (x *sendSignalsCmd) Usage() string {
+	return `
+
+Sends a signal with optional data to connected thread peers.
+Omit the --thread option to use the default thread (if selected).`
+}
+
+func (x *sendSignalsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if x.Thread == "" {
+		x.Thread = "default"
+	}
+	res, err := executeStringCmd(POST, "threads/"+x.Thread+"/signals", params{
+		args: args,
+		opts: map[string]string{"type": x.Type},
+	})
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
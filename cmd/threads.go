@@ -3,17 +3,22 @@ package cmd
 import (
 	"bytes"
 	"errors"
+	"io"
 	"io/ioutil"
 	"os"
+	"strconv"
 
 	"github.com/jessevdk/go-flags"
 	"github.com/mitchellh/go-homedir"
 	"github.com/textileio/textile-go/core"
 	"github.com/textileio/textile-go/repo"
 	"github.com/textileio/textile-go/schema/textile"
+	"github.com/textileio/textile-go/util"
 )
 
 var errMissingThreadId = errors.New("missing thread id")
+var errMissingArchivePath = errors.New("missing archive path")
+var errMissingRole = errors.New("missing role")
 
 func init() {
 	register(&threadsCmd{})
@@ -24,7 +29,15 @@ type threadsCmd struct {
 	List       lsThreadsCmd         `command:"ls" description:"List threads"`
 	Get        getThreadsCmd        `command:"get" description:"Get a thread"`
 	GetDefault getDefaultThreadsCmd `command:"default" description:"Get default thread"`
+	Update     updateThreadsCmd     `command:"update" description:"Update thread name, description, cover, or ttl"`
 	Peers      peersThreadsCmd      `command:"peers" description:"List thread peers"`
+	Kick       kickThreadsCmd       `command:"kick" description:"Remove a peer from a thread"`
+	Role       roleThreadsCmd       `command:"role" description:"Grant or revoke a thread peer role"`
+	Verify     verifyThreadsCmd     `command:"verify" description:"Verify the history of a thread"`
+	Checkpoint checkpointThreadsCmd `command:"checkpoint" description:"Add a thread checkpoint for fast joins"`
+	History    historyThreadsCmd    `command:"history" description:"Load thread history behind checkpoints"`
+	Export     exportThreadsCmd     `command:"export" description:"Export a thread to an archive"`
+	Import     importThreadsCmd     `command:"import" description:"Import a thread from an archive"`
 	Remove     rmThreadsCmd         `command:"rm" description:"Remove a thread"`
 }
 
@@ -45,6 +58,12 @@ Use this command to add, list, get, join, invite, and remove threads.
 Open threads are the most common thread type. Open threads allow 
 any member to invite new members.
 
+Public threads allow any member to comment and like, but only the 
+initiator may add files.
+
+Read-only threads only accept messages, files, and annotations from 
+the initiator, which is useful for announcement channels.
+
 Private threads are primarily used internally for backup/recovery 
 purposes and 1-to-1 communication channels.
 `
@@ -54,6 +73,7 @@ type addThreadsCmd struct {
 	Client     ClientOptions  `group:"Client Options"`
 	Key        string         `short:"k" long:"key" description:"A locally unique key used by an app to identify this thread on recovery."`
 	Open       bool           `short:"o" long:"open" description:"Set the thread type to open (default private)."`
+	Type       string         `short:"t" long:"type" description:"Set the thread type to one of: private, readonly, public, open. Supersedes the open flag."`
 	Schema     flags.Filename `short:"s" long:"schema" description:"Thread Schema filename. Supersedes the built-in schema flags."`
 	Media      bool           `long:"media" description:"Use the built-in media Schema."`
 	CameraRoll bool           `long:"camera-roll" description:"Use the built-in camera roll Schema."`
@@ -71,6 +91,9 @@ func (x *addThreadsCmd) Execute(args []string) error {
 	if x.Open {
 		ttype = "open"
 	}
+	if x.Type != "" {
+		ttype = x.Type
+	}
 
 	var sch string
 	switch x.Schema {
@@ -211,6 +234,53 @@ func (x *getDefaultThreadsCmd) Execute(args []string) error {
 	return nil
 }
 
+type updateThreadsCmd struct {
+	Client      ClientOptions `group:"Client Options"`
+	Thread      string        `short:"t" long:"thread" description:"Thread ID. Omit for default."`
+	Name        string        `short:"n" long:"name" description:"New thread name."`
+	Description string        `short:"d" long:"description" description:"New thread description."`
+	Cover       string        `short:"c" long:"cover" description:"Files target to use as the thread cover."`
+	Ttl         string        `long:"ttl" description:"Seconds until new messages and files expire. Use 0 for never."`
+}
+
+func (x *updateThreadsCmd) Usage() string {
+	return `
+
+Updates the name, description, cover, or ttl of a thread.
+Omitted options keep their current values.
+Messages and files added with a ttl are deleted by every peer once they expire.
+Only the thread initiator and moderators are allowed to update a thread.
+Omit the --thread option to use the default thread (if selected).
+`
+}
+
+func (x *updateThreadsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if x.Thread == "" {
+		x.Thread = "default"
+	}
+	opts := make(map[string]string)
+	if x.Name != "" {
+		opts["name"] = x.Name
+	}
+	if x.Description != "" {
+		opts["description"] = x.Description
+	}
+	if x.Cover != "" {
+		opts["cover"] = x.Cover
+	}
+	if x.Ttl != "" {
+		opts["ttl"] = x.Ttl
+	}
+	var info *core.BlockInfo
+	res, err := executeJsonCmd(PUT, "threads/"+x.Thread, params{opts: opts}, &info)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
+
 type peersThreadsCmd struct {
 	Client ClientOptions `group:"Client Options"`
 	Thread string        `short:"t" long:"thread" description:"Thread ID. Omit for default."`
@@ -238,6 +308,250 @@ func (x *peersThreadsCmd) Execute(args []string) error {
 	return nil
 }
 
+type kickThreadsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Thread string        `short:"t" long:"thread" description:"Thread ID. Omit for default."`
+}
+
+func (x *kickThreadsCmd) Usage() string {
+	return `
+
+Removes a peer from a thread and rotates the thread key.
+Only the thread initiator is allowed to remove peers.
+Omit the --thread option to use the default thread (if selected).
+`
+}
+
+func (x *kickThreadsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if len(args) == 0 {
+		return errMissingPeerId
+	}
+	if x.Thread == "" {
+		x.Thread = "default"
+	}
+	var info *core.BlockInfo
+	res, err := executeJsonCmd(DEL, "threads/"+x.Thread+"/peers/"+args[0], params{}, &info)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
+
+type roleThreadsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Thread string        `short:"t" long:"thread" description:"Thread ID. Omit for default."`
+}
+
+func (x *roleThreadsCmd) Usage() string {
+	return `
+
+Grants a role to a thread peer, one of: reader, writer, moderator, admin.
+Use the default role to revoke a previously granted role.
+Only thread admins are allowed to change roles.
+Omit the --thread option to use the default thread (if selected).
+`
+}
+
+func (x *roleThreadsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if len(args) == 0 {
+		return errMissingPeerId
+	}
+	if len(args) == 1 {
+		return errMissingRole
+	}
+	if x.Thread == "" {
+		x.Thread = "default"
+	}
+	var info *core.BlockInfo
+	res, err := executeJsonCmd(POST, "threads/"+x.Thread+"/peers/"+args[0]+"/roles", params{
+		args: []string{args[1]},
+	}, &info)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
+
+type verifyThreadsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Repair bool          `short:"r" long:"repair" description:"Re-fetch missing blocks and files, and re-index orphaned blocks."`
+}
+
+func (x *verifyThreadsCmd) Usage() string {
+	return `
+
+Verifies that the history of a thread is complete by walking it from head.
+Reports missing parents, orphaned (unindexed) blocks, undecryptable blocks,
+and file targets missing from the local IPFS blockstore.
+Use the --repair option to re-fetch missing pieces from peers or cafes.
+`
+}
+
+func (x *verifyThreadsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if len(args) == 0 {
+		return errMissingThreadId
+	}
+	var res *core.ThreadVerifyResult
+	out, err := executeJsonCmd(GET, "threads/"+args[0]+"/verify", params{
+		opts: map[string]string{"repair": strconv.FormatBool(x.Repair)},
+	}, &res)
+	if err != nil {
+		return err
+	}
+	output(out)
+	return nil
+}
+
+type checkpointThreadsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+}
+
+func (x *checkpointThreadsCmd) Usage() string {
+	return `
+
+Adds a checkpoint block to a thread, summarizing its members and live
+(non-ignored) messages and files, along with their comments and likes.
+New peers index from the latest checkpoint instead of walking the full history.
+Only thread moderators and admins can add checkpoints.
+`
+}
+
+func (x *checkpointThreadsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if len(args) == 0 {
+		return errMissingThreadId
+	}
+	var info *core.BlockInfo
+	res, err := executeJsonCmd(POST, "threads/"+args[0]+"/checkpoints", params{}, &info)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
+
+type historyThreadsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+}
+
+func (x *historyThreadsCmd) Usage() string {
+	return `
+
+Loads thread history that was skipped when joining from a checkpoint.
+Each call loads history back to the next older checkpoint.
+`
+}
+
+func (x *historyThreadsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if len(args) == 0 {
+		return errMissingThreadId
+	}
+	var info *core.ThreadInfo
+	res, err := executeJsonCmd(POST, "threads/"+args[0]+"/history", params{}, &info)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
+
+type exportThreadsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+	Output string        `short:"o" long:"output" description:"Archive output path. Omit for stdout."`
+}
+
+func (x *exportThreadsCmd) Usage() string {
+	return `
+
+Exports a thread to a tar archive, including the thread keys,
+all encrypted blocks, the schema, and all file data.
+Archives contain thread keys, so keep them private.
+`
+}
+
+func (x *exportThreadsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if len(args) == 0 {
+		return errMissingThreadId
+	}
+
+	req, err := request(GET, "threads/"+args[0]+"/export", params{})
+	if err != nil {
+		return err
+	}
+	defer req.Body.Close()
+	if req.StatusCode >= 400 {
+		res, err := util.UnmarshalString(req.Body)
+		if err != nil {
+			return err
+		}
+		return errors.New(res)
+	}
+
+	out := os.Stdout
+	if x.Output != "" {
+		pth, err := homedir.Expand(x.Output)
+		if err != nil {
+			return err
+		}
+		out, err = os.Create(pth)
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+	}
+	if _, err := io.Copy(out, req.Body); err != nil {
+		return err
+	}
+	return nil
+}
+
+type importThreadsCmd struct {
+	Client ClientOptions `group:"Client Options"`
+}
+
+func (x *importThreadsCmd) Usage() string {
+	return `
+
+Imports a thread from a tar archive created with the export command.
+No network access is needed.
+`
+}
+
+func (x *importThreadsCmd) Execute(args []string) error {
+	setApi(x.Client)
+	if len(args) == 0 {
+		return errMissingArchivePath
+	}
+
+	pth, err := homedir.Expand(args[0])
+	if err != nil {
+		return err
+	}
+	archive, err := os.Open(pth)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	var info *core.ThreadInfo
+	res, err := executeJsonCmd(POST, "threads/import", params{
+		payload: archive,
+		ctype:   "application/x-tar",
+	}, &info)
+	if err != nil {
+		return err
+	}
+	output(res)
+	return nil
+}
+
 type rmThreadsCmd struct {
 	Client ClientOptions `group:"Client Options"`
 }
@@ -105,9 +105,29 @@ func (a *api) Start() {
 		threads := v0.Group("/threads")
 		{
 			threads.POST("", a.addThreads)
+			threads.POST("/import", a.importThreads)
 			threads.GET("", a.lsThreads)
 			threads.GET("/:id", a.getThreads)
+			threads.PUT("/:id", a.updateThreads)
+			threads.GET("/:id/export", a.exportThreads)
+			threads.GET("/:id/verify", a.verifyThreads)
+			threads.POST("/:id/checkpoints", a.addThreadCheckpoints)
+			threads.POST("/:id/history", a.loadThreadHistory)
+			threads.GET("/:id/receipts", a.lsThreadReceipts)
+			threads.POST("/:id/receipts", a.addThreadReceipts)
+			threads.PUT("/:id/receipts", a.updateThreadReceipts)
+			threads.GET("/:id/flags", a.lsThreadFlags)
+			threads.PUT("/:id/flags", a.updateThreadFlags)
+			threads.POST("/:id/signals", a.addThreadSignals)
+			threads.GET("/:id/invites", a.lsThreadInvites)
+			threads.DELETE("/:id/invites/:invite", a.rmThreadInvites)
+			threads.POST("/:id/requests", a.addThreadRequests)
+			threads.GET("/:id/requests", a.lsThreadRequests)
+			threads.POST("/:id/requests/:request/approve", a.approveThreadRequests)
+			threads.POST("/:id/requests/:request/deny", a.denyThreadRequests)
 			threads.GET("/:id/peers", a.peersThreads)
+			threads.DELETE("/:id/peers/:peer", a.rmThreadPeers)
+			threads.POST("/:id/peers/:peer/roles", a.addThreadPeerRoles)
 			threads.DELETE("/:id", a.rmThreads)
 			threads.POST("/:id/messages", a.addThreadMessages)
 			threads.POST("/:id/files", a.addThreadFiles)
@@ -121,6 +141,7 @@ func (a *api) Start() {
 			{
 				block.GET("", a.getBlocks)
 				block.DELETE("", a.rmBlocks)
+				block.POST("/restore", a.restoreBlocks)
 
 				block.GET("/comment", a.getBlockComment)
 				comments := block.Group("/comments")
@@ -129,11 +150,34 @@ func (a *api) Start() {
 					comments.GET("", a.lsBlockComments)
 				}
 
+				edits := block.Group("/edits")
+				{
+					edits.POST("", a.addBlockEdits)
+					edits.GET("", a.lsBlockEdits)
+				}
+
+				block.GET("/replies", a.lsBlockReplies)
+
 				block.GET("/like", a.getBlockLike)
 				likes := block.Group("/likes")
 				{
 					likes.POST("", a.addBlockLikes)
 					likes.GET("", a.lsBlockLikes)
+					likes.DELETE("", a.rmBlockLikes)
+				}
+
+				flags := block.Group("/flags")
+				{
+					flags.POST("", a.addBlockFlags)
+					flags.GET("", a.lsBlockFlags)
+					flags.DELETE("", a.rmBlockFlags)
+				}
+
+				reactions := block.Group("/reactions")
+				{
+					reactions.POST("", a.addBlockReactions)
+					reactions.GET("", a.lsBlockReactions)
+					reactions.DELETE("/:reaction", a.rmBlockReactions)
 				}
 			}
 		}
@@ -161,6 +205,11 @@ func (a *api) Start() {
 			sub.GET("/:id", a.getThreadsSub)
 		}
 
+		search := v0.Group("/search")
+		{
+			search.GET("", a.searchBlocks)
+		}
+
 		invites := v0.Group("/invites")
 		{
 			invites.POST("", a.createInvites)
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (a *api) exportThreads(g *gin.Context) {
+	id := g.Param("id")
+	if id == "default" {
+		id = a.node.config.Threads.Defaults.ID
+	}
+
+	var buf bytes.Buffer
+	if err := a.node.ExportThread(id, &buf); err != nil {
+		if err == ErrThreadNotFound {
+			g.String(http.StatusNotFound, err.Error())
+		} else {
+			a.abort500(g, err)
+		}
+		return
+	}
+
+	g.Header("Content-Disposition", "attachment; filename="+id+".tar")
+	g.Data(http.StatusOK, "application/x-tar", buf.Bytes())
+}
+
+func (a *api) importThreads(g *gin.Context) {
+	thrd, err := a.node.ImportThread(g.Request.Body)
+	if err != nil {
+		switch err {
+		case ErrThreadLoaded:
+			g.String(http.StatusConflict, err.Error())
+		case ErrInvalidThreadArchive:
+			g.String(http.StatusBadRequest, err.Error())
+		default:
+			a.abort500(g, err)
+		}
+		return
+	}
+
+	info, err := thrd.Info()
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+
+	g.JSON(http.StatusCreated, info)
+}
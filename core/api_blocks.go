@@ -1,47 +1,35 @@
 package core
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/textileio/textile-go/repo"
 )
 
 func (a *api) lsBlocks(g *gin.Context) {
-	opts, err := a.readOpts(g)
+	query, err := a.readBlockQuery(g, 5)
 	if err != nil {
-		a.abort500(g, err)
+		g.String(http.StatusBadRequest, err.Error())
 		return
 	}
-
-	threadId := opts["thread"]
-	if threadId == "default" {
-		threadId = a.node.config.Threads.Defaults.ID
-	}
-	if threadId == "" {
+	if len(query.ThreadIds) == 0 {
 		g.String(http.StatusBadRequest, "missing thread id")
 		return
 	}
-
-	thrd := a.node.Thread(threadId)
-	if thrd == nil {
-		g.String(http.StatusNotFound, ErrThreadNotFound.Error())
-		return
-	}
-
-	limit := 5
-	if opts["limit"] != "" {
-		limit, err = strconv.Atoi(opts["limit"])
-		if err != nil {
-			g.String(http.StatusBadRequest, err.Error())
+	for _, id := range query.ThreadIds {
+		if a.node.Thread(id) == nil {
+			g.String(http.StatusNotFound, ErrThreadNotFound.Error())
 			return
 		}
 	}
 
 	infos := make([]BlockInfo, 0)
-	query := fmt.Sprintf("threadId='%s'", thrd.Id)
-	for _, block := range a.node.datastore.Blocks().List(opts["offset"], limit, query) {
+	for _, block := range a.node.Blocks(query) {
 		infos = append(infos, BlockInfo{
 			Id:       block.Id,
 			ThreadId: block.ThreadId,
@@ -52,6 +40,10 @@ func (a *api) lsBlocks(g *gin.Context) {
 			Parents:  block.Parents,
 			Target:   block.Target,
 			Body:     block.Body,
+			Verified: block.Verified,
+			Clock:    block.Clock,
+			Expires:  block.Expires,
+			Hidden:   block.Hidden,
 		})
 	}
 
@@ -93,6 +85,36 @@ func (a *api) rmBlocks(g *gin.Context) {
 	g.JSON(http.StatusCreated, info)
 }
 
+func (a *api) restoreBlocks(g *gin.Context) {
+	id := g.Param("id")
+
+	thrd := a.getBlockThread(g, id)
+	if thrd == nil {
+		return
+	}
+
+	hash, err := thrd.RestoreIgnored(id)
+	if err != nil {
+		switch err {
+		case ErrIgnoreNotFound:
+			g.String(http.StatusNotFound, err.Error())
+		case ErrWriteNotAllowed:
+			g.String(http.StatusForbidden, err.Error())
+		default:
+			a.abort500(g, err)
+		}
+		return
+	}
+
+	info, err := a.node.BlockInfo(hash.B58String())
+	if err != nil {
+		g.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	g.JSON(http.StatusCreated, info)
+}
+
 func (a *api) getBlockThread(g *gin.Context, id string) *Thread {
 	block, err := a.node.Block(id)
 	if err != nil {
@@ -106,3 +128,97 @@ func (a *api) getBlockThread(g *gin.Context, id string) *Thread {
 	}
 	return thrd
 }
+
+// readBlockQuery reads a typed block query from url query params, falling back to opts.
+// List params may be repeated or or'd, e.g., type=MESSAGE|COMMENT. Dates are RFC3339.
+func (a *api) readBlockQuery(g *gin.Context, limit int) (*repo.BlockQuery, error) {
+	opts, err := a.readOpts(g)
+	if err != nil {
+		return nil, err
+	}
+	list := func(key string) []string {
+		vals := g.QueryArray(key)
+		if len(vals) == 0 && opts[key] != "" {
+			vals = []string{opts[key]}
+		}
+		var items []string
+		for _, val := range vals {
+			for _, item := range strings.Split(val, "|") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+		}
+		return items
+	}
+	param := func(key string) string {
+		if items := list(key); len(items) > 0 {
+			return items[0]
+		}
+		return ""
+	}
+	date := func(key string) (*time.Time, error) {
+		if param(key) == "" {
+			return nil, nil
+		}
+		d, err := time.Parse(time.RFC3339, param(key))
+		if err != nil {
+			return nil, err
+		}
+		return &d, nil
+	}
+
+	query := &repo.BlockQuery{
+		AuthorIds: list("author"),
+		Target:    param("target"),
+		Offset:    param("offset"),
+		Limit:     limit,
+	}
+	for _, id := range list("thread") {
+		if id == "default" {
+			id = a.node.config.Threads.Defaults.ID
+		}
+		query.ThreadIds = append(query.ThreadIds, id)
+	}
+	for _, desc := range list("type") {
+		btype, err := repo.BlockTypeFromString(desc)
+		if err != nil {
+			return nil, err
+		}
+		query.Types = append(query.Types, btype)
+	}
+	if query.Since, err = date("since"); err != nil {
+		return nil, err
+	}
+	if query.Until, err = date("until"); err != nil {
+		return nil, err
+	}
+	if param("limit") != "" {
+		query.Limit, err = strconv.Atoi(param("limit"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if query.Order, err = repo.BlockOrderFromString(param("order")); err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(param("dir")) {
+	case "", "desc":
+	case "asc":
+		query.Ascending = true
+	default:
+		return nil, errors.New("could not parse sort direction")
+	}
+	if param("ignored") != "" {
+		if query.Ignored, err = strconv.ParseBool(param("ignored")); err != nil {
+			return nil, err
+		}
+	}
+	if param("hidden") != "" {
+		if query.Hidden, err = strconv.ParseBool(param("hidden")); err != nil {
+			return nil, err
+		}
+	}
+
+	return query, nil
+}
@@ -23,9 +23,18 @@ func (a *api) addBlockComments(g *gin.Context) {
 		g.String(http.StatusBadRequest, "missing comment body")
 		return
 	}
+	opts, err := a.readOpts(g)
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
 
-	hash, err := thrd.AddComment(id, args[0])
+	hash, err := thrd.AddCommentReply(id, opts["reply_to"], args[0])
 	if err != nil {
+		if err == ErrInvalidReplyTarget {
+			g.String(http.StatusBadRequest, err.Error())
+			return
+		}
 		a.abort500(g, err)
 		return
 	}
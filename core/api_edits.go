@@ -0,0 +1,63 @@
+package core
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (a *api) addBlockEdits(g *gin.Context) {
+	id := g.Param("id")
+
+	thrd := a.getBlockThread(g, id)
+	if thrd == nil {
+		return
+	}
+
+	args, err := a.readArgs(g)
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+	if len(args) == 0 {
+		g.String(http.StatusBadRequest, "missing edit body")
+		return
+	}
+
+	hash, err := thrd.AddEdit(id, args[0])
+	if err != nil {
+		switch err {
+		case ErrEditNotAllowed, ErrWriteNotAllowed:
+			g.String(http.StatusForbidden, err.Error())
+		case ErrBlockNotEditable:
+			g.String(http.StatusBadRequest, err.Error())
+		default:
+			a.abort500(g, err)
+		}
+		return
+	}
+
+	info, err := a.node.BlockInfo(hash.B58String())
+	if err != nil {
+		g.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	g.JSON(http.StatusCreated, info)
+}
+
+func (a *api) lsBlockEdits(g *gin.Context) {
+	id := g.Param("id")
+
+	edits, err := a.node.ThreadEdits(id)
+	if err != nil {
+		if err == ErrBlockNotFound {
+			g.String(http.StatusNotFound, "block not found")
+		} else {
+			a.abort500(g, err)
+		}
+		return
+	}
+
+	g.JSON(http.StatusOK, edits)
+}
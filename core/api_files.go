@@ -67,7 +67,16 @@ func (a *api) addThreadFiles(g *gin.Context) {
 		return
 	}
 
-	hash, err := thrd.AddFiles(node, opts["caption"], keys)
+	var ttl int64
+	if v := opts["ttl"]; v != "" {
+		ttl, err = strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			g.String(http.StatusBadRequest, "invalid ttl: "+v)
+			return
+		}
+	}
+
+	hash, err := thrd.AddExpiringFiles(node, opts["caption"], keys, ttl)
 	if err != nil {
 		g.String(http.StatusBadRequest, err.Error())
 		return
@@ -83,36 +92,19 @@ func (a *api) addThreadFiles(g *gin.Context) {
 }
 
 func (a *api) lsThreadFiles(g *gin.Context) {
-	opts, err := a.readOpts(g)
+	query, err := a.readBlockQuery(g, 5)
 	if err != nil {
-		a.abort500(g, err)
+		g.String(http.StatusBadRequest, err.Error())
 		return
 	}
 
-	threadId := opts["thread"]
-	if threadId == "default" {
-		threadId = a.node.config.Threads.Defaults.ID
-	}
-	if threadId != "" {
-		thrd := a.node.Thread(threadId)
-		if thrd == nil {
-			g.String(http.StatusNotFound, ErrThreadNotFound.Error())
-			return
-		}
-	}
-
-	limit := 5
-	if opts["limit"] != "" {
-		limit, err = strconv.Atoi(opts["limit"])
-		if err != nil {
+	list, err := a.node.ThreadFiles(query)
+	if err != nil {
+		if err == ErrThreadNotFound {
+			g.String(http.StatusNotFound, err.Error())
+		} else {
 			g.String(http.StatusBadRequest, err.Error())
-			return
 		}
-	}
-
-	list, err := a.node.ThreadFiles(opts["offset"], limit, threadId)
-	if err != nil {
-		g.String(http.StatusBadRequest, err.Error())
 		return
 	}
 
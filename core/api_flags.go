@@ -0,0 +1,165 @@
+package core
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (a *api) addBlockFlags(g *gin.Context) {
+	id := g.Param("id")
+
+	thrd := a.getBlockThread(g, id)
+	if thrd == nil {
+		return
+	}
+
+	opts, err := a.readOpts(g)
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+	reason, err := FlagReasonFromString(opts["reason"])
+	if err != nil {
+		g.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	hash, err := thrd.AddFlag(id, reason)
+	if err != nil {
+		switch err {
+		case ErrWriteNotAllowed:
+			g.String(http.StatusForbidden, err.Error())
+		case ErrFlagExists:
+			g.String(http.StatusConflict, err.Error())
+		default:
+			a.abort500(g, err)
+		}
+		return
+	}
+
+	block, err := a.node.Block(hash.B58String())
+	if err != nil {
+		g.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	info, err := a.node.ThreadFlag(*block)
+	if err != nil {
+		g.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	g.JSON(http.StatusCreated, info)
+}
+
+func (a *api) lsBlockFlags(g *gin.Context) {
+	id := g.Param("id")
+
+	flags, err := a.node.ThreadFlags(id)
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+
+	g.JSON(http.StatusOK, flags)
+}
+
+func (a *api) rmBlockFlags(g *gin.Context) {
+	id := g.Param("id")
+
+	thrd := a.getBlockThread(g, id)
+	if thrd == nil {
+		return
+	}
+
+	hash, err := thrd.RemoveFlag(id)
+	if err != nil {
+		switch err {
+		case ErrFlagNotFound:
+			g.String(http.StatusNotFound, err.Error())
+		case ErrWriteNotAllowed:
+			g.String(http.StatusForbidden, err.Error())
+		default:
+			a.abort500(g, err)
+		}
+		return
+	}
+
+	info, err := a.node.BlockInfo(hash.B58String())
+	if err != nil {
+		g.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	g.JSON(http.StatusCreated, info)
+}
+
+func (a *api) lsThreadFlags(g *gin.Context) {
+	id := g.Param("id")
+	if id == "default" {
+		id = a.node.config.Threads.Defaults.ID
+	}
+
+	queue, err := a.node.ThreadFlagged(id)
+	if err != nil {
+		switch err {
+		case ErrThreadNotFound:
+			g.String(http.StatusNotFound, err.Error())
+		case ErrReviewNotAllowed:
+			g.String(http.StatusForbidden, err.Error())
+		default:
+			a.abort500(g, err)
+		}
+		return
+	}
+
+	g.JSON(http.StatusOK, queue)
+}
+
+func (a *api) updateThreadFlags(g *gin.Context) {
+	opts, err := a.readOpts(g)
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+
+	id := g.Param("id")
+	if id == "default" {
+		id = a.node.config.Threads.Defaults.ID
+	}
+
+	thrd := a.node.Thread(id)
+	if thrd == nil {
+		g.String(http.StatusNotFound, ErrThreadNotFound.Error())
+		return
+	}
+
+	threshold, err := strconv.Atoi(opts["threshold"])
+	if err != nil {
+		g.String(http.StatusBadRequest, "invalid threshold: "+opts["threshold"])
+		return
+	}
+
+	hash, err := thrd.SetFlagThreshold(threshold)
+	if err != nil {
+		switch err {
+		case ErrMetaNotAllowed:
+			g.String(http.StatusForbidden, err.Error())
+		case ErrInvalidFlagThreshold:
+			g.String(http.StatusBadRequest, err.Error())
+		default:
+			a.abort500(g, err)
+		}
+		return
+	}
+
+	binfo, err := a.node.BlockInfo(hash.B58String())
+	if err != nil {
+		g.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	g.JSON(http.StatusCreated, binfo)
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"net/http"
+	"strconv"
 
 	mh "gx/ipfs/QmPnFwZ2JXKnXgMw8CdBPxn7FWh6LLdjUjxV1fKHuJnkr8/go-multihash"
 	"gx/ipfs/QmTRhk7cgjUf2gfQ3p2M9KPECNZEW9XUrmHcFCgog4cPgB/go-libp2p-peer"
@@ -44,13 +45,36 @@ func (a *api) createInvites(g *gin.Context) {
 		}
 		result["invite"] = hash.B58String()
 	} else {
-		hash, key, err := thrd.AddExternalInvite()
+		var ttl int64
+		if opts["ttl"] != "" {
+			ttl, err = strconv.ParseInt(opts["ttl"], 10, 64)
+			if err != nil {
+				g.String(http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		var uses int
+		if opts["uses"] != "" {
+			uses, err = strconv.Atoi(opts["uses"])
+			if err != nil {
+				g.String(http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		hash, key, err := thrd.AddLimitedExternalInvite(ttl, uses)
+		if err != nil {
+			a.abort500(g, err)
+			return
+		}
+		link, err := thrd.InviteLink(hash, key)
 		if err != nil {
 			a.abort500(g, err)
 			return
 		}
 		result["invite"] = hash.B58String()
 		result["key"] = base58.FastBase58Encoding(key)
+		result["link"] = link
+		result["uri"] = InviteURI(link)
 	}
 
 	g.JSON(http.StatusCreated, result)
@@ -75,7 +99,13 @@ func (a *api) acceptInvites(g *gin.Context) {
 	}
 
 	var hash mh.Multihash
-	if opts["key"] != "" {
+	if IsInviteLink(id) {
+		hash, err = a.node.AcceptExternalThreadInvite(id, nil)
+		if err != nil {
+			g.String(http.StatusBadRequest, err.Error())
+			return
+		}
+	} else if opts["key"] != "" {
 		key, err := base58.Decode(opts["key"])
 		if err != nil {
 			g.String(http.StatusBadRequest, err.Error())
@@ -117,3 +147,56 @@ func (a *api) ignoreInvites(g *gin.Context) {
 
 	g.String(http.StatusOK, "ok")
 }
+
+func (a *api) lsThreadInvites(g *gin.Context) {
+	id := g.Param("id")
+	if id == "default" {
+		id = a.node.config.Threads.Defaults.ID
+	}
+
+	invites, err := a.node.ThreadExternalInvites(id)
+	if err != nil {
+		if err == ErrThreadNotFound {
+			g.String(http.StatusNotFound, err.Error())
+		} else {
+			a.abort500(g, err)
+		}
+		return
+	}
+
+	g.JSON(http.StatusOK, invites)
+}
+
+func (a *api) rmThreadInvites(g *gin.Context) {
+	id := g.Param("id")
+	if id == "default" {
+		id = a.node.config.Threads.Defaults.ID
+	}
+
+	thrd := a.node.Thread(id)
+	if thrd == nil {
+		g.String(http.StatusNotFound, ErrThreadNotFound.Error())
+		return
+	}
+
+	hash, err := thrd.RevokeExternalInvite(g.Param("invite"))
+	if err != nil {
+		switch err {
+		case ErrWriteNotAllowed:
+			g.String(http.StatusForbidden, err.Error())
+		case ErrInviteNotFound:
+			g.String(http.StatusNotFound, err.Error())
+		default:
+			g.String(http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+
+	info, err := a.node.BlockInfo(hash.B58String())
+	if err != nil {
+		g.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	g.JSON(http.StatusOK, info)
+}
@@ -16,7 +16,11 @@ func (a *api) addBlockLikes(g *gin.Context) {
 
 	hash, err := thrd.AddLike(id)
 	if err != nil {
-		a.abort500(g, err)
+		if err == ErrReactionExists {
+			g.String(http.StatusConflict, err.Error())
+		} else {
+			a.abort500(g, err)
+		}
 		return
 	}
 
@@ -50,6 +54,36 @@ func (a *api) lsBlockLikes(g *gin.Context) {
 	g.JSON(http.StatusOK, likes)
 }
 
+func (a *api) rmBlockLikes(g *gin.Context) {
+	id := g.Param("id")
+
+	thrd := a.getBlockThread(g, id)
+	if thrd == nil {
+		return
+	}
+
+	hash, err := thrd.RemoveLike(id)
+	if err != nil {
+		switch err {
+		case ErrReactionNotFound:
+			g.String(http.StatusNotFound, err.Error())
+		case ErrWriteNotAllowed:
+			g.String(http.StatusForbidden, err.Error())
+		default:
+			a.abort500(g, err)
+		}
+		return
+	}
+
+	info, err := a.node.BlockInfo(hash.B58String())
+	if err != nil {
+		g.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	g.JSON(http.StatusCreated, info)
+}
+
 func (a *api) getBlockLike(g *gin.Context) {
 	id := g.Param("id")
 
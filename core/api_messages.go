@@ -17,6 +17,11 @@ func (a *api) addThreadMessages(g *gin.Context) {
 		g.String(http.StatusBadRequest, "missing message body")
 		return
 	}
+	opts, err := a.readOpts(g)
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
 
 	threadId := g.Param("id")
 	if threadId == "default" {
@@ -28,7 +33,16 @@ func (a *api) addThreadMessages(g *gin.Context) {
 		return
 	}
 
-	hash, err := thrd.AddMessage(args[0])
+	var ttl int64
+	if v := opts["ttl"]; v != "" {
+		ttl, err = strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			g.String(http.StatusBadRequest, "invalid ttl: "+v)
+			return
+		}
+	}
+
+	hash, err := thrd.AddExpiringReply(opts["reply_to"], args[0], ttl)
 	if err != nil {
 		g.String(http.StatusBadRequest, err.Error())
 		return
@@ -44,36 +58,19 @@ func (a *api) addThreadMessages(g *gin.Context) {
 }
 
 func (a *api) lsThreadMessages(g *gin.Context) {
-	opts, err := a.readOpts(g)
+	query, err := a.readBlockQuery(g, 10)
 	if err != nil {
-		a.abort500(g, err)
+		g.String(http.StatusBadRequest, err.Error())
 		return
 	}
 
-	threadId := opts["thread"]
-	if threadId == "default" {
-		threadId = a.node.config.Threads.Defaults.ID
-	}
-	if threadId != "" {
-		thrd := a.node.Thread(threadId)
-		if thrd == nil {
-			g.String(http.StatusNotFound, ErrThreadNotFound.Error())
-			return
-		}
-	}
-
-	limit := 10
-	if opts["limit"] != "" {
-		limit, err = strconv.Atoi(opts["limit"])
-		if err != nil {
+	list, err := a.node.ThreadMessages(query)
+	if err != nil {
+		if err == ErrThreadNotFound {
+			g.String(http.StatusNotFound, err.Error())
+		} else {
 			g.String(http.StatusBadRequest, err.Error())
-			return
 		}
-	}
-
-	list, err := a.node.ThreadMessages(opts["offset"], limit, threadId)
-	if err != nil {
-		g.String(http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -97,3 +94,22 @@ func (a *api) getThreadMessages(g *gin.Context) {
 
 	g.JSON(http.StatusOK, info)
 }
+
+func (a *api) lsBlockReplies(g *gin.Context) {
+	id := g.Param("id")
+
+	info, err := a.node.ThreadReplies(id)
+	if err != nil {
+		switch err {
+		case ErrBlockNotFound:
+			g.String(http.StatusNotFound, "block not found")
+		case ErrBlockWrongType:
+			g.String(http.StatusBadRequest, err.Error())
+		default:
+			a.abort500(g, err)
+		}
+		return
+	}
+
+	g.JSON(http.StatusOK, info)
+}
@@ -0,0 +1,97 @@
+package core
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (a *api) addBlockReactions(g *gin.Context) {
+	id := g.Param("id")
+
+	thrd := a.getBlockThread(g, id)
+	if thrd == nil {
+		return
+	}
+
+	args, err := a.readArgs(g)
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+	if len(args) == 0 {
+		g.String(http.StatusBadRequest, "missing reaction")
+		return
+	}
+
+	hash, err := thrd.AddReaction(id, args[0])
+	if err != nil {
+		switch err {
+		case ErrWriteNotAllowed:
+			g.String(http.StatusForbidden, err.Error())
+		case ErrInvalidReaction:
+			g.String(http.StatusBadRequest, err.Error())
+		case ErrReactionExists:
+			g.String(http.StatusConflict, err.Error())
+		default:
+			a.abort500(g, err)
+		}
+		return
+	}
+
+	block, err := a.node.Block(hash.B58String())
+	if err != nil {
+		g.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	info, err := a.node.ThreadLike(*block)
+	if err != nil {
+		g.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	g.JSON(http.StatusCreated, info)
+}
+
+func (a *api) lsBlockReactions(g *gin.Context) {
+	id := g.Param("id")
+
+	reactions, err := a.node.ThreadReactions(id)
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+
+	g.JSON(http.StatusOK, reactions)
+}
+
+func (a *api) rmBlockReactions(g *gin.Context) {
+	id := g.Param("id")
+
+	thrd := a.getBlockThread(g, id)
+	if thrd == nil {
+		return
+	}
+
+	hash, err := thrd.RemoveReaction(id, g.Param("reaction"))
+	if err != nil {
+		switch err {
+		case ErrReactionNotFound:
+			g.String(http.StatusNotFound, err.Error())
+		case ErrWriteNotAllowed:
+			g.String(http.StatusForbidden, err.Error())
+		default:
+			a.abort500(g, err)
+		}
+		return
+	}
+
+	info, err := a.node.BlockInfo(hash.B58String())
+	if err != nil {
+		g.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	g.JSON(http.StatusCreated, info)
+}
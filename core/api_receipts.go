@@ -0,0 +1,114 @@
+package core
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (a *api) addThreadReceipts(g *gin.Context) {
+	args, err := a.readArgs(g)
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+
+	id := g.Param("id")
+	if id == "default" {
+		id = a.node.config.Threads.Defaults.ID
+	}
+
+	thrd := a.node.Thread(id)
+	if thrd == nil {
+		g.String(http.StatusNotFound, ErrThreadNotFound.Error())
+		return
+	}
+
+	// default to the thread head
+	var blockId string
+	if len(args) > 0 {
+		blockId = args[0]
+	} else {
+		info, err := thrd.Info()
+		if err != nil {
+			a.abort500(g, err)
+			return
+		}
+		if info.Head == nil {
+			g.String(http.StatusBadRequest, "thread has no blocks")
+			return
+		}
+		blockId = info.Head.Id
+	}
+
+	receipt, err := thrd.MarkRead(blockId)
+	if err != nil {
+		switch err {
+		case ErrReceiptsOff:
+			g.String(http.StatusForbidden, err.Error())
+		case ErrBlockNotFound:
+			g.String(http.StatusNotFound, err.Error())
+		default:
+			a.abort500(g, err)
+		}
+		return
+	}
+
+	g.JSON(http.StatusCreated, ThreadReceiptInfo{
+		PeerId:   receipt.PeerId,
+		Username: a.node.ContactUsername(receipt.PeerId),
+		BlockId:  receipt.BlockId,
+		Date:     receipt.Date,
+	})
+}
+
+func (a *api) lsThreadReceipts(g *gin.Context) {
+	id := g.Param("id")
+	if id == "default" {
+		id = a.node.config.Threads.Defaults.ID
+	}
+
+	receipts, err := a.node.ThreadReceipts(id)
+	if err != nil {
+		if err == ErrThreadNotFound {
+			g.String(http.StatusNotFound, err.Error())
+		} else {
+			a.abort500(g, err)
+		}
+		return
+	}
+
+	g.JSON(http.StatusOK, receipts)
+}
+
+func (a *api) updateThreadReceipts(g *gin.Context) {
+	opts, err := a.readOpts(g)
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+
+	id := g.Param("id")
+	if id == "default" {
+		id = a.node.config.Threads.Defaults.ID
+	}
+
+	thrd := a.node.Thread(id)
+	if thrd == nil {
+		g.String(http.StatusNotFound, ErrThreadNotFound.Error())
+		return
+	}
+
+	if err := thrd.SetReceiptsOff(opts["off"] == "true"); err != nil {
+		a.abort500(g, err)
+		return
+	}
+
+	info, err := thrd.Info()
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+
+	g.JSON(http.StatusOK, info)
+}
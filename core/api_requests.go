@@ -0,0 +1,116 @@
+package core
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (a *api) addThreadRequests(g *gin.Context) {
+	args, err := a.readArgs(g)
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+	opts, err := a.readOpts(g)
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+
+	var note string
+	if len(args) > 0 {
+		note = args[0]
+	}
+
+	if err := a.node.RequestThreadJoin(g.Param("id"), opts["peer"], note); err != nil {
+		if err == ErrThreadJoined {
+			g.String(http.StatusConflict, err.Error())
+		} else {
+			g.String(http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+
+	g.String(http.StatusCreated, "ok")
+}
+
+func (a *api) lsThreadRequests(g *gin.Context) {
+	opts, err := a.readOpts(g)
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+
+	id := g.Param("id")
+	if id == "default" {
+		id = a.node.config.Threads.Defaults.ID
+	}
+
+	reqs, err := a.node.ThreadJoinRequests(id, opts["all"] == "true")
+	if err != nil {
+		if err == ErrThreadNotFound {
+			g.String(http.StatusNotFound, err.Error())
+		} else {
+			a.abort500(g, err)
+		}
+		return
+	}
+
+	g.JSON(http.StatusOK, reqs)
+}
+
+func (a *api) approveThreadRequests(g *gin.Context) {
+	id := g.Param("id")
+	if id == "default" {
+		id = a.node.config.Threads.Defaults.ID
+	}
+
+	info, err := a.node.ApproveThreadJoinRequest(id, g.Param("request"))
+	if err != nil {
+		a.abortJoinRequest(g, err)
+		return
+	}
+
+	g.JSON(http.StatusOK, info)
+}
+
+func (a *api) denyThreadRequests(g *gin.Context) {
+	args, err := a.readArgs(g)
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+
+	id := g.Param("id")
+	if id == "default" {
+		id = a.node.config.Threads.Defaults.ID
+	}
+
+	var note string
+	if len(args) > 0 {
+		note = args[0]
+	}
+
+	info, err := a.node.DenyThreadJoinRequest(id, g.Param("request"), note)
+	if err != nil {
+		a.abortJoinRequest(g, err)
+		return
+	}
+
+	g.JSON(http.StatusOK, info)
+}
+
+// abortJoinRequest maps join request decision errors to http status codes
+func (a *api) abortJoinRequest(g *gin.Context, err error) {
+	switch err {
+	case ErrThreadNotFound, ErrJoinRequestNotFound:
+		g.String(http.StatusNotFound, err.Error())
+	case ErrJoinRequestNotAllowed:
+		g.String(http.StatusForbidden, err.Error())
+	case ErrJoinRequestDecided:
+		g.String(http.StatusConflict, err.Error())
+	default:
+		g.String(http.StatusBadRequest, err.Error())
+	}
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/textileio/textile-go/repo"
+)
+
+func (a *api) searchBlocks(g *gin.Context) {
+	opts, err := a.readOpts(g)
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+
+	text := strings.TrimSpace(g.Query("q"))
+	if text == "" {
+		g.String(http.StatusBadRequest, "missing search query")
+		return
+	}
+	query := &repo.SearchQuery{
+		Text:     text,
+		ThreadId: opts["thread"],
+		AuthorId: opts["author"],
+	}
+	if query.ThreadId == "default" {
+		query.ThreadId = a.node.config.Threads.Defaults.ID
+	}
+
+	// Expects or'd list of block types (e.g., MESSAGE|COMMENT|FILES).
+	if opts["type"] != "" {
+		for _, desc := range strings.Split(opts["type"], "|") {
+			btype, err := repo.BlockTypeFromString(desc)
+			if err != nil {
+				g.String(http.StatusBadRequest, err.Error())
+				return
+			}
+			query.Types = append(query.Types, btype)
+		}
+	}
+
+	// Expects RFC3339 dates (e.g., 2006-01-02T15:04:05Z).
+	if opts["since"] != "" {
+		since, err := time.Parse(time.RFC3339, opts["since"])
+		if err != nil {
+			g.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		query.Since = &since
+	}
+	if opts["until"] != "" {
+		until, err := time.Parse(time.RFC3339, opts["until"])
+		if err != nil {
+			g.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		query.Until = &until
+	}
+
+	limit := 10
+	if opts["limit"] != "" {
+		limit, err = strconv.Atoi(opts["limit"])
+		if err != nil {
+			g.String(http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	results, err := a.node.Search(query, limit)
+	if err != nil {
+		if err == ErrThreadNotFound {
+			g.String(http.StatusNotFound, err.Error())
+		} else {
+			// most likely a malformed full-text query
+			g.String(http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+
+	g.JSON(http.StatusOK, results)
+}
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (a *api) addThreadSignals(g *gin.Context) {
+	args, err := a.readArgs(g)
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+	opts, err := a.readOpts(g)
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+
+	id := g.Param("id")
+	if id == "default" {
+		id = a.node.config.Threads.Defaults.ID
+	}
+
+	thrd := a.node.Thread(id)
+	if thrd == nil {
+		g.String(http.StatusNotFound, ErrThreadNotFound.Error())
+		return
+	}
+
+	stype, err := SignalTypeFromString(opts["type"])
+	if err != nil {
+		g.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var data string
+	if len(args) > 0 {
+		data = args[0]
+	}
+
+	if err := thrd.SendSignal(stype, data); err != nil {
+		if err == ErrOffline {
+			g.String(http.StatusServiceUnavailable, err.Error())
+		} else {
+			a.abort500(g, err)
+		}
+		return
+	}
+
+	g.String(http.StatusOK, "ok")
+}
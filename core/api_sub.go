@@ -17,6 +17,8 @@ func (a *api) getThreadsSub(g *gin.Context) {
 	}
 
 	// Expects or'd list of event types (e.g., FILES|COMMENTS|LIKES).
+	// Transient thread signals, e.g., typing or presence, are only included when
+	// SIGNAL is in the list, since they are not blocks.
 	types := strings.Split(strings.TrimSpace(strings.ToUpper(opts["type"])), "|")
 	threadId := g.Param("id")
 	if threadId == "default" {
@@ -30,6 +32,22 @@ func (a *api) getThreadsSub(g *gin.Context) {
 			if !ok {
 				return false
 			}
+			if signal, ok := update.(ThreadSignalInfo); ok {
+				if threadId != "" && signal.ThreadId != threadId {
+					break
+				}
+				for _, t := range types {
+					if t == "SIGNAL" {
+						if opts["events"] == "true" {
+							g.SSEvent("signal", signal)
+						} else {
+							g.JSON(http.StatusOK, signal)
+							g.Writer.Write([]byte("\n"))
+						}
+						break
+					}
+				}
+			}
 			if data, ok := update.(ThreadUpdate); ok {
 				if threadId != "" && data.ThreadId != threadId {
 					break
@@ -3,6 +3,7 @@ package core
 import (
 	"crypto/rand"
 	"net/http"
+	"strconv"
 
 	mh "gx/ipfs/QmPnFwZ2JXKnXgMw8CdBPxn7FWh6LLdjUjxV1fKHuJnkr8/go-multihash"
 	libp2pc "gx/ipfs/QmPvyPwuCgJ7pDmrKDxRtsScJgBaM5h4EpRL2qQJsmXf4n/go-libp2p-crypto"
@@ -136,6 +137,231 @@ func (a *api) peersThreads(g *gin.Context) {
 	g.JSON(http.StatusOK, contacts)
 }
 
+func (a *api) rmThreadPeers(g *gin.Context) {
+	id := g.Param("id")
+	if id == "default" {
+		id = a.node.config.Threads.Defaults.ID
+	}
+
+	thrd := a.node.Thread(id)
+	if thrd == nil {
+		g.String(http.StatusNotFound, ErrThreadNotFound.Error())
+		return
+	}
+
+	hash, err := thrd.AddKick(g.Param("peer"))
+	if err != nil {
+		switch err {
+		case ErrKickNotAllowed:
+			g.String(http.StatusForbidden, err.Error())
+		case ErrPeerNotFound:
+			g.String(http.StatusNotFound, err.Error())
+		default:
+			g.String(http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+
+	info, err := a.node.BlockInfo(hash.B58String())
+	if err != nil {
+		g.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	g.JSON(http.StatusOK, info)
+}
+
+func (a *api) addThreadPeerRoles(g *gin.Context) {
+	args, err := a.readArgs(g)
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+	if len(args) == 0 {
+		g.String(http.StatusBadRequest, "missing role")
+		return
+	}
+	role, err := repo.ThreadRoleFromString(args[0])
+	if err != nil {
+		g.String(http.StatusBadRequest, "invalid thread role")
+		return
+	}
+
+	id := g.Param("id")
+	if id == "default" {
+		id = a.node.config.Threads.Defaults.ID
+	}
+
+	thrd := a.node.Thread(id)
+	if thrd == nil {
+		g.String(http.StatusNotFound, ErrThreadNotFound.Error())
+		return
+	}
+
+	hash, err := thrd.AddRole(g.Param("peer"), role)
+	if err != nil {
+		if err == ErrRoleNotAllowed {
+			g.String(http.StatusForbidden, err.Error())
+		} else {
+			g.String(http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+
+	info, err := a.node.BlockInfo(hash.B58String())
+	if err != nil {
+		g.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	g.JSON(http.StatusCreated, info)
+}
+
+func (a *api) updateThreads(g *gin.Context) {
+	opts, err := a.readOpts(g)
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+
+	id := g.Param("id")
+	if id == "default" {
+		id = a.node.config.Threads.Defaults.ID
+	}
+
+	thrd := a.node.Thread(id)
+	if thrd == nil {
+		g.String(http.StatusNotFound, ErrThreadNotFound.Error())
+		return
+	}
+	info, err := thrd.Info()
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+
+	// omitted options keep their current values
+	name, description, cover, ttl := info.Name, info.Description, info.Cover, info.Ttl
+	if v, ok := opts["name"]; ok {
+		name = v
+	}
+	if v, ok := opts["description"]; ok {
+		description = v
+	}
+	if v, ok := opts["cover"]; ok {
+		cover = v
+	}
+	if v, ok := opts["ttl"]; ok {
+		ttl, err = strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			g.String(http.StatusBadRequest, "invalid ttl: "+v)
+			return
+		}
+	}
+
+	hash, err := thrd.UpdateMeta(name, description, cover, ttl)
+	if err != nil {
+		if err == ErrMetaNotAllowed {
+			g.String(http.StatusForbidden, err.Error())
+		} else {
+			g.String(http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+
+	binfo, err := a.node.BlockInfo(hash.B58String())
+	if err != nil {
+		g.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	g.JSON(http.StatusCreated, binfo)
+}
+
+func (a *api) verifyThreads(g *gin.Context) {
+	opts, err := a.readOpts(g)
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+
+	id := g.Param("id")
+	if id == "default" {
+		id = a.node.config.Threads.Defaults.ID
+	}
+
+	thrd := a.node.Thread(id)
+	if thrd == nil {
+		g.String(http.StatusNotFound, ErrThreadNotFound.Error())
+		return
+	}
+
+	res, err := thrd.Verify(opts["repair"] == "true")
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+
+	g.JSON(http.StatusOK, res)
+}
+
+func (a *api) addThreadCheckpoints(g *gin.Context) {
+	id := g.Param("id")
+	if id == "default" {
+		id = a.node.config.Threads.Defaults.ID
+	}
+
+	thrd := a.node.Thread(id)
+	if thrd == nil {
+		g.String(http.StatusNotFound, ErrThreadNotFound.Error())
+		return
+	}
+
+	hash, err := thrd.AddCheckpoint()
+	if err != nil {
+		if err == ErrCheckpointNotAllowed {
+			g.String(http.StatusForbidden, err.Error())
+		} else {
+			g.String(http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+
+	info, err := a.node.BlockInfo(hash.B58String())
+	if err != nil {
+		g.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	g.JSON(http.StatusCreated, info)
+}
+
+func (a *api) loadThreadHistory(g *gin.Context) {
+	id := g.Param("id")
+	if id == "default" {
+		id = a.node.config.Threads.Defaults.ID
+	}
+
+	thrd := a.node.Thread(id)
+	if thrd == nil {
+		g.String(http.StatusNotFound, ErrThreadNotFound.Error())
+		return
+	}
+
+	if err := thrd.LoadHistory(); err != nil {
+		a.abort500(g, err)
+		return
+	}
+
+	info, err := thrd.Info()
+	if err != nil {
+		a.abort500(g, err)
+		return
+	}
+
+	g.JSON(http.StatusOK, info)
+}
+
 func (a *api) rmThreads(g *gin.Context) {
 	id := g.Param("id")
 	thrd := a.node.Thread(id)
@@ -9,18 +9,10 @@ import (
 // ErrBlockNotFound indicates a block was not found in the index
 var ErrBlockNotFound = errors.New("block not found")
 
-// GetBlocks paginates blocks
-func (t *Textile) Blocks(offset string, limit int, query string) []repo.Block {
-	var filtered []repo.Block
-
-	for _, block := range t.datastore.Blocks().List(offset, limit, query) {
-		ignored := t.datastore.Blocks().List("", -1, "target='ignore-"+block.Id+"'")
-		if len(ignored) == 0 {
-			filtered = append(filtered, block)
-		}
-	}
-
-	return filtered
+// Blocks paginates blocks matching a typed query.
+// Causal order is a topological order of the thread, with concurrent blocks sorted by date and id.
+func (t *Textile) Blocks(query *repo.BlockQuery) []repo.Block {
+	return t.datastore.Blocks().ListByQuery(query)
 }
 
 // Block returns block with id
@@ -34,7 +26,7 @@ func (t *Textile) Block(id string) (*repo.Block, error) {
 
 // BlocksByTarget returns block with parent
 func (t *Textile) BlocksByTarget(target string) []repo.Block {
-	return t.datastore.Blocks().List("", -1, "target='"+target+"'")
+	return t.Blocks(&repo.BlockQuery{Target: target, Ignored: true, Expired: true, Hidden: true})
 }
 
 // BlockInfo returns block info with id
@@ -54,5 +46,9 @@ func (t *Textile) BlockInfo(id string) (*BlockInfo, error) {
 		Parents:  block.Parents,
 		Target:   block.Target,
 		Body:     block.Body,
+		Verified: block.Verified,
+		Clock:    block.Clock,
+		Expires:  block.Expires,
+		Hidden:   block.Hidden,
 	}, nil
 }
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"errors"
+	"strings"
+
+	mh "gx/ipfs/QmPnFwZ2JXKnXgMw8CdBPxn7FWh6LLdjUjxV1fKHuJnkr8/go-multihash"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/textileio/textile-go/pb"
+	"github.com/textileio/textile-go/strkey"
+)
+
+// InviteURIPrefix is prepended to an invite link to form a textile:// URI
+const InviteURIPrefix = "textile://invite/"
+
+// ErrInvalidInviteLink indicates an invite link could not be decoded
+var ErrInvalidInviteLink = errors.New("invalid invite link")
+
+// InviteLink bundles an external invite, the thread name, and the local peer's
+// username and cafe inboxes into a single checksummed string
+func (t *Thread) InviteLink(inviteId mh.Multihash, key []byte) (string, error) {
+	link := &pb.ThreadInviteLink{
+		Id:   inviteId.B58String(),
+		Key:  key,
+		Name: t.Name,
+	}
+	username, err := t.datastore.Profile().GetUsername()
+	if err != nil {
+		return "", err
+	}
+	if username != nil {
+		link.Inviter = *username
+	}
+	for _, ses := range t.datastore.CafeSessions().List() {
+		link.Inboxes = append(link.Inboxes, ses.CafeId)
+	}
+	return EncodeInviteLink(link)
+}
+
+// EncodeInviteLink encodes an invite link with the strkey invite version byte
+func EncodeInviteLink(link *pb.ThreadInviteLink) (string, error) {
+	payload, err := proto.Marshal(link)
+	if err != nil {
+		return "", err
+	}
+	return strkey.Encode(strkey.VersionByteInvite, payload)
+}
+
+// DecodeInviteLink decodes and checks an invite link, with or without the URI prefix
+func DecodeInviteLink(src string) (*pb.ThreadInviteLink, error) {
+	payload, err := strkey.Decode(strkey.VersionByteInvite, strings.TrimPrefix(src, InviteURIPrefix))
+	if err != nil {
+		return nil, ErrInvalidInviteLink
+	}
+	link := new(pb.ThreadInviteLink)
+	if err := proto.Unmarshal(payload, link); err != nil {
+		return nil, ErrInvalidInviteLink
+	}
+	if link.Id == "" || len(link.Key) == 0 {
+		return nil, ErrInvalidInviteLink
+	}
+	return link, nil
+}
+
+// IsInviteLink returns whether or not a string looks like an invite link
+func IsInviteLink(src string) bool {
+	version, err := strkey.Version(strings.TrimPrefix(src, InviteURIPrefix))
+	return err == nil && version == strkey.VersionByteInvite
+}
+
+// InviteURI returns the textile:// URI form of an invite link
+func InviteURI(link string) string {
+	return InviteURIPrefix + link
+}
@@ -329,6 +329,8 @@ func (t *Textile) Start() error {
 		}
 
 		go t.runQueues()
+		go t.runSweeper()
+		go t.resumeSyncs()
 
 		if err := ipfs.PrintSwarmAddrs(t.node); err != nil {
 			log.Errorf(err.Error())
@@ -579,6 +581,19 @@ func (t *Textile) flushQueues() {
 	}()
 }
 
+// resumeSyncs resumes thread history syncs that were interrupted by a shutdown
+func (t *Textile) resumeSyncs() {
+	for _, thrd := range t.threads {
+		if thrd.syncPending() == 0 {
+			continue
+		}
+		log.Debugf("resuming sync for thread %s", thrd.Id)
+		if err := thrd.sync(); err != nil {
+			log.Errorf("error syncing thread %s: %s", thrd.Id, err)
+		}
+	}
+}
+
 // threadByBlock returns the thread owning the given block
 func (t *Textile) threadByBlock(block *repo.Block) (*Thread, error) {
 	if block == nil {
@@ -607,6 +622,7 @@ func (t *Textile) loadThread(mod *repo.Thread) (*Thread, error) {
 	threadConfig := &ThreadConfig{
 		RepoPath: t.repoPath,
 		Config:   t.config,
+		Account:  t.account,
 		Node: func() *core.IpfsNode {
 			return t.node
 		},
@@ -620,6 +636,7 @@ func (t *Textile) loadThread(mod *repo.Thread) (*Thread, error) {
 		ThreadsOutbox: t.threadsOutbox,
 		CafeOutbox:    t.cafeOutbox,
 		SendUpdate:    t.sendThreadUpdate,
+		SendSignal:    t.sendThreadSignal,
 	}
 
 	thrd, err := NewThread(mod, threadConfig)
@@ -641,6 +658,12 @@ func (t *Textile) sendThreadUpdate(update ThreadUpdate) {
 	t.threadUpdates.Send(update)
 }
 
+// sendThreadSignal adds a thread signal to the update channel.
+// Signals are transient, so they are dropped rather than blocking on a slow listener.
+func (t *Textile) sendThreadSignal(signal ThreadSignalInfo) {
+	t.threadUpdates.TrySend(signal)
+}
+
 // sendNotification adds a notification to the notification channel
 func (t *Textile) sendNotification(notification *repo.Notification) error {
 	if err := t.datastore.Notifications().Add(notification); err != nil {
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"github.com/textileio/textile-go/repo"
+)
+
+// SearchResultInfo is a block matching a search, with a snippet of the matched text
+type SearchResultInfo struct {
+	Block   BlockInfo `json:"block"`
+	Snippet string    `json:"snippet"`
+	Rank    float64   `json:"rank"`
+}
+
+// Search runs a full-text search over messages, comments, captions, and file names,
+// returning up to limit results, best match first
+func (t *Textile) Search(query *repo.SearchQuery, limit int) ([]SearchResultInfo, error) {
+	if query.ThreadId != "" && t.Thread(query.ThreadId) == nil {
+		return nil, ErrThreadNotFound
+	}
+
+	results, err := t.datastore.Search().Search(query)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]SearchResultInfo, 0)
+	for _, res := range results {
+		if limit >= 0 && len(infos) >= limit {
+			break
+		}
+
+		// the sweeper may not have caught up with expired blocks yet
+		block := t.datastore.Blocks().Get(res.BlockId)
+		if block == nil || expired(*block) {
+			continue
+		}
+
+		body, _ := t.latestBody(*block)
+		infos = append(infos, SearchResultInfo{
+			Block: BlockInfo{
+				Id:       block.Id,
+				ThreadId: block.ThreadId,
+				AuthorId: block.AuthorId,
+				Username: t.ContactUsername(block.AuthorId),
+				Type:     block.Type.Description(),
+				Date:     block.Date,
+				Parents:  block.Parents,
+				Target:   block.Target,
+				Body:     body,
+				Verified: block.Verified,
+				Clock:    block.Clock,
+				Expires:  block.Expires,
+				Hidden:   block.Hidden,
+			},
+			Snippet: res.Snippet,
+			Rank:    res.Rank,
+		})
+	}
+
+	return infos, nil
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	"github.com/textileio/textile-go/crypto"
 	"github.com/textileio/textile-go/ipfs"
+	"github.com/textileio/textile-go/keypair"
 	"github.com/textileio/textile-go/pb"
 	"github.com/textileio/textile-go/repo"
 	"github.com/textileio/textile-go/repo/config"
@@ -42,6 +43,24 @@ var ErrInvalidFileNode = errors.New("invalid files node")
 // ErrBlockWrongType indicates a block was requested as a type other than its own
 var ErrBlockWrongType = errors.New("block type is not the type requested")
 
+// ErrInvalidBlockSignature indicates a block signature does not match its author's account
+var ErrInvalidBlockSignature = errors.New("invalid block signature")
+
+// ErrBlockAuthorMismatch indicates a block peer signature does not match its author
+var ErrBlockAuthorMismatch = errors.New("block author does not match account address")
+
+// ErrInvalidBlockClock indicates a block clock is not one more than its highest parent clock
+var ErrInvalidBlockClock = errors.New("invalid block clock")
+
+// ErrUnsignedBlock indicates a block other than a merge is missing its signatures
+var ErrUnsignedBlock = errors.New("block is not signed")
+
+// ErrWriteNotAllowed indicates a write was attempted that the thread type does not allow
+var ErrWriteNotAllowed = errors.New("thread type does not allow this write")
+
+// ErrInvalidMergeBlock indicates a merge block carries a payload, an author, or unknown parents
+var ErrInvalidMergeBlock = errors.New("invalid merge block")
+
 // ThreadUpdate is used to notify listeners about updates in a thread
 type ThreadUpdate struct {
 	Block      BlockInfo   `json:"block"`
@@ -52,18 +71,24 @@ type ThreadUpdate struct {
 
 // ThreadInfo reports info about a thread
 type ThreadInfo struct {
-	Id         string       `json:"id"`
-	Key        string       `json:"key"`
-	Name       string       `json:"name"`
-	Schema     *schema.Node `json:"schema,omitempty"`
-	SchemaId   string       `json:"schema_id,omitempty"`
-	Initiator  string       `json:"initiator"`
-	Type       string       `json:"type"`
-	State      string       `json:"state"`
-	Head       *BlockInfo   `json:"head,omitempty"`
-	PeerCount  int          `json:"peer_cnt"`
-	BlockCount int          `json:"block_cnt"`
-	FileCount  int          `json:"file_cnt"`
+	Id            string       `json:"id"`
+	Key           string       `json:"key"`
+	Name          string       `json:"name"`
+	Description   string       `json:"description,omitempty"`
+	Cover         string       `json:"cover,omitempty"`
+	Ttl           int64        `json:"ttl,omitempty"`
+	Schema        *schema.Node `json:"schema,omitempty"`
+	SchemaId      string       `json:"schema_id,omitempty"`
+	Initiator     string       `json:"initiator"`
+	Type          string       `json:"type"`
+	State         string       `json:"state"`
+	Head          *BlockInfo   `json:"head,omitempty"`
+	PeerCount     int          `json:"peer_cnt"`
+	BlockCount    int          `json:"block_cnt"`
+	FileCount     int          `json:"file_cnt"`
+	SyncCount     int          `json:"sync_cnt,omitempty"`
+	ReceiptsOff   bool         `json:"receipts_off,omitempty"`
+	FlagThreshold int          `json:"flag_threshold,omitempty"`
 }
 
 // ThreadInviteInfo reports info about a thread
@@ -76,27 +101,33 @@ type ThreadInviteInfo struct {
 
 // BlockInfo is a more readable version of repo.Block
 type BlockInfo struct {
-	Id       string    `json:"id"`
-	ThreadId string    `json:"thread_id"`
-	AuthorId string    `json:"author_id,omitempty"`
-	Username string    `json:"username,omitempty"`
-	Type     string    `json:"type"`
-	Date     time.Time `json:"date"`
-	Parents  []string  `json:"parents"`
-	Target   string    `json:"target,omitempty"`
-	Body     string    `json:"body,omitempty"`
+	Id       string     `json:"id"`
+	ThreadId string     `json:"thread_id"`
+	AuthorId string     `json:"author_id,omitempty"`
+	Username string     `json:"username,omitempty"`
+	Type     string     `json:"type"`
+	Date     time.Time  `json:"date"`
+	Parents  []string   `json:"parents"`
+	Target   string     `json:"target,omitempty"`
+	Body     string     `json:"body,omitempty"`
+	Verified bool       `json:"verified"`
+	Clock    int64      `json:"clock"`
+	Expires  *time.Time `json:"expires,omitempty"`
+	Hidden   bool       `json:"hidden,omitempty"`
 }
 
 // ThreadConfig is used to construct a Thread
 type ThreadConfig struct {
 	RepoPath      string
 	Config        *config.Config
+	Account       *keypair.Full
 	Node          func() *core.IpfsNode
 	Datastore     repo.Datastore
 	Service       func() *ThreadsService
 	ThreadsOutbox *ThreadsOutbox
 	CafeOutbox    *CafeOutbox
 	SendUpdate    func(update ThreadUpdate)
+	SendSignal    func(signal ThreadSignalInfo)
 }
 
 // Thread is the primary mechanism representing a collecion of data / files / photos
@@ -108,16 +139,21 @@ type Thread struct {
 	Schema        *schema.Node
 	schemaId      string
 	initiator     string
-	privKey       libp2pc.PrivKey
+	privKey       libp2pc.PrivKey   // current key
+	keys          []libp2pc.PrivKey // all keys, newest first
+	keyBlocks     []string          // key blocks that introduced each key, empty if unknown
 	repoPath      string
 	config        *config.Config
+	account       *keypair.Full
 	node          func() *core.IpfsNode
 	datastore     repo.Datastore
 	service       func() *ThreadsService
 	threadsOutbox *ThreadsOutbox
 	cafeOutbox    *CafeOutbox
 	sendUpdate    func(update ThreadUpdate)
+	sendSignal    func(signal ThreadSignalInfo)
 	mux           sync.Mutex
+	syncMux       sync.Mutex
 }
 
 // NewThread create a new Thread from a repo model and config
@@ -135,7 +171,7 @@ func NewThread(model *repo.Thread, conf *ThreadConfig) (*Thread, error) {
 		}
 	}
 
-	return &Thread{
+	thrd := &Thread{
 		Id:            model.Id,
 		Key:           model.Key,
 		Name:          model.Name,
@@ -143,16 +179,21 @@ func NewThread(model *repo.Thread, conf *ThreadConfig) (*Thread, error) {
 		Schema:        sch,
 		schemaId:      model.Schema,
 		initiator:     model.Initiator,
-		privKey:       sk,
 		repoPath:      conf.RepoPath,
 		config:        conf.Config,
+		account:       conf.Account,
 		node:          conf.Node,
 		datastore:     conf.Datastore,
 		service:       conf.Service,
 		threadsOutbox: conf.ThreadsOutbox,
 		cafeOutbox:    conf.CafeOutbox,
 		sendUpdate:    conf.SendUpdate,
-	}, nil
+		sendSignal:    conf.SendSignal,
+	}
+	if err := thrd.loadKeys(sk); err != nil {
+		return nil, err
+	}
+	return thrd, nil
 }
 
 // Info returns thread info
@@ -176,6 +217,10 @@ func (t *Thread) Info() (*ThreadInfo, error) {
 				Parents:  h.Parents,
 				Target:   h.Target,
 				Body:     h.Body,
+				Verified: h.Verified,
+				Clock:    h.Clock,
+				Expires:  h.Expires,
+				Hidden:   h.Hidden,
 			}
 		}
 	}
@@ -185,31 +230,40 @@ func (t *Thread) Info() (*ThreadInfo, error) {
 		return nil, err
 	}
 
-	blocks := t.datastore.Blocks().Count(fmt.Sprintf("threadId='%s'", t.Id))
-	files := t.datastore.Blocks().Count(fmt.Sprintf("threadId='%s' and type=%d", t.Id, repo.FilesBlock))
+	blocks := t.datastore.Blocks().CountByQuery(t.indexQuery())
+	files := t.datastore.Blocks().CountByQuery(t.indexQuery(repo.FilesBlock))
 
 	return &ThreadInfo{
-		Id:         t.Id,
-		Key:        t.Key,
-		Name:       t.Name,
-		Schema:     t.Schema,
-		SchemaId:   t.schemaId,
-		Initiator:  t.initiator,
-		Type:       mod.Type.Description(),
-		State:      state.Description(),
-		Head:       head,
-		PeerCount:  len(t.Peers()) + 1,
-		BlockCount: blocks,
-		FileCount:  files,
+		Id:            t.Id,
+		Key:           t.Key,
+		Name:          t.Name,
+		Description:   mod.Description,
+		Cover:         mod.Cover,
+		Ttl:           mod.Ttl,
+		Schema:        t.Schema,
+		SchemaId:      t.schemaId,
+		Initiator:     t.initiator,
+		Type:          mod.Type.Description(),
+		State:         state.Description(),
+		Head:          head,
+		PeerCount:     len(t.Peers()) + 1,
+		BlockCount:    blocks,
+		FileCount:     files,
+		SyncCount:     t.syncPending(),
+		ReceiptsOff:   mod.ReceiptsOff,
+		FlagThreshold: mod.FlagThreshold,
 	}, nil
 }
 
-// State returns the current thread state
+// State returns the current thread state, which is loading while history is being synced
 func (t *Thread) State() (repo.ThreadState, error) {
 	mod := t.datastore.Threads().Get(t.Id)
 	if mod == nil {
 		return -1, errThreadReload
 	}
+	if t.syncPending() > 0 {
+		return repo.ThreadLoading, nil
+	}
 	return mod.State, nil
 }
 
@@ -232,9 +286,27 @@ func (t *Thread) Encrypt(data []byte) ([]byte, error) {
 	return crypto.Encrypt(t.privKey.GetPublic(), data)
 }
 
-// Decrypt data with thread secret key
+// Decrypt data with thread secret key, falling back to older keys
 func (t *Thread) Decrypt(data []byte) ([]byte, error) {
-	return crypto.Decrypt(t.privKey, data)
+	plaintext, _, err := t.decrypt(data)
+	return plaintext, err
+}
+
+// decrypt decrypts data with the thread keys, also returning the key block that retired
+// the key used, which is empty for the current key or a key retired by an unknown block
+func (t *Thread) decrypt(data []byte) ([]byte, string, error) {
+	var err error
+	for i, key := range t.keys {
+		var plaintext []byte
+		plaintext, err = crypto.Decrypt(key, data)
+		if err == nil {
+			if i == 0 {
+				return plaintext, "", nil
+			}
+			return plaintext, t.keyBlocks[i-1], nil
+		}
+	}
+	return nil, "", err
 }
 
 // AddPeer directly adds a peer to a thread
@@ -257,85 +329,20 @@ func (t *Thread) AddPeer(id string) error {
 	return nil
 }
 
-// followParents tries to follow a list of chains of block ids, processing along the way
-func (t *Thread) followParents(parents []string) error {
-	for _, parent := range parents {
-		if parent == "" {
-			log.Debugf("found genesis block, aborting")
-			continue
-		}
-
-		hash, err := mh.FromB58String(parent)
-		if err != nil {
-			return err
-		}
-
-		if err := t.followParent(hash); err != nil {
-			log.Warningf("failed to follow parent %s: %s", parent, err)
-			continue
-		}
-	}
-
-	return nil
-}
-
-// followParent tries to follow a chain of block ids, processing along the way
-func (t *Thread) followParent(parent mh.Multihash) error {
-	ciphertext, err := ipfs.DataAtPath(t.node(), parent.B58String())
-	if err != nil {
-		return err
-	}
-
-	block, err := t.handleBlock(parent, ciphertext)
-	if err != nil {
-		return err
-	}
-	if block == nil {
-		// exists, abort
-		return nil
-	}
-
-	switch block.Type {
-	case pb.ThreadBlock_MERGE:
-		err = t.handleMergeBlock(parent, block)
-	case pb.ThreadBlock_IGNORE:
-		_, err = t.handleIgnoreBlock(parent, block)
-	case pb.ThreadBlock_FLAG:
-		_, err = t.handleFlagBlock(parent, block)
-	case pb.ThreadBlock_JOIN:
-		_, err = t.handleJoinBlock(parent, block)
-	case pb.ThreadBlock_ANNOUNCE:
-		_, err = t.handleAnnounceBlock(parent, block)
-	case pb.ThreadBlock_LEAVE:
-		err = t.handleLeaveBlock(parent, block)
-	case pb.ThreadBlock_MESSAGE:
-		_, err = t.handleMessageBlock(parent, block)
-	case pb.ThreadBlock_FILES:
-		_, err = t.handleFilesBlock(parent, block)
-	case pb.ThreadBlock_COMMENT:
-		_, err = t.handleCommentBlock(parent, block)
-	case pb.ThreadBlock_LIKE:
-		_, err = t.handleLikeBlock(parent, block)
-	default:
-		return errors.New(fmt.Sprintf("invalid message type: %s", block.Type))
-	}
-	if err != nil {
-		return err
-	}
-
-	return t.followParents(block.Header.Parents)
-}
-
 // addOrUpdatePeer collects thread peers, saving them as contacts and
 // saving their cafe inboxes for offline message delivery
 func (t *Thread) addOrUpdatePeer(pid peer.ID, address string, username string, inboxes []string) error {
-	if err := t.datastore.ThreadPeers().Add(&repo.ThreadPeer{
-		Id:       pid.Pretty(),
-		ThreadId: t.Id,
-		Welcomed: false,
-	}); err != nil {
-		if !repo.ConflictError(err) {
-			return err
+	// removed peers can resurface during back prop, but may also be invited back
+	if t.kicked(pid.Pretty()) == nil {
+		if err := t.datastore.ThreadPeers().Add(&repo.ThreadPeer{
+			Id:       pid.Pretty(),
+			ThreadId: t.Id,
+			Welcomed: false,
+			Role:     t.peerRole(pid.Pretty(), address),
+		}); err != nil {
+			if !repo.ConflictError(err) {
+				return err
+			}
 		}
 	}
 
@@ -370,9 +377,39 @@ func (t *Thread) newBlockHeader() (*pb.ThreadBlockHeader, error) {
 		Parents: parents,
 		Author:  t.node().Identity.Pretty(),
 		Address: t.config.Account.Address,
+		Clock:   t.nextClock(parents),
 	}, nil
 }
 
+// nextClock returns a lamport clock value one more than the highest parent clock
+func (t *Thread) nextClock(parents []string) int64 {
+	var clock int64
+	for _, p := range parents {
+		if parent := t.datastore.Blocks().Get(p); parent != nil && parent.Clock > clock {
+			clock = parent.Clock
+		}
+	}
+	return clock + 1
+}
+
+// verifyClock checks that an incoming block clock is one more than its highest parent clock.
+// Blocks whose parents are not all indexed, e.g., behind a checkpoint, can't be checked.
+func (t *Thread) verifyClock(block *pb.ThreadBlock) error {
+	for _, p := range block.Header.Parents {
+		if p != "" && t.datastore.Blocks().Get(p) == nil {
+			return nil
+		}
+	}
+	// unclocked legacy blocks can only be built on other legacy blocks
+	if block.Header.Clock == 0 && len(block.Header.Sig) == 0 && t.legacyParents(block.Header.Parents) {
+		return nil
+	}
+	if block.Header.Clock != t.nextClock(block.Header.Parents) {
+		return ErrInvalidBlockClock
+	}
+	return nil
+}
+
 // commitResult wraps the results of a block commit
 type commitResult struct {
 	hash       mh.Multihash
@@ -397,6 +434,9 @@ func (t *Thread) commitBlock(msg proto.Message, mtype pb.ThreadBlock_Type, encry
 		}
 		block.Payload = payload
 	}
+	if err := t.signBlock(block); err != nil {
+		return nil, err
+	}
 	plaintext, err := proto.Marshal(block)
 	if err != nil {
 		return nil, err
@@ -433,57 +473,118 @@ func (t *Thread) addBlock(ciphertext []byte) (mh.Multihash, error) {
 	return id.Hash(), nil
 }
 
-// handleBlock receives an incoming encrypted block
-func (t *Thread) handleBlock(hash mh.Multihash, ciphertext []byte) (*pb.ThreadBlock, error) {
+// handleBlock receives an incoming encrypted block,
+// returning the key block that retired its key, if any, for keyAllowed
+func (t *Thread) handleBlock(hash mh.Multihash, ciphertext []byte) (*pb.ThreadBlock, string, error) {
 	index := t.datastore.Blocks().Get(hash.B58String())
 	if index != nil {
-		return nil, nil
+		return nil, "", nil
 	}
 
-	block := new(pb.ThreadBlock)
-	plaintext, err := t.Decrypt(ciphertext)
+	block, retiredBy, err := t.decodeBlockKey(ciphertext)
 	if err != nil {
-		// might be a merge block
-		err2 := proto.Unmarshal(ciphertext, block)
-		if err2 != nil || block.Type != pb.ThreadBlock_MERGE {
-			return nil, err
-		}
-	} else {
-		if err := proto.Unmarshal(plaintext, block); err != nil {
-			return nil, err
-		}
+		return nil, "", err
 	}
 
 	// nil payload only allowed for some types
 	if block.Payload == nil && block.Type != pb.ThreadBlock_MERGE && block.Type != pb.ThreadBlock_LEAVE {
-		return nil, errors.New("nil message payload")
+		return nil, "", errors.New("nil message payload")
+	}
+
+	// merge blocks are checked against their parents when handled
+	if block.Type == pb.ThreadBlock_MERGE {
+		if block.Payload != nil || block.Header.Author != "" || len(block.Header.Sig) > 0 || len(block.Header.Parents) < 2 {
+			return nil, "", ErrInvalidMergeBlock
+		}
+	} else if t.legacyBlock(block) {
+		log.Debugf("handling unsigned legacy %s from %s", block.Type.String(), block.Header.Author)
+	} else if err := verifyBlockSig(block); err != nil {
+		return nil, "", err
+	}
+
+	// read markers are not part of the thread history, so they are not stored,
+	// but are still checked against kicks and keys before being handled
+	if block.Type == pb.ThreadBlock_READ {
+		return block, retiredBy, nil
 	}
 
 	if _, err := t.addBlock(ciphertext); err != nil {
-		return nil, err
+		return nil, "", err
+	}
+	return block, retiredBy, nil
+}
+
+// decodeBlock decrypts and unmarshals a block, falling back to plaintext merge blocks
+func (t *Thread) decodeBlock(ciphertext []byte) (*pb.ThreadBlock, error) {
+	block, _, err := t.decodeBlockKey(ciphertext)
+	return block, err
+}
+
+// decodeBlockKey decodes a block, also returning the key block that retired its key, if any
+func (t *Thread) decodeBlockKey(ciphertext []byte) (*pb.ThreadBlock, string, error) {
+	block := new(pb.ThreadBlock)
+	plaintext, retiredBy, err := t.decrypt(ciphertext)
+	if err != nil {
+		// might be a merge block
+		err2 := proto.Unmarshal(ciphertext, block)
+		if err2 != nil || block.Type != pb.ThreadBlock_MERGE {
+			return nil, "", err
+		}
+		return block, "", nil
 	}
-	return block, nil
+	if err := proto.Unmarshal(plaintext, block); err != nil {
+		return nil, "", err
+	}
+	return block, retiredBy, nil
 }
 
 // indexBlock stores off index info for this block type
 func (t *Thread) indexBlock(commit *commitResult, blockType repo.BlockType, target string, body string) error {
+	return t.indexBlockUntil(commit, blockType, target, body, nil)
+}
+
+// indexBlockUntil stores off index info for a block that is swept after expires, if set
+func (t *Thread) indexBlockUntil(commit *commitResult, blockType repo.BlockType, target string, body string, expires *time.Time) error {
 	date, err := ptypes.Timestamp(commit.header.Date)
 	if err != nil {
 		return err
 	}
+
+	// invalid blocks never make it past handleBlock, so only legacy blocks
+	// and merges are indexed as unverified, the latter when a parent is
+	var verified bool
+	if blockType == repo.MergeBlock {
+		verified = t.parentsVerified(commit.header.Parents)
+	} else {
+		verified = len(commit.header.Sig) > 0
+	}
+	var address string
+	if verified {
+		address = commit.header.Address
+	}
+
+	id := commit.hash.B58String()
 	index := &repo.Block{
-		Id:       commit.hash.B58String(),
+		Id:       id,
 		Type:     blockType,
 		Date:     date,
 		Parents:  commit.header.Parents,
 		ThreadId: t.Id,
 		AuthorId: commit.header.Author,
+		Address:  address,
 		Target:   target,
 		Body:     body,
+		Verified: verified,
+		Clock:    commit.header.Clock,
+		Expires:  expires,
+		Hidden:   t.flagHidden(id), // flags may arrive before their target
 	}
 	if err := t.datastore.Blocks().Add(index); err != nil {
 		return err
 	}
+	if err := t.indexSearch(index); err != nil {
+		return err
+	}
 	t.pushUpdate(BlockInfo{
 		Id:       index.Id,
 		ThreadId: index.ThreadId,
@@ -494,11 +595,243 @@ func (t *Thread) indexBlock(commit *commitResult, blockType repo.BlockType, targ
 		Parents:  index.Parents,
 		Target:   index.Target,
 		Body:     index.Body,
+		Verified: index.Verified,
+		Clock:    index.Clock,
+		Expires:  index.Expires,
+		Hidden:   index.Hidden,
 	})
 
 	return nil
 }
 
+// indexQuery returns a query over every indexed block of this thread with the given types,
+// including ignored, hidden, and expired blocks
+func (t *Thread) indexQuery(types ...repo.BlockType) *repo.BlockQuery {
+	return &repo.BlockQuery{
+		ThreadIds: []string{t.Id},
+		Types:     types,
+		Ignored:   true,
+		Expired:   true,
+		Hidden:    true,
+	}
+}
+
+// writeAllowed returns whether or not an author is allowed to write a block type.
+// Membership blocks are always allowed. Otherwise, granted roles take precedence
+// over thread type rules: read-only threads only accept writes from the initiator,
+// and public threads only restrict files.
+func (t *Thread) writeAllowed(btype pb.ThreadBlock_Type, author string, address string) bool {
+	switch btype {
+	case pb.ThreadBlock_MERGE,
+		pb.ThreadBlock_JOIN,
+		pb.ThreadBlock_ANNOUNCE,
+		pb.ThreadBlock_LEAVE,
+		pb.ThreadBlock_KEY:
+		return true
+	}
+
+	role := t.peerRole(author, address)
+	switch btype {
+	case pb.ThreadBlock_ROLE, pb.ThreadBlock_JOIN_DECISION:
+		return role == repo.AdminRole
+	case pb.ThreadBlock_KICK, pb.ThreadBlock_META, pb.ThreadBlock_CHECKPOINT:
+		return role >= repo.ModeratorRole
+	case pb.ThreadBlock_INVITE, pb.ThreadBlock_EXTERNAL_INVITE:
+		return role != repo.ReaderRole
+	}
+
+	if role == repo.ReaderRole {
+		return btype == pb.ThreadBlock_FLAG || btype == pb.ThreadBlock_UNDO
+	}
+	if role != repo.DefaultRole {
+		return true
+	}
+
+	switch t.Type {
+	case repo.ReadOnlyThread:
+		return false
+	case repo.PublicThread:
+		return btype != pb.ThreadBlock_FILES
+	default:
+		return true
+	}
+}
+
+// canWrite returns whether or not the local peer is allowed to write a block type
+func (t *Thread) canWrite(btype pb.ThreadBlock_Type) bool {
+	return t.writeAllowed(btype, t.node().Identity.Pretty(), t.account.Address())
+}
+
+// blockAllowed returns whether or not an incoming block is allowed by the thread type and roles
+func (t *Thread) blockAllowed(hash mh.Multihash, block *pb.ThreadBlock) bool {
+	// removed peers may still hold an old key
+	if !t.kickAllowed(hash, block) {
+		return false
+	}
+
+	if block.Type == pb.ThreadBlock_JOIN && !t.joinAllowed(hash, block) {
+		return false
+	}
+
+	return t.writeAllowed(block.Type, block.Header.Author, block.Header.Address)
+}
+
+// handleDisallowedBlock indexes an incoming block the thread type or roles do not allow
+// as an ignore targeting itself, keeping the chain intact without surfacing it
+func (t *Thread) handleDisallowedBlock(hash mh.Multihash, block *pb.ThreadBlock) error {
+	log.Warningf("ignoring %s from %s in %s thread %s",
+		block.Type.String(), block.Header.Author, t.Type.Description(), t.Id)
+
+	return t.indexBlock(&commitResult{
+		hash:   hash,
+		header: block.Header,
+	}, repo.IgnoreBlock, fmt.Sprintf("ignore-%s", hash.B58String()), "")
+}
+
+// signBlock signs a block with the local account key, and with the local peer key,
+// which binds the peer id to the account address
+func (t *Thread) signBlock(block *pb.ThreadBlock) error {
+	input, err := blockSigningBytes(block)
+	if err != nil {
+		return err
+	}
+	sig, err := t.account.Sign(input)
+	if err != nil {
+		return err
+	}
+	psig, err := t.node().PrivateKey.Sign(input)
+	if err != nil {
+		return err
+	}
+	block.Header.Sig = sig
+	block.Header.PeerSig = psig
+	return nil
+}
+
+// parentsVerified returns whether or not all parents are indexed and verified
+func (t *Thread) parentsVerified(parents []string) bool {
+	for _, p := range parents {
+		if p == "" {
+			continue
+		}
+		index := t.datastore.Blocks().Get(p)
+		if index == nil || !index.Verified {
+			return false
+		}
+	}
+	return true
+}
+
+// legacyBlockTypes are the block types written before blocks were signed
+var legacyBlockTypes = map[pb.ThreadBlock_Type]bool{
+	pb.ThreadBlock_IGNORE:   true,
+	pb.ThreadBlock_FLAG:     true,
+	pb.ThreadBlock_JOIN:     true,
+	pb.ThreadBlock_ANNOUNCE: true,
+	pb.ThreadBlock_LEAVE:    true,
+	pb.ThreadBlock_MESSAGE:  true,
+	pb.ThreadBlock_FILES:    true,
+	pb.ThreadBlock_COMMENT:  true,
+	pb.ThreadBlock_LIKE:     true,
+}
+
+// legacyBlock returns whether or not an unsigned block was written before the thread
+// was upgraded to signed blocks, i.e., it's an unclocked legacy type dated before the
+// first signed block. Its parents are checked to be legacy blocks by verifyClock.
+func (t *Thread) legacyBlock(block *pb.ThreadBlock) bool {
+	if block.Header == nil || len(block.Header.Sig) > 0 || len(block.Header.PeerSig) > 0 {
+		return false
+	}
+	if block.Header.Clock != 0 || !legacyBlockTypes[block.Type] {
+		return false
+	}
+	date, err := ptypes.Timestamp(block.Header.Date)
+	if err != nil {
+		return false
+	}
+	upgraded := t.upgradedAt()
+	return upgraded == nil || date.Before(*upgraded)
+}
+
+// upgradedAt returns the date of the first signed block, if any
+func (t *Thread) upgradedAt() *time.Time {
+	query := t.indexQuery()
+	query.Verified = true
+	query.Ascending = true
+	query.Limit = 1
+	signed := t.datastore.Blocks().ListByQuery(query)
+	if len(signed) == 0 {
+		return nil
+	}
+	return &signed[0].Date
+}
+
+// legacyParents returns whether or not all parents are indexed, unsigned and unclocked
+func (t *Thread) legacyParents(parents []string) bool {
+	for _, p := range parents {
+		if p == "" {
+			continue
+		}
+		index := t.datastore.Blocks().Get(p)
+		if index == nil || index.Verified || index.Clock != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// verifyBlockSig checks a block signature against the header address,
+// and the peer signature against the header author.
+// Only plaintext merge blocks and legacy blocks are unsigned, which are checked by the caller.
+func verifyBlockSig(block *pb.ThreadBlock) error {
+	if block.Header == nil {
+		return ErrInvalidThreadBlock
+	}
+	if len(block.Header.Sig) == 0 || len(block.Header.PeerSig) == 0 {
+		return ErrUnsignedBlock
+	}
+
+	input, err := blockSigningBytes(block)
+	if err != nil {
+		return err
+	}
+
+	accnt, err := keypair.Parse(block.Header.Address)
+	if err != nil {
+		return ErrInvalidBlockSignature
+	}
+	if err := accnt.Verify(input, block.Header.Sig); err != nil {
+		return ErrInvalidBlockSignature
+	}
+
+	pid, err := peer.IDB58Decode(block.Header.Author)
+	if err != nil {
+		return ErrBlockAuthorMismatch
+	}
+	pk, err := pid.ExtractPublicKey()
+	if err != nil || pk == nil {
+		return ErrBlockAuthorMismatch
+	}
+	ok, err := pk.Verify(input, block.Header.PeerSig)
+	if err != nil || !ok {
+		return ErrBlockAuthorMismatch
+	}
+	return nil
+}
+
+// blockSigningBytes returns the bytes covered by block signatures,
+// which is the whole block with both header signatures left empty
+func blockSigningBytes(block *pb.ThreadBlock) ([]byte, error) {
+	header := proto.Clone(block.Header).(*pb.ThreadBlockHeader)
+	header.Sig = nil
+	header.PeerSig = nil
+	return proto.Marshal(&pb.ThreadBlock{
+		Header:  header,
+		Type:    block.Type,
+		Payload: block.Payload,
+	})
+}
+
 // handleHead determines whether or not a thread can be fast-forwarded or if a merge block is needed
 // - parents are the parents of the incoming chain
 func (t *Thread) handleHead(inbound mh.Multihash, parents []string) (mh.Multihash, error) {
@@ -507,28 +840,35 @@ func (t *Thread) handleHead(inbound mh.Multihash, parents []string) (mh.Multihas
 		return nil, err
 	}
 
-	// fast-forward is possible if current HEAD is equal to one of the incoming parents
-	var fastForwardable bool
-	if head == "" {
-		fastForwardable = true
-	} else {
-		for _, parent := range parents {
-			if head == parent {
-				fastForwardable = true
-			}
+	var merged []string
+	if index := t.datastore.Blocks().Get(head); index != nil && index.Type == repo.MergeBlock {
+		merged = index.Parents
+	}
+	heads, replaceMerge := nextHeads(head, merged, inbound.B58String(), parents, t.isAncestor)
+	if heads == nil {
+		// inbound is already part of the history
+		return nil, nil
+	}
+	if replaceMerge {
+		if err := t.dropMerge(head); err != nil {
+			return nil, err
 		}
 	}
-	if fastForwardable {
+	if len(heads) == 1 {
 		// no need for a merge
-		log.Debugf("fast-forwarded to %s", inbound.B58String())
-		if err := t.updateHead(inbound); err != nil {
+		log.Debugf("fast-forwarded to %s", heads[0])
+		hash, err := mh.FromB58String(heads[0])
+		if err != nil {
+			return nil, err
+		}
+		if err := t.updateHead(hash); err != nil {
 			return nil, err
 		}
 		return nil, nil
 	}
 
 	// needs merge
-	return t.merge(inbound)
+	return t.merge(heads)
 }
 
 // updateHead updates the ref to the content id of the latest update
@@ -0,0 +1,765 @@
+package core
+
+import (
+	"crypto/rand"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	mh "gx/ipfs/QmPnFwZ2JXKnXgMw8CdBPxn7FWh6LLdjUjxV1fKHuJnkr8/go-multihash"
+	libp2pc "gx/ipfs/QmPvyPwuCgJ7pDmrKDxRtsScJgBaM5h4EpRL2qQJsmXf4n/go-libp2p-crypto"
+	"gx/ipfs/QmTRhk7cgjUf2gfQ3p2M9KPECNZEW9XUrmHcFCgog4cPgB/go-libp2p-peer"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/segmentio/ksuid"
+	"github.com/textileio/textile-go/crypto"
+	"github.com/textileio/textile-go/ipfs"
+	"github.com/textileio/textile-go/keypair"
+	"github.com/textileio/textile-go/pb"
+	"github.com/textileio/textile-go/repo"
+)
+
+var blocksRepoPath = "testdata/.textile_blocks"
+
+// testPeer is a remote thread peer with its own peer and account keys
+type testPeer struct {
+	id    peer.ID
+	sk    libp2pc.PrivKey
+	accnt *keypair.Full
+}
+
+func newTestPeer(t *testing.T) *testPeer {
+	sk, _, err := libp2pc.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := peer.IDFromPrivateKey(sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testPeer{id: id, sk: sk, accnt: keypair.Random()}
+}
+
+// selfTestPeer returns the keys of the local peer
+func selfTestPeer(node *Textile) *testPeer {
+	return &testPeer{id: node.Ipfs().Identity, sk: node.Ipfs().PrivateKey, accnt: node.Account()}
+}
+
+// newTestThread starts a node with a thread of the given type
+func newTestThread(t *testing.T, repoPath string, ttype repo.ThreadType) (*Textile, *Thread) {
+	os.RemoveAll(repoPath)
+	if err := InitRepo(InitConfig{
+		Account:  keypair.Random(),
+		RepoPath: repoPath,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	node, err := NewTextile(RunConfig{RepoPath: repoPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := node.Start(); err != nil {
+		t.Fatal(err)
+	}
+	<-node.OnlineCh()
+
+	return node, addTestThread(t, node, ttype, node.Account().Address())
+}
+
+// addTestThread adds a thread of the given type with an initiator account
+func addTestThread(t *testing.T, node *Textile, ttype repo.ThreadType, initiator string) *Thread {
+	sk, _, err := libp2pc.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	thrd, err := node.AddThread(sk, AddThreadConfig{
+		Key:       ksuid.New().String(),
+		Name:      "blocks",
+		Initiator: initiator,
+		Type:      ttype,
+		Join:      true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return thrd
+}
+
+// testBlock builds an encrypted block on the thread head, written by author.
+// The block is signed with the signer's keys, or left unsigned if signer is nil.
+func testBlock(t *testing.T, thrd *Thread, btype pb.ThreadBlock_Type, msg proto.Message, author peer.ID, signer *testPeer) (mh.Multihash, []byte) {
+	head, err := thrd.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parents []string
+	if head != "" {
+		parents = strings.Split(head, ",")
+	}
+	return testBlockOn(t, thrd, parents, thrd.nextClock(parents), btype, msg, author, signer)
+}
+
+// testBlockOn builds an encrypted block on the given parents and adds it to ipfs,
+// so that it can be fetched when followed from a child
+func testBlockOn(t *testing.T, thrd *Thread, parents []string, clock int64, btype pb.ThreadBlock_Type, msg proto.Message, author peer.ID, signer *testPeer) (mh.Multihash, []byte) {
+	return testBlockAt(t, thrd, time.Now(), parents, clock, btype, msg, author, signer)
+}
+
+// testBlockAt builds an encrypted block like testBlockOn, dated at the given time
+func testBlockAt(t *testing.T, thrd *Thread, at time.Time, parents []string, clock int64, btype pb.ThreadBlock_Type, msg proto.Message, author peer.ID, signer *testPeer) (mh.Multihash, []byte) {
+	date, err := ptypes.TimestampProto(at)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block := &pb.ThreadBlock{
+		Header: &pb.ThreadBlockHeader{
+			Date:    date,
+			Parents: parents,
+			Author:  author.Pretty(),
+			Clock:   clock,
+		},
+		Type: btype,
+	}
+	if msg != nil {
+		payload, err := ptypes.MarshalAny(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block.Payload = payload
+	}
+	if signer != nil {
+		block.Header.Address = signer.accnt.Address()
+		input, err := blockSigningBytes(block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block.Header.Sig, err = signer.accnt.Sign(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block.Header.PeerSig, err = signer.sk.Sign(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	plaintext, err := proto.Marshal(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := thrd.Encrypt(plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := thrd.addBlock(ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hash, ciphertext
+}
+
+// handleTestBlock feeds a block through the threads service as if received from the network
+func handleTestBlock(t *testing.T, node *Textile, thrd *Thread, from peer.ID, hash mh.Multihash, ciphertext []byte) error {
+	env, err := node.threadsService.NewEnvelope(thrd.Id, hash, ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = node.threadsService.Handle(from, env)
+	return err
+}
+
+func TestThreadsService_HandleForgedBlocks(t *testing.T) {
+	node, thrd := newTestThread(t, blocksRepoPath, repo.OpenThread)
+	defer func() {
+		node.Stop()
+		os.RemoveAll(blocksRepoPath)
+	}()
+
+	victim := newTestPeer(t)
+	attacker := newTestPeer(t)
+	if err := thrd.AddPeer(victim.id.Pretty()); err != nil {
+		t.Fatal(err)
+	}
+	hasVictim := func() bool {
+		for _, p := range thrd.Peers() {
+			if p.Id == victim.id.Pretty() {
+				return true
+			}
+		}
+		return false
+	}
+
+	// an unsigned leave can't remove another peer
+	hash, ciphertext := testBlock(t, thrd, pb.ThreadBlock_LEAVE, nil, victim.id, nil)
+	if err := handleTestBlock(t, node, thrd, attacker.id, hash, ciphertext); err != ErrUnsignedBlock {
+		t.Errorf("unsigned leave should be rejected, got: %v", err)
+	}
+	if !hasVictim() {
+		t.Error("unsigned leave removed a peer")
+	}
+
+	// an attacker can't write as another peer with its own keys
+	hash, ciphertext = testBlock(t, thrd, pb.ThreadBlock_LEAVE, nil, victim.id, attacker)
+	if err := handleTestBlock(t, node, thrd, attacker.id, hash, ciphertext); err != ErrBlockAuthorMismatch {
+		t.Errorf("forged leave should be rejected, got: %v", err)
+	}
+	if !hasVictim() {
+		t.Error("forged leave removed a peer")
+	}
+
+	// an attacker can't claim another peer's account
+	hash, ciphertext = testBlock(t, thrd, pb.ThreadBlock_MESSAGE, &pb.ThreadMessage{Body: "hi"}, attacker.id, attacker)
+	block := new(pb.ThreadBlock)
+	plaintext, err := thrd.Decrypt(ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := proto.Unmarshal(plaintext, block); err != nil {
+		t.Fatal(err)
+	}
+	block.Header.Address = victim.accnt.Address()
+	plaintext, err = proto.Marshal(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err = thrd.Encrypt(plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := handleTestBlock(t, node, thrd, attacker.id, hash, ciphertext); err != ErrInvalidBlockSignature {
+		t.Errorf("block with a forged address should be rejected, got: %v", err)
+	}
+	if node.datastore.Blocks().Get(hash.B58String()) != nil {
+		t.Error("block with a forged address was indexed")
+	}
+
+	// a block can't jump ahead in causal order
+	head, err := thrd.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parents := strings.Split(head, ",")
+	hash, ciphertext = testBlockOn(t, thrd, parents, thrd.nextClock(parents)+100,
+		pb.ThreadBlock_MESSAGE, &pb.ThreadMessage{Body: "hi"}, attacker.id, attacker)
+	if err := handleTestBlock(t, node, thrd, attacker.id, hash, ciphertext); err != ErrInvalidBlockClock {
+		t.Errorf("block with a forged clock should be rejected, got: %v", err)
+	}
+	if node.datastore.Blocks().Get(hash.B58String()) != nil {
+		t.Error("block with a forged clock was indexed")
+	}
+
+	// a block signed by its author is accepted
+	hash, ciphertext = testBlock(t, thrd, pb.ThreadBlock_MESSAGE, &pb.ThreadMessage{Body: "hi"}, attacker.id, attacker)
+	if err := handleTestBlock(t, node, thrd, attacker.id, hash, ciphertext); err != nil {
+		t.Errorf("signed block should be accepted, got: %s", err)
+	}
+	index := node.datastore.Blocks().Get(hash.B58String())
+	if index == nil || !index.Verified {
+		t.Error("signed block was not indexed as verified")
+	}
+}
+
+func TestThreadsService_HandleLegacyBlocks(t *testing.T) {
+	node, thrd := newTestThread(t, blocksRepoPath, repo.OpenThread)
+	defer func() {
+		node.Stop()
+		os.RemoveAll(blocksRepoPath)
+	}()
+
+	author := newTestPeer(t)
+	before := time.Now().Add(-time.Hour)
+
+	// unsigned, unclocked blocks from before the first signed block are legacy history
+	legacy, ciphertext := testBlockAt(t, thrd, before, nil, 0,
+		pb.ThreadBlock_MESSAGE, &pb.ThreadMessage{Body: "old"}, author.id, nil)
+	if err := handleTestBlock(t, node, thrd, author.id, legacy, ciphertext); err != nil {
+		t.Fatalf("legacy block should be accepted, got: %s", err)
+	}
+	index := node.datastore.Blocks().Get(legacy.B58String())
+	if index == nil || index.Verified {
+		t.Error("legacy block was not indexed as unverified")
+	}
+
+	hash, ciphertext := testBlockAt(t, thrd, before.Add(time.Minute), []string{legacy.B58String()}, 0,
+		pb.ThreadBlock_MESSAGE, &pb.ThreadMessage{Body: "older"}, author.id, nil)
+	if err := handleTestBlock(t, node, thrd, author.id, hash, ciphertext); err != nil {
+		t.Errorf("legacy block on legacy history should be accepted, got: %s", err)
+	}
+
+	// legacy blocks can't be built on signed history
+	head, err := thrd.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, ciphertext = testBlockAt(t, thrd, before, strings.Split(head, ","), 0,
+		pb.ThreadBlock_MESSAGE, &pb.ThreadMessage{Body: "hi"}, author.id, nil)
+	if err := handleTestBlock(t, node, thrd, author.id, hash, ciphertext); err != ErrInvalidBlockClock {
+		t.Errorf("legacy block on signed history should be rejected, got: %v", err)
+	}
+
+	// or be dated after the upgrade
+	hash, ciphertext = testBlockAt(t, thrd, time.Now(), []string{legacy.B58String()}, 0,
+		pb.ThreadBlock_MESSAGE, &pb.ThreadMessage{Body: "hi"}, author.id, nil)
+	if err := handleTestBlock(t, node, thrd, author.id, hash, ciphertext); err != ErrUnsignedBlock {
+		t.Errorf("unsigned block after the upgrade should be rejected, got: %v", err)
+	}
+}
+
+func TestThreadsService_ThreadTypeWrites(t *testing.T) {
+	node, thrd := newTestThread(t, blocksRepoPath, repo.ReadOnlyThread)
+	defer func() {
+		node.Stop()
+		os.RemoveAll(blocksRepoPath)
+	}()
+
+	// local writes to threads started by another account follow the thread type
+	initiator := keypair.Random().Address()
+	readOnly := addTestThread(t, node, repo.ReadOnlyThread, initiator)
+	if _, err := readOnly.AddMessage("hi"); err != ErrWriteNotAllowed {
+		t.Errorf("message in a read-only thread should not be allowed, got: %v", err)
+	}
+	public := addTestThread(t, node, repo.PublicThread, initiator)
+	if _, err := public.AddFiles(nil, "", nil); err != ErrWriteNotAllowed {
+		t.Errorf("files in a public thread should not be allowed, got: %v", err)
+	}
+	if _, err := public.AddMessage("hi"); err != nil {
+		t.Errorf("message in a public thread should be allowed, got: %s", err)
+	}
+
+	// incoming writes from other peers are indexed as ignored
+	writer := newTestPeer(t)
+	hash, ciphertext := testBlock(t, thrd, pb.ThreadBlock_MESSAGE, &pb.ThreadMessage{Body: "hi"}, writer.id, writer)
+	if err := handleTestBlock(t, node, thrd, writer.id, hash, ciphertext); err != nil {
+		t.Fatalf("handle message failed: %s", err)
+	}
+	if !thrd.ignored(hash.B58String()) {
+		t.Error("message from a non-initiator in a read-only thread was not ignored")
+	}
+
+	public = addTestThread(t, node, repo.PublicThread, node.Account().Address())
+	hash, ciphertext = testBlock(t, public, pb.ThreadBlock_FILES, &pb.ThreadFiles{Target: hash.B58String()}, writer.id, writer)
+	if err := handleTestBlock(t, node, public, writer.id, hash, ciphertext); err != nil {
+		t.Fatalf("handle files failed: %s", err)
+	}
+	if !public.ignored(hash.B58String()) {
+		t.Error("files from a non-initiator in a public thread were not ignored")
+	}
+	hash, ciphertext = testBlock(t, public, pb.ThreadBlock_MESSAGE, &pb.ThreadMessage{Body: "hi"}, writer.id, writer)
+	if err := handleTestBlock(t, node, public, writer.id, hash, ciphertext); err != nil {
+		t.Fatalf("handle message failed: %s", err)
+	}
+	if public.ignored(hash.B58String()) {
+		t.Error("message from a non-initiator in a public thread was ignored")
+	}
+}
+
+func TestThreadsService_HandleCausalOrder(t *testing.T) {
+	node, thrd := newTestThread(t, blocksRepoPath, repo.ReadOnlyThread)
+	defer func() {
+		node.Stop()
+		os.RemoveAll(blocksRepoPath)
+	}()
+
+	self := selfTestPeer(node)
+	writer := newTestPeer(t)
+
+	// the initiator grants a writer role, which the writer then uses
+	head, err := thrd.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parents := strings.Split(head, ",")
+	clock := thrd.nextClock(parents)
+	role, _ := testBlockOn(t, thrd, parents, clock, pb.ThreadBlock_ROLE, &pb.ThreadRole{
+		Target: "role-" + writer.id.Pretty(),
+		Role:   pb.ThreadRole_WRITER,
+	}, self.id, self)
+	msg, ciphertext := testBlockOn(t, thrd, []string{role.B58String()}, clock+1,
+		pb.ThreadBlock_MESSAGE, &pb.ThreadMessage{Body: "hi"}, writer.id, writer)
+
+	// the message arrives first, as it would during newest first sync
+	if err := handleTestBlock(t, node, thrd, writer.id, msg, ciphertext); err != nil {
+		t.Fatalf("handle message failed: %s", err)
+	}
+	if node.datastore.Blocks().Get(role.B58String()) == nil {
+		t.Fatal("role was not synced")
+	}
+	index := node.datastore.Blocks().Get(msg.B58String())
+	if index == nil || index.Type != repo.MessageBlock {
+		t.Error("message was not checked against the role that allows it")
+	}
+	if thrd.ignored(msg.B58String()) {
+		t.Error("message allowed by an earlier role was ignored")
+	}
+}
+
+func TestThreadsService_HandleKickedPeer(t *testing.T) {
+	node, thrd := newTestThread(t, blocksRepoPath, repo.OpenThread)
+	defer func() {
+		node.Stop()
+		os.RemoveAll(blocksRepoPath)
+	}()
+
+	member := newTestPeer(t)
+	hash, ciphertext := testBlock(t, thrd, pb.ThreadBlock_JOIN, &pb.ThreadJoin{}, member.id, member)
+	if err := handleTestBlock(t, node, thrd, member.id, hash, ciphertext); err != nil {
+		t.Fatalf("handle join failed: %s", err)
+	}
+	if !thrd.hasPeer(member.id.Pretty()) {
+		t.Fatal("member did not join")
+	}
+
+	if _, err := thrd.AddKick(member.id.Pretty()); err != nil {
+		t.Fatalf("add kick failed: %s", err)
+	}
+
+	// blocks written after the kick are rejected
+	hash, ciphertext = testBlock(t, thrd, pb.ThreadBlock_MESSAGE, &pb.ThreadMessage{Body: "hi"}, member.id, member)
+	if err := handleTestBlock(t, node, thrd, member.id, hash, ciphertext); err != nil {
+		t.Fatalf("handle message failed: %s", err)
+	}
+	if !thrd.ignored(hash.B58String()) {
+		t.Error("message from a kicked peer was not ignored")
+	}
+
+	// a kicked peer that is invited back can join and write again
+	hash, ciphertext = testBlock(t, thrd, pb.ThreadBlock_JOIN, &pb.ThreadJoin{}, member.id, member)
+	if err := handleTestBlock(t, node, thrd, member.id, hash, ciphertext); err != nil {
+		t.Fatalf("handle join failed: %s", err)
+	}
+	if !thrd.hasPeer(member.id.Pretty()) {
+		t.Error("kicked peer could not join again")
+	}
+	hash, ciphertext = testBlock(t, thrd, pb.ThreadBlock_MESSAGE, &pb.ThreadMessage{Body: "hi"}, member.id, member)
+	if err := handleTestBlock(t, node, thrd, member.id, hash, ciphertext); err != nil {
+		t.Fatalf("handle message failed: %s", err)
+	}
+	if thrd.ignored(hash.B58String()) {
+		t.Error("message from a peer that joined again was ignored")
+	}
+}
+
+func TestThreadsService_HandleRetiredKey(t *testing.T) {
+	node, thrd := newTestThread(t, blocksRepoPath, repo.OpenThread)
+	defer func() {
+		node.Stop()
+		os.RemoveAll(blocksRepoPath)
+	}()
+
+	member := newTestPeer(t)
+	if err := thrd.AddPeer(member.id.Pretty()); err != nil {
+		t.Fatal(err)
+	}
+	oldKey := thrd.privKey
+	if _, err := thrd.rotateKey(); err != nil {
+		t.Fatalf("rotate key failed: %s", err)
+	}
+
+	// a block built on the rotation can't use the old key
+	hash, ciphertext := testBlock(t, thrd, pb.ThreadBlock_MESSAGE, &pb.ThreadMessage{Body: "hi"}, member.id, member)
+	plaintext, err := thrd.Decrypt(ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err = crypto.Encrypt(oldKey.GetPublic(), plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err = thrd.addBlock(ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := handleTestBlock(t, node, thrd, member.id, hash, ciphertext); err != nil {
+		t.Fatalf("handle message failed: %s", err)
+	}
+	if !thrd.ignored(hash.B58String()) {
+		t.Error("block encrypted with a retired key was not ignored")
+	}
+
+	// the same goes for read markers, which are dropped
+	hash, ciphertext = testBlock(t, thrd, pb.ThreadBlock_READ, &pb.ThreadRead{Target: hash.B58String()}, member.id, member)
+	plaintext, err = thrd.Decrypt(ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err = crypto.Encrypt(oldKey.GetPublic(), plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err = mh.Sum(ciphertext, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := handleTestBlock(t, node, thrd, member.id, hash, ciphertext); err != nil {
+		t.Fatalf("handle read failed: %s", err)
+	}
+	if thrd.datastore.ThreadReceipts().Get(thrd.Id, member.id.Pretty()) != nil {
+		t.Error("read marker encrypted with a retired key was handled")
+	}
+}
+
+func TestThreadsService_HandleInviteJoins(t *testing.T) {
+	node, thrd := newTestThread(t, blocksRepoPath, repo.OpenThread)
+	defer func() {
+		node.Stop()
+		os.RemoveAll(blocksRepoPath)
+	}()
+
+	// a single use external invite
+	invite, key, err := thrd.AddLimitedExternalInvite(0, 1)
+	if err != nil {
+		t.Fatalf("add external invite failed: %s", err)
+	}
+	ciphertext, err := ipfs.DataAtPath(node.Ipfs(), invite.B58String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext, err := crypto.DecryptAES(ciphertext, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := new(pb.ThreadBlock)
+	if err := proto.Unmarshal(plaintext, block); err != nil {
+		t.Fatal(err)
+	}
+	msg := new(pb.ThreadInvite)
+	if err := ptypes.UnmarshalAny(block.Payload, msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Record == nil {
+		t.Fatal("external invite has no record")
+	}
+
+	join := func(record *pb.ThreadInviteRecord) bool {
+		joiner := newTestPeer(t)
+		var inviteId string
+		if record != nil {
+			inviteId = invite.B58String()
+		}
+		hash, ciphertext := testBlock(t, thrd, pb.ThreadBlock_JOIN, &pb.ThreadJoin{
+			Invite: inviteId,
+			Record: record,
+		}, joiner.id, joiner)
+		if err := handleTestBlock(t, node, thrd, joiner.id, hash, ciphertext); err != nil {
+			t.Fatalf("handle join failed: %s", err)
+		}
+		return !thrd.ignored(hash.B58String())
+	}
+
+	if !join(msg.Record) {
+		t.Error("join with a valid invite record was ignored")
+	}
+	if join(msg.Record) {
+		t.Error("join with a used up invite record was allowed")
+	}
+	if join(nil) {
+		t.Error("join without an invite record was allowed after the key was handed out")
+	}
+	forged := *msg.Record
+	forged.MaxUses = 5
+	if join(&forged) {
+		t.Error("join with a forged invite record was allowed")
+	}
+
+	// a signed record must still carry a ksuid nonce
+	malformed := *msg.Record
+	malformed.Nonce = "x' or '1'='1"
+	input, err := inviteRecordSigningBytes(&malformed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	malformed.Sig, err = node.Ipfs().PrivateKey.Sign(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if join(&malformed) {
+		t.Error("join with a malformed invite nonce was allowed")
+	}
+}
+
+func TestThreadsService_HandleCheckpoint(t *testing.T) {
+	node, thrd := newTestThread(t, blocksRepoPath, repo.OpenThread)
+	defer func() {
+		node.Stop()
+		os.RemoveAll(blocksRepoPath)
+	}()
+
+	member := newTestPeer(t)
+	hash, ciphertext := testBlock(t, thrd, pb.ThreadBlock_JOIN, &pb.ThreadJoin{}, member.id, member)
+	if err := handleTestBlock(t, node, thrd, member.id, hash, ciphertext); err != nil {
+		t.Fatalf("handle join failed: %s", err)
+	}
+
+	stranger := newTestPeer(t)
+	checkpoint := &pb.ThreadCheckpoint{
+		Members: []*pb.ThreadCheckpoint_Member{
+			{Id: member.id.Pretty(), Address: member.accnt.Address()},
+			{Id: stranger.id.Pretty(), Address: stranger.accnt.Address()},
+		},
+	}
+
+	// checkpoints from peers below moderator are not trusted
+	hash, ciphertext = testBlock(t, thrd, pb.ThreadBlock_CHECKPOINT, checkpoint, member.id, member)
+	if err := handleTestBlock(t, node, thrd, member.id, hash, ciphertext); err != nil {
+		t.Fatalf("handle checkpoint failed: %s", err)
+	}
+	if !thrd.ignored(hash.B58String()) {
+		t.Error("checkpoint from a default peer was not ignored")
+	}
+
+	if _, err := thrd.AddRole(member.id.Pretty(), repo.ModeratorRole); err != nil {
+		t.Fatalf("add role failed: %s", err)
+	}
+
+	// members without a verified join are not added by a trusted checkpoint
+	head, err := thrd.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkpoint.Heads = strings.Split(head, ",")
+	hash, ciphertext = testBlock(t, thrd, pb.ThreadBlock_CHECKPOINT, checkpoint, member.id, member)
+	if err := handleTestBlock(t, node, thrd, member.id, hash, ciphertext); err != nil {
+		t.Fatalf("handle checkpoint failed: %s", err)
+	}
+	if thrd.ignored(hash.B58String()) {
+		t.Error("checkpoint from a moderator was ignored")
+	}
+	if !thrd.hasPeer(member.id.Pretty()) {
+		t.Error("checkpoint author was removed")
+	}
+	if thrd.hasPeer(stranger.id.Pretty()) {
+		t.Error("checkpoint added a member without a verified join")
+	}
+}
+
+func TestThreadsService_HandleJoinDecision(t *testing.T) {
+	node, thrd := newTestThread(t, blocksRepoPath, repo.OpenThread)
+	defer func() {
+		node.Stop()
+		os.RemoveAll(blocksRepoPath)
+	}()
+
+	member := newTestPeer(t)
+	hash, ciphertext := testBlock(t, thrd, pb.ThreadBlock_JOIN, &pb.ThreadJoin{}, member.id, member)
+	if err := handleTestBlock(t, node, thrd, member.id, hash, ciphertext); err != nil {
+		t.Fatalf("handle join failed: %s", err)
+	}
+
+	requester := newTestPeer(t)
+	date, err := ptypes.TimestampProto(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := thrd.handleJoinRequest(requester.id, &pb.ThreadJoinRequest{
+		Thread:  thrd.Id,
+		Address: requester.accnt.Address(),
+		Date:    date,
+	})
+	if err != nil || req == nil {
+		t.Fatalf("handle join request failed: %s", err)
+	}
+	decision := &pb.ThreadJoinDecision{Target: requester.id.Pretty()}
+
+	// only admins may decide join requests
+	hash, ciphertext = testBlock(t, thrd, pb.ThreadBlock_JOIN_DECISION, decision, member.id, member)
+	if err := handleTestBlock(t, node, thrd, member.id, hash, ciphertext); err != nil {
+		t.Fatalf("handle join decision failed: %s", err)
+	}
+	if !thrd.ignored(hash.B58String()) {
+		t.Error("join decision from a default peer was not ignored")
+	}
+	if thrd.datastore.ThreadJoinRequests().Get(req.Id).Status != repo.PendingJoinRequest {
+		t.Error("join decision from a default peer decided the request")
+	}
+
+	if _, err := thrd.AddRole(member.id.Pretty(), repo.AdminRole); err != nil {
+		t.Fatalf("add role failed: %s", err)
+	}
+
+	// another admin's decision is applied to the local request
+	hash, ciphertext = testBlock(t, thrd, pb.ThreadBlock_JOIN_DECISION, decision, member.id, member)
+	if err := handleTestBlock(t, node, thrd, member.id, hash, ciphertext); err != nil {
+		t.Fatalf("handle join decision failed: %s", err)
+	}
+	decided := thrd.datastore.ThreadJoinRequests().Get(req.Id)
+	if decided.Status != repo.DeniedJoinRequest || decided.DeciderId != member.id.Pretty() {
+		t.Error("join decision from an admin was not applied")
+	}
+}
+
+func TestThreadsService_HandleInvalidRole(t *testing.T) {
+	node, thrd := newTestThread(t, blocksRepoPath, repo.OpenThread)
+	defer func() {
+		node.Stop()
+		os.RemoveAll(blocksRepoPath)
+	}()
+
+	admin := newTestPeer(t)
+	hash, ciphertext := testBlock(t, thrd, pb.ThreadBlock_JOIN, &pb.ThreadJoin{}, admin.id, admin)
+	if err := handleTestBlock(t, node, thrd, admin.id, hash, ciphertext); err != nil {
+		t.Fatalf("handle join failed: %s", err)
+	}
+	if _, err := thrd.AddRole(admin.id.Pretty(), repo.AdminRole); err != nil {
+		t.Fatalf("add role failed: %s", err)
+	}
+
+	invalid := []*pb.ThreadRole{
+		{Target: admin.id.Pretty(), Role: pb.ThreadRole_WRITER},
+		{Target: "role-notapeer", Role: pb.ThreadRole_WRITER},
+		{Target: "role-" + admin.id.Pretty(), Role: pb.ThreadRole_Role(repo.AdminRole + 1)},
+	}
+	for _, msg := range invalid {
+		hash, ciphertext := testBlock(t, thrd, pb.ThreadBlock_ROLE, msg, admin.id, admin)
+		if err := handleTestBlock(t, node, thrd, admin.id, hash, ciphertext); err != nil {
+			t.Fatalf("handle role failed: %s", err)
+		}
+		if !thrd.ignored(hash.B58String()) {
+			t.Errorf("role with target %s and role %d was not ignored", msg.Target, msg.Role)
+		}
+	}
+	if role := thrd.peerRole(admin.id.Pretty(), ""); role != repo.AdminRole {
+		t.Errorf("invalid roles changed the granted role to %s", role.Description())
+	}
+}
+
+func TestThreadsService_HandleInvalidReaction(t *testing.T) {
+	node, thrd := newTestThread(t, blocksRepoPath, repo.OpenThread)
+	defer func() {
+		node.Stop()
+		os.RemoveAll(blocksRepoPath)
+	}()
+
+	author := newTestPeer(t)
+	target, err := thrd.AddMessage("hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := []string{
+		strings.Repeat("x", maxReactionLength+1),
+		"two words",
+		"\xff",
+	}
+	for _, reaction := range invalid {
+		msg := &pb.ThreadLike{Target: target.B58String(), Reaction: reaction}
+		hash, ciphertext := testBlock(t, thrd, pb.ThreadBlock_LIKE, msg, author.id, author)
+		if err := handleTestBlock(t, node, thrd, author.id, hash, ciphertext); err != nil {
+			t.Fatalf("handle like failed: %s", err)
+		}
+		if !thrd.ignored(hash.B58String()) {
+			t.Errorf("like with reaction %q was not ignored", reaction)
+		}
+	}
+
+	msg := &pb.ThreadLike{Target: target.B58String(), Reaction: ":tada:"}
+	hash, ciphertext := testBlock(t, thrd, pb.ThreadBlock_LIKE, msg, author.id, author)
+	if err := handleTestBlock(t, node, thrd, author.id, hash, ciphertext); err != nil {
+		t.Fatalf("handle like failed: %s", err)
+	}
+	if thrd.ignored(hash.B58String()) {
+		t.Error("valid reaction was ignored")
+	}
+}
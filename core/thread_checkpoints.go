@@ -0,0 +1,245 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	mh "gx/ipfs/QmPnFwZ2JXKnXgMw8CdBPxn7FWh6LLdjUjxV1fKHuJnkr8/go-multihash"
+	"gx/ipfs/QmTRhk7cgjUf2gfQ3p2M9KPECNZEW9XUrmHcFCgog4cPgB/go-libp2p-peer"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/textileio/textile-go/pb"
+	"github.com/textileio/textile-go/repo"
+)
+
+// ErrCheckpointNotAllowed indicates a checkpoint was attempted without permission
+var ErrCheckpointNotAllowed = errors.New("not allowed to checkpoint this thread")
+
+// AddCheckpoint adds an outgoing checkpoint block, which summarizes thread members and
+// the state deciding who may write, so that joining peers can skip walking the full history.
+// Content behind the checkpoint heads is loaded lazily with LoadHistory.
+func (t *Thread) AddCheckpoint() (mh.Multihash, error) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	if !t.canWrite(pb.ThreadBlock_CHECKPOINT) {
+		return nil, ErrCheckpointNotAllowed
+	}
+
+	msg, err := t.buildCheckpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.commitBlock(msg, pb.ThreadBlock_CHECKPOINT, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.indexBlock(res, repo.CheckpointBlock, "", checkpointBody(msg)); err != nil {
+		return nil, err
+	}
+
+	if err := t.updateHead(res.hash); err != nil {
+		return nil, err
+	}
+
+	if err := t.post(res, t.Peers()); err != nil {
+		return nil, err
+	}
+
+	log.Debugf("added CHECKPOINT to %s: %s", t.Id, res.hash.B58String())
+
+	return res.hash, nil
+}
+
+// LoadHistory syncs the history hidden behind indexed checkpoints,
+// stopping again at the next older checkpoint
+func (t *Thread) LoadHistory() error {
+	// walk the local index to find where history is missing
+	var missing []string
+	seen := make(map[string]bool)
+	var queue []string
+	for _, checkpoint := range t.datastore.Blocks().ListByQuery(t.indexQuery(repo.CheckpointBlock)) {
+		queue = append(queue, checkpoint.Parents...)
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		index := t.datastore.Blocks().Get(id)
+		if index == nil {
+			missing = append(missing, id)
+			continue
+		}
+		if index.Type != repo.CheckpointBlock {
+			queue = append(queue, index.Parents...)
+		}
+	}
+
+	return t.followParents(missing)
+}
+
+// handleCheckpointBlock handles an incoming checkpoint block.
+// State blocks are fetched without following their parents and members are added directly.
+// Content is left behind the checkpoint until history is loaded.
+func (t *Thread) handleCheckpointBlock(hash mh.Multihash, block *pb.ThreadBlock) (*pb.ThreadCheckpoint, error) {
+	msg := new(pb.ThreadCheckpoint)
+	if err := ptypes.UnmarshalAny(block.Payload, msg); err != nil {
+		return nil, err
+	}
+
+	if err := t.indexBlock(&commitResult{
+		hash:   hash,
+		header: block.Header,
+	}, repo.CheckpointBlock, "", checkpointBody(msg)); err != nil {
+		return nil, err
+	}
+
+	var unknown []string
+	for _, id := range msg.State {
+		if t.datastore.Blocks().Get(id) == nil {
+			unknown = append(unknown, id)
+		}
+	}
+
+	limit := t.syncConcurrency()
+	for i := 0; i < len(unknown); i += limit {
+		end := i + limit
+		if end > len(unknown) {
+			end = len(unknown)
+		}
+		batch := unknown[i:end]
+		fetched := t.fetchBlocks(batch)
+		for j, id := range batch {
+			if err := t.syncBlock(id, fetched[j]); err != nil {
+				log.Warningf("failed to load checkpoint block %s: %s", id, err)
+			}
+		}
+	}
+
+	// members are only added once their joins have been checked
+	self := t.node().Identity.Pretty()
+	for _, member := range msg.Members {
+		if member.Id == self || !t.memberVerified(block, member) {
+			continue
+		}
+		pid, err := peer.IDB58Decode(member.Id)
+		if err != nil {
+			return nil, err
+		}
+		if err := t.addOrUpdatePeer(pid, member.Address, member.Username, member.Inboxes); err != nil {
+			return nil, err
+		}
+	}
+
+	return msg, nil
+}
+
+// memberVerified returns whether or not a checkpoint member is backed by a signature,
+// either the checkpoint's own or that of an indexed join by the member
+func (t *Thread) memberVerified(block *pb.ThreadBlock, member *pb.ThreadCheckpoint_Member) bool {
+	if member.Id == block.Header.Author {
+		return member.Address == block.Header.Address
+	}
+	contact := t.datastore.Contacts().Get(member.Id)
+	if contact == nil || contact.Address != member.Address {
+		return false
+	}
+	query := t.indexQuery(repo.JoinBlock)
+	query.AuthorIds = []string{member.Id}
+	query.Verified = true
+	return t.datastore.Blocks().CountByQuery(query) > 0
+}
+
+// trustedCheckpoint returns whether or not a block is a signed checkpoint from a peer
+// that is currently a moderator or admin, in which case the history behind it is loaded lazily
+func (t *Thread) trustedCheckpoint(hash mh.Multihash, block *pb.ThreadBlock) bool {
+	if block.Type != pb.ThreadBlock_CHECKPOINT || verifyBlockSig(block) != nil {
+		return false
+	}
+	if t.peerRole(block.Header.Author, block.Header.Address) < repo.ModeratorRole {
+		return false
+	}
+
+	// a checkpoint must summarize the history it was written on
+	msg := new(pb.ThreadCheckpoint)
+	if err := ptypes.UnmarshalAny(block.Payload, msg); err != nil {
+		return false
+	}
+	if !sameHeads(msg.Heads, block.Header.Parents) {
+		return false
+	}
+	return t.blockAllowed(hash, block)
+}
+
+// buildCheckpoint summarizes the current heads, members, and state of a thread
+func (t *Thread) buildCheckpoint() (*pb.ThreadCheckpoint, error) {
+	msg := new(pb.ThreadCheckpoint)
+
+	head, err := t.Head()
+	if err != nil {
+		return nil, err
+	}
+	if head != "" {
+		msg.Heads = strings.Split(head, ",")
+	}
+
+	join, err := t.buildJoin("", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	msg.Members = append(msg.Members, &pb.ThreadCheckpoint_Member{
+		Id:       t.node().Identity.Pretty(),
+		Address:  t.account.Address(),
+		Username: join.Username,
+		Inboxes:  join.Inboxes,
+	})
+	for _, tp := range t.Peers() {
+		member := &pb.ThreadCheckpoint_Member{Id: tp.Id}
+		if contact := t.datastore.Contacts().Get(tp.Id); contact != nil {
+			member.Address = contact.Address
+			member.Username = contact.Username
+			member.Inboxes = contact.Inboxes
+		}
+		msg.Members = append(msg.Members, member)
+	}
+
+	// latest role and kick per peer, then keys, invites, and joins, which together
+	// decide who may write, then latest meta, all oldest first
+	var state []repo.Block
+	latest := make(map[string]bool)
+	query := t.indexQuery(repo.RoleBlock, repo.KickBlock)
+	query.Order = repo.CausalOrder
+	for _, block := range t.datastore.Blocks().ListByQuery(query) {
+		if !latest[block.Target] {
+			latest[block.Target] = true
+			state = append(state, block)
+		}
+	}
+	query = t.indexQuery(repo.KeyBlock, repo.ExternalInviteBlock, repo.JoinBlock)
+	query.Verified = true
+	state = append(state, t.datastore.Blocks().ListByQuery(query)...)
+	query = t.indexQuery(repo.MetaBlock)
+	query.Limit = 1
+	state = append(state, t.datastore.Blocks().ListByQuery(query)...)
+	sort.SliceStable(state, func(i, j int) bool {
+		return state[i].Clock < state[j].Clock
+	})
+	for _, block := range state {
+		msg.State = append(msg.State, block.Id)
+	}
+
+	return msg, nil
+}
+
+// checkpointBody returns a short summary of a checkpoint, used as its indexed body
+func checkpointBody(msg *pb.ThreadCheckpoint) string {
+	return fmt.Sprintf("%d members, %d heads", len(msg.Members), len(msg.Heads))
+}
@@ -10,12 +10,30 @@ import (
 
 // AddComment adds an outgoing comment block
 func (t *Thread) AddComment(target string, body string) (mh.Multihash, error) {
+	return t.AddCommentReply(target, "", body)
+}
+
+// AddCommentReply adds an outgoing comment block on a target, in reply to
+// a message or comment about it. An empty reply is a top-level comment.
+func (t *Thread) AddCommentReply(target string, replyTo string, body string) (mh.Multihash, error) {
 	t.mux.Lock()
 	defer t.mux.Unlock()
 
+	if !t.canWrite(pb.ThreadBlock_COMMENT) {
+		return nil, ErrWriteNotAllowed
+	}
+
+	if replyTo != "" {
+		parent := t.datastore.Blocks().Get(replyTo)
+		if parent == nil || parent.ThreadId != t.Id || !repliable(parent.Type) {
+			return nil, ErrInvalidReplyTarget
+		}
+	}
+
 	msg := &pb.ThreadComment{
-		Target: target,
-		Body:   body,
+		Target:  target,
+		Body:    body,
+		ReplyTo: replyTo,
 	}
 
 	res, err := t.commitBlock(msg, pb.ThreadBlock_COMMENT, nil)
@@ -23,7 +41,7 @@ func (t *Thread) AddComment(target string, body string) (mh.Multihash, error) {
 		return nil, err
 	}
 
-	if err := t.indexBlock(res, repo.CommentBlock, target, body); err != nil {
+	if err := t.indexBlock(res, repo.CommentBlock, commentTarget(msg), body); err != nil {
 		return nil, err
 	}
 
@@ -50,8 +68,17 @@ func (t *Thread) handleCommentBlock(hash mh.Multihash, block *pb.ThreadBlock) (*
 	if err := t.indexBlock(&commitResult{
 		hash:   hash,
 		header: block.Header,
-	}, repo.CommentBlock, msg.Target, msg.Body); err != nil {
+	}, repo.CommentBlock, commentTarget(msg), msg.Body); err != nil {
 		return nil, err
 	}
 	return msg, nil
 }
+
+// commentTarget returns the indexed target of a comment, which is its parent
+// if it's a reply, so that it's threaded under the parent
+func commentTarget(msg *pb.ThreadComment) string {
+	if msg.ReplyTo != "" {
+		return msg.ReplyTo
+	}
+	return msg.Target
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	mh "gx/ipfs/QmPnFwZ2JXKnXgMw8CdBPxn7FWh6LLdjUjxV1fKHuJnkr8/go-multihash"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/textileio/textile-go/pb"
+	"github.com/textileio/textile-go/repo"
+)
+
+// ErrEditNotAllowed indicates an edit targeted a block that was not authored locally
+var ErrEditNotAllowed = errors.New("only the author can edit a block")
+
+// ErrBlockNotEditable indicates an edit targeted a block type without a body
+var ErrBlockNotEditable = errors.New("only messages, comments, and files can be edited")
+
+// AddEdit adds an outgoing edit block targeted at a message, comment, or files block
+func (t *Thread) AddEdit(block string, body string) (mh.Multihash, error) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	if !t.canWrite(pb.ThreadBlock_EDIT) {
+		return nil, ErrWriteNotAllowed
+	}
+
+	rblock := t.datastore.Blocks().Get(block)
+	if rblock == nil || rblock.ThreadId != t.Id {
+		return nil, ErrBlockNotFound
+	}
+	if !editable(rblock.Type) {
+		return nil, ErrBlockNotEditable
+	}
+	if rblock.AuthorId != t.node().Identity.Pretty() {
+		return nil, ErrEditNotAllowed
+	}
+
+	// adding an edit specific prefix here to ensure future flexibility
+	target := fmt.Sprintf("edit-%s", block)
+
+	msg := &pb.ThreadEdit{
+		Target: target,
+		Body:   body,
+	}
+
+	res, err := t.commitBlock(msg, pb.ThreadBlock_EDIT, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.indexBlock(res, repo.EditBlock, target, body); err != nil {
+		return nil, err
+	}
+
+	if err := t.updateHead(res.hash); err != nil {
+		return nil, err
+	}
+
+	if err := t.post(res, t.Peers()); err != nil {
+		return nil, err
+	}
+
+	log.Debugf("added EDIT to %s: %s", t.Id, res.hash.B58String())
+
+	return res.hash, nil
+}
+
+// handleEditBlock handles an incoming edit block.
+// The target may not be known yet during back prop, so edits
+// are matched against the target author when read.
+func (t *Thread) handleEditBlock(hash mh.Multihash, block *pb.ThreadBlock) (*pb.ThreadEdit, error) {
+	msg := new(pb.ThreadEdit)
+	if err := ptypes.UnmarshalAny(block.Payload, msg); err != nil {
+		return nil, err
+	}
+
+	if err := t.indexBlock(&commitResult{
+		hash:   hash,
+		header: block.Header,
+	}, repo.EditBlock, msg.Target, msg.Body); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// editable returns whether or not a block type has a body that can be edited
+func editable(btype repo.BlockType) bool {
+	switch btype {
+	case repo.MessageBlock, repo.CommentBlock, repo.FilesBlock:
+		return true
+	default:
+		return false
+	}
+}
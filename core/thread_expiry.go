@@ -0,0 +1,89 @@
+package core
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/textileio/textile-go/repo"
+)
+
+// ErrInvalidTtl indicates a negative ttl
+var ErrInvalidTtl = errors.New("ttl must not be negative")
+
+// indexExpiringBlock indexes a message or files block, which expires after the ttl
+// carried by the block. Every peer derives the same expiry from the block date.
+func (t *Thread) indexExpiringBlock(commit *commitResult, blockType repo.BlockType, target string, body string, ttl int64) error {
+	date, err := ptypes.Timestamp(commit.header.Date)
+	if err != nil {
+		return err
+	}
+	return t.indexBlockUntil(commit, blockType, target, body, expiry(date, ttl))
+}
+
+// blockTtl returns the ttl written into an outgoing block, which is the thread ttl if not set,
+// so that peers don't depend on their own copy of the thread settings
+func (t *Thread) blockTtl(ttl int64) int64 {
+	if ttl > 0 {
+		return ttl
+	}
+	mod := t.datastore.Threads().Get(t.Id)
+	if mod == nil || mod.Ttl <= 0 {
+		return 0
+	}
+	return mod.Ttl
+}
+
+// expiry returns the date a block expires given its ttl in seconds, if any
+func expiry(date time.Time, ttl int64) *time.Time {
+	if ttl <= 0 {
+		return nil
+	}
+	expires := date.Add(time.Duration(ttl) * time.Second)
+	return &expires
+}
+
+// expireBlock sweeps an expired block along with its comments, likes, edits,
+// and notifications, and unpins its files if not used by another block.
+// Swept blocks are kept as tombstones, so they are not fetched again during sync
+// and their clocks and parents remain known.
+func (t *Thread) expireBlock(block repo.Block) error {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	// the block row is still needed to tell if files are shared
+	if err := t.ignoreBlockTarget(&block); err != nil {
+		log.Warningf("error removing files for expired block %s: %s", block.Id, err)
+	}
+
+	deps := t.indexQuery(repo.CommentBlock, repo.LikeBlock)
+	deps.Target = block.Id
+	edits := t.indexQuery(repo.EditBlock)
+	edits.Target = "edit-" + block.Id
+	for _, dep := range append(t.datastore.Blocks().ListByQuery(deps), t.datastore.Blocks().ListByQuery(edits)...) {
+		if err := t.sweepBlock(dep.Id, *block.Expires); err != nil {
+			return err
+		}
+	}
+
+	log.Debugf("expired %s block %s in %s", block.Type.Description(), block.Id, t.Id)
+
+	return t.sweepBlock(block.Id, *block.Expires)
+}
+
+// sweepBlock clears a block index, its notifications, and its search text,
+// keeping the index as an expired tombstone
+func (t *Thread) sweepBlock(id string, expires time.Time) error {
+	if err := t.datastore.Notifications().DeleteByBlock(id); err != nil {
+		return err
+	}
+	if err := t.datastore.Search().Delete(id); err != nil {
+		return err
+	}
+	return t.datastore.Blocks().Sweep(id, expires)
+}
+
+// expired returns whether or not a block is past its expiry
+func expired(block repo.Block) bool {
+	return block.Expires != nil && !block.Expires.After(time.Now())
+}
@@ -22,9 +22,22 @@ import (
 
 // AddFile adds an outgoing files block
 func (t *Thread) AddFiles(node ipld.Node, caption string, keys Keys) (mh.Multihash, error) {
+	return t.AddExpiringFiles(node, caption, keys, 0)
+}
+
+// AddExpiringFiles adds an outgoing files block, which expires after ttl seconds.
+// A zero ttl falls back to the thread ttl.
+func (t *Thread) AddExpiringFiles(node ipld.Node, caption string, keys Keys, ttl int64) (mh.Multihash, error) {
 	t.mux.Lock()
 	defer t.mux.Unlock()
 
+	if !t.canWrite(pb.ThreadBlock_FILES) {
+		return nil, ErrWriteNotAllowed
+	}
+	if ttl < 0 {
+		return nil, ErrInvalidTtl
+	}
+
 	if t.Schema == nil {
 		return nil, ErrThreadSchemaRequired
 	}
@@ -53,6 +66,7 @@ func (t *Thread) AddFiles(node ipld.Node, caption string, keys Keys) (mh.Multiha
 		Target: node.Cid().Hash().B58String(),
 		Body:   caption,
 		Keys:   keys,
+		Ttl:    t.blockTtl(ttl),
 	}
 
 	res, err := t.commitBlock(msg, pb.ThreadBlock_FILES, nil)
@@ -60,7 +74,7 @@ func (t *Thread) AddFiles(node ipld.Node, caption string, keys Keys) (mh.Multiha
 		return nil, err
 	}
 
-	if err := t.indexBlock(res, repo.FilesBlock, msg.Target, msg.Body); err != nil {
+	if err := t.indexExpiringBlock(res, repo.FilesBlock, msg.Target, msg.Body, msg.Ttl); err != nil {
 		return nil, err
 	}
 
@@ -98,10 +112,8 @@ func (t *Thread) handleFilesBlock(hash mh.Multihash, block *pb.ThreadBlock) (*pb
 		return nil, ErrThreadSchemaRequired
 	}
 
-	var node ipld.Node
-
 	var ignore bool
-	ignored := t.datastore.Blocks().List("", -1, "target='ignore-"+hash.B58String()+"'")
+	ignored := t.activeIgnores(hash.B58String())
 	if len(ignored) > 0 {
 		date, err := ptypes.Timestamp(block.Header.Date)
 		if err != nil {
@@ -112,91 +124,114 @@ func (t *Thread) handleFilesBlock(hash mh.Multihash, block *pb.ThreadBlock) (*pb
 			ignore = true
 		}
 	}
+
+	var node ipld.Node
 	if !ignore {
-		target, err := cid.Parse(msg.Target)
+		var err error
+		node, err = t.loadFiles(msg)
 		if err != nil {
 			return nil, err
 		}
-		node, err = ipfs.NodeAtCid(t.node(), target)
+	}
+
+	if err := t.indexExpiringBlock(&commitResult{
+		hash:   hash,
+		header: block.Header,
+	}, repo.FilesBlock, msg.Target, msg.Body, msg.Ttl); err != nil {
+		return nil, err
+	}
+
+	if !ignore {
+		if err := t.indexFiles(node, msg.Target); err != nil {
+			return nil, err
+		}
+	}
+
+	return msg, nil
+}
+
+// loadFiles pins the target of a files block, validating it against the thread schema,
+// and adds its decrypted files to the index
+func (t *Thread) loadFiles(msg *pb.ThreadFiles) (ipld.Node, error) {
+	target, err := cid.Parse(msg.Target)
+	if err != nil {
+		return nil, err
+	}
+	node, err := ipfs.NodeAtCid(t.node(), target)
+	if err != nil {
+		return nil, err
+	}
+	if err := ipfs.PinNode(t.node(), node, false); err != nil {
+		return nil, err
+	}
+
+	// each link should point to a dag described by the thread schema
+	for i, link := range node.Links() {
+		nd, err := ipfs.NodeAtLink(t.node(), link)
 		if err != nil {
 			return nil, err
 		}
-		if err := ipfs.PinNode(t.node(), node, false); err != nil {
+		if err := t.processFileNode(t.Schema, nd, i, msg.Keys, true); err != nil {
 			return nil, err
 		}
+	}
 
-		// each link should point to a dag described by the thread schema
-		for i, link := range node.Links() {
-			nd, err := ipfs.NodeAtLink(t.node(), link)
+	if err := t.cafeOutbox.Add(msg.Target, repo.CafeStoreRequest); err != nil {
+		return nil, err
+	}
+
+	// use msg keys to decrypt each file
+	for pth, key := range msg.Keys {
+		fd, err := ipfs.DataAtPath(t.node(), msg.Target+pth+FileLinkName)
+		if err != nil {
+			return nil, err
+		}
+
+		var plaintext []byte
+		if key != "" {
+			keyb, err := base58.Decode(key)
 			if err != nil {
 				return nil, err
 			}
-			if err := t.processFileNode(t.Schema, nd, i, msg.Keys, true); err != nil {
+			plaintext, err = crypto.DecryptAES(fd, keyb)
+			if err != nil {
 				return nil, err
 			}
+		} else {
+			plaintext = fd
 		}
 
-		if err := t.cafeOutbox.Add(msg.Target, repo.CafeStoreRequest); err != nil {
+		var file repo.File
+		if err := json.Unmarshal(plaintext, &file); err != nil {
 			return nil, err
 		}
 
-		// use msg keys to decrypt each file
-		for pth, key := range msg.Keys {
-			fd, err := ipfs.DataAtPath(t.node(), msg.Target+pth+FileLinkName)
-			if err != nil {
-				return nil, err
-			}
-
-			var plaintext []byte
-			if key != "" {
-				keyb, err := base58.Decode(key)
-				if err != nil {
-					return nil, err
-				}
-				plaintext, err = crypto.DecryptAES(fd, keyb)
-				if err != nil {
-					return nil, err
-				}
-			} else {
-				plaintext = fd
-			}
+		log.Debugf("received file: %s", file.Hash)
 
-			var file repo.File
-			if err := json.Unmarshal(plaintext, &file); err != nil {
+		if err := t.datastore.Files().Add(&file); err != nil {
+			if !repo.ConflictError(err) {
 				return nil, err
 			}
-
-			log.Debugf("received file: %s", file.Hash)
-
-			if err := t.datastore.Files().Add(&file); err != nil {
-				if !repo.ConflictError(err) {
-					return nil, err
-				}
-				log.Debugf("file exists: %s", file.Hash)
-			}
+			log.Debugf("file exists: %s", file.Hash)
 		}
 	}
 
-	if err := t.indexBlock(&commitResult{
-		hash:   hash,
-		header: block.Header,
-	}, repo.FilesBlock, msg.Target, msg.Body); err != nil {
-		return nil, err
-	}
+	return node, nil
+}
 
-	if !ignore {
-		for _, link := range node.Links() {
-			nd, err := ipfs.NodeAtLink(t.node(), link)
-			if err != nil {
-				return nil, err
-			}
-			if err := t.indexFileNode(nd, msg.Target); err != nil {
-				return nil, err
-			}
+// indexFiles indexes the file links of a files block target.
+// The block must be indexed first, so that its files are searchable.
+func (t *Thread) indexFiles(node ipld.Node, target string) error {
+	for _, link := range node.Links() {
+		nd, err := ipfs.NodeAtLink(t.node(), link)
+		if err != nil {
+			return err
+		}
+		if err := t.indexFileNode(nd, target); err != nil {
+			return err
 		}
 	}
-
-	return msg, nil
+	return nil
 }
 
 // removeFiles unpins and removes target files unless they are used by another target,
@@ -209,7 +244,12 @@ func (t *Thread) removeFiles(node ipld.Node) error {
 
 	target := node.Cid().Hash().B58String()
 
-	blocks := t.datastore.Blocks().List("", -1, "target='"+target+"'")
+	blocks := t.datastore.Blocks().ListByQuery(&repo.BlockQuery{
+		Target:  target,
+		Ignored: true,
+		Expired: true,
+		Hidden:  true,
+	})
 	if len(blocks) == 1 {
 		// safe to unpin target node
 
@@ -398,7 +438,13 @@ func (t *Thread) indexFileLink(inode ipld.Node, target string) error {
 		return ErrMissingDataLink
 	}
 
-	return t.datastore.Files().AddTarget(dlink.Cid.Hash().B58String(), target)
+	hash := dlink.Cid.Hash().B58String()
+
+	if err := t.datastore.Files().AddTarget(hash, target); err != nil {
+		return err
+	}
+
+	return t.indexSearchFile(hash, target)
 }
 
 // deIndexFileNode walks a file node, de-indexing file links
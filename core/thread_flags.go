@@ -1,7 +1,9 @@
 package core
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	mh "gx/ipfs/QmPnFwZ2JXKnXgMw8CdBPxn7FWh6LLdjUjxV1fKHuJnkr8/go-multihash"
 
@@ -10,16 +12,58 @@ import (
 	"github.com/textileio/textile-go/repo"
 )
 
-// AddFlag adds an outgoing flag block targeted at another block to flag
-func (t *Thread) AddFlag(block string) (mh.Multihash, error) {
+// ErrFlagExists indicates a block was already flagged by the local peer
+var ErrFlagExists = errors.New("block already flagged")
+
+// ErrFlagNotFound indicates a flag removal targeted a block that was not flagged by the local peer
+var ErrFlagNotFound = errors.New("flag not found")
+
+// ErrInvalidFlagReason indicates an unknown flag reason code
+var ErrInvalidFlagReason = errors.New("invalid flag reason")
+
+// FlagReasonFromString parses a flag reason code, where empty is unspecified
+func FlagReasonFromString(desc string) (pb.ThreadFlag_Reason, error) {
+	desc = strings.ToUpper(strings.TrimSpace(desc))
+	if desc == "" {
+		return pb.ThreadFlag_UNSPECIFIED, nil
+	}
+	reason, ok := pb.ThreadFlag_Reason_value[desc]
+	if !ok {
+		return 0, ErrInvalidFlagReason
+	}
+	return pb.ThreadFlag_Reason(reason), nil
+}
+
+// AddFlag adds an outgoing flag block targeted at another block to flag,
+// with an optional reason code
+func (t *Thread) AddFlag(block string, reason pb.ThreadFlag_Reason) (mh.Multihash, error) {
 	t.mux.Lock()
 	defer t.mux.Unlock()
 
+	if !t.canWrite(pb.ThreadBlock_FLAG) {
+		return nil, ErrWriteNotAllowed
+	}
+
+	rblock := t.datastore.Blocks().Get(block)
+	if rblock == nil || rblock.ThreadId != t.Id {
+		return nil, ErrBlockNotFound
+	}
+
 	// adding a flag specific prefix here to ensure future flexibility
 	target := fmt.Sprintf("flag-%s", block)
 
+	query := t.indexQuery(repo.FlagBlock)
+	query.Target = target
+	query.AuthorIds = []string{t.node().Identity.Pretty()}
+	for _, flag := range t.datastore.Blocks().ListByQuery(query) {
+		if !t.ignored(flag.Id) {
+			return nil, ErrFlagExists
+		}
+	}
+
 	msg := &pb.ThreadFlag{
 		Target: target,
+		Reason: reason,
 	}
 
 	res, err := t.commitBlock(msg, pb.ThreadBlock_FLAG, nil)
@@ -27,7 +71,11 @@ func (t *Thread) AddFlag(block string) (mh.Multihash, error) {
 		return nil, err
 	}
 
-	if err := t.indexBlock(res, repo.FlagBlock, target, ""); err != nil {
+	if err := t.indexBlock(res, repo.FlagBlock, target, flagBody(reason)); err != nil {
+		return nil, err
+	}
+
+	if err := t.moderateFlagTarget(block); err != nil {
 		return nil, err
 	}
 
@@ -51,14 +99,94 @@ func (t *Thread) handleFlagBlock(hash mh.Multihash, block *pb.ThreadBlock) (*pb.
 		return nil, err
 	}
 
-	// TODO: how do we want to handle flags? making visible to UIs would be a good start
-
 	if err := t.indexBlock(&commitResult{
 		hash:   hash,
 		header: block.Header,
-	}, repo.FlagBlock, msg.Target, ""); err != nil {
+	}, repo.FlagBlock, msg.Target, flagBody(msg.Reason)); err != nil {
+		return nil, err
+	}
+
+	if err := t.moderateFlagTarget(strings.TrimPrefix(msg.Target, "flag-")); err != nil {
 		return nil, err
 	}
 
 	return msg, nil
 }
+
+// flagHidden returns whether or not a block should be hidden by its flags, which is the case
+// once a moderator has flagged it, or once it has been flagged by the thread threshold of distinct peers
+func (t *Thread) flagHidden(blockId string) bool {
+	query := t.indexQuery(repo.FlagBlock)
+	query.Target = "flag-" + blockId
+	flags := t.datastore.Blocks().ListByQuery(query)
+	if len(flags) == 0 {
+		return false
+	}
+
+	reporters := make(map[string]struct{})
+	for _, flag := range flags {
+		if !flag.Verified || t.ignored(flag.Id) {
+			continue
+		}
+		// the address is only indexed for signed blocks
+		if t.peerRole(flag.AuthorId, flag.Address) >= repo.ModeratorRole {
+			return true
+		}
+		reporters[flag.AuthorId] = struct{}{}
+	}
+
+	mod := t.datastore.Threads().Get(t.Id)
+	if mod == nil || mod.FlagThreshold <= 0 {
+		return false
+	}
+	return len(reporters) >= mod.FlagThreshold
+}
+
+// moderateFlagTarget hides or reveals a block based on its flags.
+// Hidden blocks are left out of listings and search.
+func (t *Thread) moderateFlagTarget(blockId string) error {
+	target := t.datastore.Blocks().Get(blockId)
+	if target == nil || target.ThreadId != t.Id {
+		return nil
+	}
+
+	hidden := t.flagHidden(blockId)
+	if hidden == target.Hidden {
+		return nil
+	}
+	if err := t.datastore.Blocks().UpdateHidden(blockId, hidden); err != nil {
+		return err
+	}
+	target.Hidden = hidden
+
+	if hidden {
+		log.Debugf("hid flagged block %s in %s", blockId, t.Id)
+		return t.datastore.Search().Delete(blockId)
+	}
+	return t.reindexSearch(target)
+}
+
+// moderateFlagTargets re-evaluates every flagged block in this thread,
+// e.g., after the flag threshold changes
+func (t *Thread) moderateFlagTargets() error {
+	seen := make(map[string]struct{})
+	for _, flag := range t.datastore.Blocks().ListByQuery(t.indexQuery(repo.FlagBlock)) {
+		if _, ok := seen[flag.Target]; ok {
+			continue
+		}
+		seen[flag.Target] = struct{}{}
+
+		if err := t.moderateFlagTarget(strings.TrimPrefix(flag.Target, "flag-")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flagBody returns the indexed body of a flag, which is its reason code, if any
+func flagBody(reason pb.ThreadFlag_Reason) string {
+	if reason == pb.ThreadFlag_UNSPECIFIED {
+		return ""
+	}
+	return reason.String()
+}
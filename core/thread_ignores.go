@@ -18,6 +18,15 @@ func (t *Thread) AddIgnore(block string) (mh.Multihash, error) {
 	t.mux.Lock()
 	defer t.mux.Unlock()
 
+	if !t.canWrite(pb.ThreadBlock_IGNORE) {
+		return nil, ErrWriteNotAllowed
+	}
+
+	rblock := t.datastore.Blocks().Get(block)
+	if !t.ignoreAllowed(t.node().Identity.Pretty(), t.account.Address(), rblock) {
+		return nil, ErrWriteNotAllowed
+	}
+
 	// adding an ignore specific prefix here to ensure future flexibility
 	target := fmt.Sprintf("ignore-%s", block)
 
@@ -34,7 +43,6 @@ func (t *Thread) AddIgnore(block string) (mh.Multihash, error) {
 		return nil, err
 	}
 
-	rblock := t.datastore.Blocks().Get(block)
 	if err := t.ignoreBlockTarget(rblock); err != nil {
 		return nil, err
 	}
@@ -51,6 +59,9 @@ func (t *Thread) AddIgnore(block string) (mh.Multihash, error) {
 	if err := t.datastore.Notifications().DeleteByBlock(block); err != nil {
 		return nil, err
 	}
+	if err := t.datastore.Search().Delete(block); err != nil {
+		return nil, err
+	}
 
 	log.Debugf("added IGNORE to %s: %s", t.Id, res.hash.B58String())
 
@@ -64,10 +75,11 @@ func (t *Thread) handleIgnoreBlock(hash mh.Multihash, block *pb.ThreadBlock) (*p
 		return nil, err
 	}
 
-	// cleanup
+	// hiding others' content requires moderation rights
 	blockId := strings.Replace(msg.Target, "ignore-", "", 1)
-	if err := t.datastore.Notifications().DeleteByBlock(blockId); err != nil {
-		return nil, err
+	rblock := t.datastore.Blocks().Get(blockId)
+	if !t.ignoreAllowed(block.Header.Author, block.Header.Address, rblock) {
+		return msg, t.handleDisallowedBlock(hash, block)
 	}
 
 	if err := t.indexBlock(&commitResult{
@@ -77,7 +89,19 @@ func (t *Thread) handleIgnoreBlock(hash mh.Multihash, block *pb.ThreadBlock) (*p
 		return nil, err
 	}
 
-	rblock := t.datastore.Blocks().Get(blockId)
+	// an undo may have arrived first during back prop
+	if t.undone(hash.B58String()) {
+		return msg, nil
+	}
+
+	// cleanup
+	if err := t.datastore.Notifications().DeleteByBlock(blockId); err != nil {
+		return nil, err
+	}
+	if err := t.datastore.Search().Delete(blockId); err != nil {
+		return nil, err
+	}
+
 	if err := t.ignoreBlockTarget(rblock); err != nil {
 		return nil, err
 	}
@@ -1,9 +1,16 @@
 package core
 
 import (
+	"errors"
+	"time"
+
 	mh "gx/ipfs/QmPnFwZ2JXKnXgMw8CdBPxn7FWh6LLdjUjxV1fKHuJnkr8/go-multihash"
 	"gx/ipfs/QmTRhk7cgjUf2gfQ3p2M9KPECNZEW9XUrmHcFCgog4cPgB/go-libp2p-peer"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/segmentio/ksuid"
 	"github.com/textileio/textile-go/crypto"
 	"github.com/textileio/textile-go/pb"
 	"github.com/textileio/textile-go/repo"
@@ -15,11 +22,15 @@ func (t *Thread) AddInvite(inviteeId peer.ID) (mh.Multihash, error) {
 	t.mux.Lock()
 	defer t.mux.Unlock()
 
-	if t.Type == repo.PrivateThread {
+	if t.Type == repo.PrivateThread || !t.canWrite(pb.ThreadBlock_INVITE) {
 		return nil, ErrInvitesNotAllowed
 	}
 
-	threadSk, err := t.privKey.Bytes()
+	threadSk, keys, err := t.inviteKeys()
+	if err != nil {
+		return nil, err
+	}
+	record, err := t.newInviteRecord(inviteeId.Pretty(), nil, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -28,6 +39,8 @@ func (t *Thread) AddInvite(inviteeId peer.ID) (mh.Multihash, error) {
 		Name:      t.Name,
 		Schema:    t.schemaId,
 		Initiator: t.initiator,
+		Keys:      keys,
+		Record:    record,
 	}
 
 	inviteePk, err := inviteeId.ExtractPublicKey()
@@ -54,17 +67,43 @@ func (t *Thread) AddInvite(inviteeId peer.ID) (mh.Multihash, error) {
 	return res.hash, nil
 }
 
+// ErrInviteExpired indicates an external invite is past its expiry date
+var ErrInviteExpired = errors.New("invite has expired")
+
+// ErrInviteRevoked indicates an external invite was revoked by its creator
+var ErrInviteRevoked = errors.New("invite has been revoked")
+
+// ErrInviteNotFound indicates an external invite is not known to this thread
+var ErrInviteNotFound = errors.New("invite not found")
+
+// ErrInvalidInviteRecord indicates an invite record is not signed by its inviter
+var ErrInvalidInviteRecord = errors.New("invalid invite record")
+
+// revokedInviteBody is the indexed body of an external invite block that revokes its invite.
+// The block that creates an invite is indexed with the invite record nonce.
+const revokedInviteBody = "revoked"
+
 // AddExternalInvite creates an external invite, which can be retrieved by any peer
 // and does not become part of the hash chain
 func (t *Thread) AddExternalInvite() (mh.Multihash, []byte, error) {
+	return t.AddLimitedExternalInvite(0, 0)
+}
+
+// AddLimitedExternalInvite creates an external invite that expires after ttl seconds
+// and may be used to join at most maxUses times. Zero means no limit.
+// The limits are announced to thread peers with an external invite block.
+func (t *Thread) AddLimitedExternalInvite(ttl int64, maxUses int) (mh.Multihash, []byte, error) {
 	t.mux.Lock()
 	defer t.mux.Unlock()
 
-	if t.Type == repo.PrivateThread {
+	if t.Type == repo.PrivateThread || !t.canWrite(pb.ThreadBlock_INVITE) {
 		return nil, nil, ErrInvitesNotAllowed
 	}
+	if ttl < 0 || maxUses < 0 {
+		return nil, nil, errors.New("invalid invite limits")
+	}
 
-	threadSk, err := t.privKey.Bytes()
+	threadSk, keys, err := t.inviteKeys()
 	if err != nil {
 		return nil, nil, err
 	}
@@ -73,6 +112,21 @@ func (t *Thread) AddExternalInvite() (mh.Multihash, []byte, error) {
 		Name:      t.Name,
 		Schema:    t.schemaId,
 		Initiator: t.initiator,
+		Keys:      keys,
+		MaxUses:   int32(maxUses),
+	}
+	var expires *time.Time
+	if ttl > 0 {
+		exp := time.Now().Add(time.Second * time.Duration(ttl))
+		expires = &exp
+		msg.Expires, err = ptypes.TimestampProto(exp)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	msg.Record, err = t.newInviteRecord("", msg.Expires, msg.MaxUses)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	key, err := crypto.GenerateAESKey()
@@ -87,6 +141,14 @@ func (t *Thread) AddExternalInvite() (mh.Multihash, []byte, error) {
 		return nil, nil, err
 	}
 
+	if _, err := t.addExternalInvitePolicy(&repo.ThreadExternalInvite{
+		Id:      res.hash.B58String(),
+		Expires: expires,
+		MaxUses: maxUses,
+	}, msg.Record); err != nil {
+		return nil, nil, err
+	}
+
 	go t.cafeOutbox.Flush()
 
 	log.Debugf("created external INVITE for %s", t.Id)
@@ -94,6 +156,342 @@ func (t *Thread) AddExternalInvite() (mh.Multihash, []byte, error) {
 	return res.hash, key, nil
 }
 
+// RevokeExternalInvite revokes an external invite created by the local peer.
+// Peers will reject joins with the invite from here on.
+func (t *Thread) RevokeExternalInvite(inviteId string) (mh.Multihash, error) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	invite := t.datastore.ThreadExternalInvites().Get(inviteId)
+	if invite == nil || invite.ThreadId != t.Id {
+		return nil, ErrInviteNotFound
+	}
+	if invite.InviterId != t.node().Identity.Pretty() {
+		return nil, ErrWriteNotAllowed
+	}
+	if invite.Revoked {
+		return nil, ErrInviteRevoked
+	}
+	invite.Revoked = true
+
+	hash, err := t.addExternalInvitePolicy(invite, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	go t.cafeOutbox.Flush()
+
+	return hash, nil
+}
+
+// addExternalInvitePolicy adds an outgoing external invite block, which tells peers
+// how long and how many times an external invite may be used.
+// New invites carry their record, later blocks may only revoke the invite.
+func (t *Thread) addExternalInvitePolicy(invite *repo.ThreadExternalInvite, record *pb.ThreadInviteRecord) (mh.Multihash, error) {
+	msg := &pb.ThreadExternalInvite{
+		Invite:  invite.Id,
+		MaxUses: int32(invite.MaxUses),
+		Revoked: invite.Revoked,
+		Record:  record,
+	}
+	if invite.Expires != nil {
+		var err error
+		msg.Expires, err = ptypes.TimestampProto(*invite.Expires)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := t.commitBlock(msg, pb.ThreadBlock_EXTERNAL_INVITE, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.indexBlock(res, repo.ExternalInviteBlock, invite.Id, externalInviteBody(msg)); err != nil {
+		return nil, err
+	}
+
+	date, err := ptypes.Timestamp(res.header.Date)
+	if err != nil {
+		return nil, err
+	}
+	invite.ThreadId = t.Id
+	invite.InviterId = t.node().Identity.Pretty()
+	invite.Date = date
+	if err := t.datastore.ThreadExternalInvites().AddOrUpdate(invite); err != nil {
+		return nil, err
+	}
+
+	if err := t.updateHead(res.hash); err != nil {
+		return nil, err
+	}
+
+	if err := t.post(res, t.Peers()); err != nil {
+		return nil, err
+	}
+
+	log.Debugf("added EXTERNAL_INVITE to %s: %s", t.Id, res.hash.B58String())
+
+	return res.hash, nil
+}
+
+// handleExternalInviteBlock handles an incoming external invite block.
+// An invite is created by a block carrying its record, signed by the block author,
+// who is then the only peer that may revoke it. A revocation is final.
+func (t *Thread) handleExternalInviteBlock(hash mh.Multihash, block *pb.ThreadBlock) (*pb.ThreadExternalInvite, error) {
+	msg := new(pb.ThreadExternalInvite)
+	if err := ptypes.UnmarshalAny(block.Payload, msg); err != nil {
+		return nil, err
+	}
+
+	existing := t.datastore.ThreadExternalInvites().Get(msg.Invite)
+	if existing == nil {
+		if msg.Record == nil || !t.inviteRecordValid(msg.Record) || msg.Record.Inviter != block.Header.Author {
+			return msg, t.handleDisallowedBlock(hash, block)
+		}
+	} else if existing.InviterId != block.Header.Author {
+		return msg, t.handleDisallowedBlock(hash, block)
+	}
+
+	if err := t.indexBlock(&commitResult{
+		hash:   hash,
+		header: block.Header,
+	}, repo.ExternalInviteBlock, msg.Invite, externalInviteBody(msg)); err != nil {
+		return nil, err
+	}
+
+	date, err := ptypes.Timestamp(block.Header.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	// later blocks can only revoke an invite
+	if existing != nil {
+		if existing.Revoked || !msg.Revoked {
+			return msg, nil
+		}
+		existing.Revoked = true
+		existing.Date = date
+		if err := t.datastore.ThreadExternalInvites().AddOrUpdate(existing); err != nil {
+			return nil, err
+		}
+		return msg, nil
+	}
+
+	invite := &repo.ThreadExternalInvite{
+		Id:        msg.Invite,
+		ThreadId:  t.Id,
+		InviterId: block.Header.Author,
+		MaxUses:   int(msg.Record.MaxUses),
+		Revoked:   msg.Revoked,
+		Date:      date,
+	}
+	if msg.Record.Expires != nil {
+		expires, err := ptypes.Timestamp(msg.Record.Expires)
+		if err != nil {
+			return nil, err
+		}
+		invite.Expires = &expires
+	}
+	if err := t.datastore.ThreadExternalInvites().AddOrUpdate(invite); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// externalInviteBody returns the indexed body of an external invite block
+func externalInviteBody(msg *pb.ThreadExternalInvite) string {
+	if msg.Revoked {
+		return revokedInviteBody
+	}
+	if msg.Record != nil {
+		return msg.Record.Nonce
+	}
+	return ""
+}
+
+// newInviteRecord returns an invite record signed with the local peer key
+func (t *Thread) newInviteRecord(invitee string, expires *timestamp.Timestamp, maxUses int32) (*pb.ThreadInviteRecord, error) {
+	record := &pb.ThreadInviteRecord{
+		Thread:  t.Id,
+		Inviter: t.node().Identity.Pretty(),
+		Invitee: invitee,
+		Nonce:   ksuid.New().String(),
+		Expires: expires,
+		MaxUses: maxUses,
+	}
+	input, err := inviteRecordSigningBytes(record)
+	if err != nil {
+		return nil, err
+	}
+	record.Sig, err = t.node().PrivateKey.Sign(input)
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// inviteRecordValid returns whether or not an invite record is for this thread
+// and signed by its inviter
+func (t *Thread) inviteRecordValid(record *pb.ThreadInviteRecord) bool {
+	return record.Thread == t.Id && verifyInviteRecord(record) == nil
+}
+
+// verifyInviteRecord checks the inviter's signature on an invite record,
+// which must carry a well-formed ksuid nonce
+func verifyInviteRecord(record *pb.ThreadInviteRecord) error {
+	if len(record.Sig) == 0 {
+		return ErrInvalidInviteRecord
+	}
+	if _, err := ksuid.Parse(record.Nonce); err != nil {
+		return ErrInvalidInviteRecord
+	}
+	pid, err := peer.IDB58Decode(record.Inviter)
+	if err != nil {
+		return err
+	}
+	pk, err := pid.ExtractPublicKey()
+	if err != nil {
+		return err
+	}
+	input, err := inviteRecordSigningBytes(record)
+	if err != nil {
+		return err
+	}
+	ok, err := pk.Verify(input, record.Sig)
+	if err != nil || !ok {
+		return ErrInvalidInviteRecord
+	}
+	return nil
+}
+
+// inviteRecordSigningBytes returns the bytes an invite record signature is made over
+func inviteRecordSigningBytes(record *pb.ThreadInviteRecord) ([]byte, error) {
+	unsigned := *record
+	unsigned.Sig = nil
+	return proto.Marshal(&unsigned)
+}
+
+// inviteRecordAllowed returns whether or not a join may use an invite record.
+// Limits are compared in causal order, since join dates are chosen by the joiner.
+func (t *Thread) inviteRecordAllowed(hash mh.Multihash, block *pb.ThreadBlock, record *pb.ThreadInviteRecord) bool {
+	if !t.inviteRecordValid(record) {
+		return false
+	}
+	if record.Invitee != "" && record.Invitee != block.Header.Author {
+		return false
+	}
+	if record.Expires != nil {
+		expires, err := ptypes.Timestamp(record.Expires)
+		if err != nil || t.joinedAfter(block, expires) {
+			return false
+		}
+	}
+
+	// a revoked invite may only have been used by joins that precede the revocation
+	if revoke := t.inviteRevocation(record.Nonce); revoke != nil {
+		if block.Header.Clock >= revoke.Clock || !t.precedes(hash.B58String(), block.Header.Clock, revoke.Id) {
+			return false
+		}
+	}
+
+	if record.MaxUses > 0 && t.inviteUsesBefore(hash, block, record.Nonce) >= int(record.MaxUses) {
+		return false
+	}
+	return true
+}
+
+// joinedAfter returns whether or not a join was written after a date.
+// Parents are dated by their own authors, so a joiner can only backdate a join
+// by building on history from before the date.
+func (t *Thread) joinedAfter(block *pb.ThreadBlock, date time.Time) bool {
+	latest, err := ptypes.Timestamp(block.Header.Date)
+	if err != nil {
+		return true
+	}
+	for _, p := range block.Header.Parents {
+		if parent := t.datastore.Blocks().Get(p); parent != nil && parent.Date.After(latest) {
+			latest = parent.Date
+		}
+	}
+	return latest.After(date)
+}
+
+// inviteRevocation returns the block that revoked the external invite with a record nonce, if any
+func (t *Thread) inviteRevocation(nonce string) *repo.Block {
+	query := t.indexQuery(repo.ExternalInviteBlock)
+	query.Body = nonce
+	query.Limit = 1
+	created := t.datastore.Blocks().ListByQuery(query)
+	if len(created) == 0 {
+		return nil
+	}
+	query = t.indexQuery(repo.ExternalInviteBlock)
+	query.Target = created[0].Target
+	query.Body = revokedInviteBody
+	query.Order = repo.CausalOrder
+	revokes := t.datastore.Blocks().ListByQuery(query)
+	if len(revokes) == 0 {
+		return nil
+	}
+	// the first revocation is final
+	return &revokes[len(revokes)-1]
+}
+
+// inviteUsesBefore returns the number of joins with an invite record that come before a join.
+// Blocks are handled in causal order, so indexed joins either precede the join or are
+// concurrent with it, in which case they are ordered by clock, then id.
+func (t *Thread) inviteUsesBefore(hash mh.Multihash, block *pb.ThreadBlock, nonce string) int {
+	id := hash.B58String()
+	query := t.indexQuery(repo.JoinBlock)
+	query.Body = nonce
+	var uses int
+	for _, join := range t.datastore.Blocks().ListByQuery(query) {
+		if join.Clock < block.Header.Clock || (join.Clock == block.Header.Clock && join.Id < id) {
+			uses++
+		}
+	}
+	return uses
+}
+
+// inviteUses returns the number of joins with an external invite
+func (t *Thread) inviteUses(inviteId string) int {
+	return t.datastore.Blocks().CountByQuery(&repo.BlockQuery{
+		ThreadIds: []string{t.Id},
+		Types:     []repo.BlockType{repo.JoinBlock},
+		Target:    inviteId,
+		Expired:   true,
+		Hidden:    true,
+	})
+}
+
+// externalKeyIssued returns whether or not the current thread key was handed out
+// with an external invite, i.e., an invite was created since the last key rotation
+func (t *Thread) externalKeyIssued() bool {
+	invites := t.datastore.Blocks().ListByQuery(t.indexQuery(repo.ExternalInviteBlock))
+	if len(invites) == 0 {
+		return false
+	}
+	rotations := t.datastore.Blocks().ListByQuery(t.indexQuery(repo.KeyBlock))
+	for _, invite := range invites {
+		if invite.Body == "" || invite.Body == revokedInviteBody {
+			continue
+		}
+		var rotated bool
+		for _, rotation := range rotations {
+			if t.isAncestor(invite.Id, rotation.Id) {
+				rotated = true
+				break
+			}
+		}
+		if !rotated {
+			return true
+		}
+	}
+	return false
+}
+
 // handleInviteMessage handles an incoming invite.
 // This happens right before a join. The invite is not kept on-chain,
 // so we only need to follow parents and update HEAD.
@@ -0,0 +1,228 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	mh "gx/ipfs/QmPnFwZ2JXKnXgMw8CdBPxn7FWh6LLdjUjxV1fKHuJnkr8/go-multihash"
+	"gx/ipfs/QmTRhk7cgjUf2gfQ3p2M9KPECNZEW9XUrmHcFCgog4cPgB/go-libp2p-peer"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/segmentio/ksuid"
+	"github.com/textileio/textile-go/pb"
+	"github.com/textileio/textile-go/repo"
+)
+
+// ErrJoinRequestNotFound indicates a join request is not known to this thread
+var ErrJoinRequestNotFound = errors.New("join request not found")
+
+// ErrJoinRequestDecided indicates a join request was already approved or denied
+var ErrJoinRequestDecided = errors.New("join request was already decided")
+
+// ErrJoinRequestNotAllowed indicates a join request decision was attempted without permission
+var ErrJoinRequestNotAllowed = errors.New("not allowed to decide join requests in this thread")
+
+// ApproveJoinRequest approves a pending join request by sending the requester a direct invite
+func (t *Thread) ApproveJoinRequest(id string) (*repo.ThreadJoinRequest, error) {
+	req, err := t.pendingJoinRequest(id)
+	if err != nil {
+		return nil, err
+	}
+	pid, err := peer.IDB58Decode(req.PeerId)
+	if err != nil {
+		return nil, err
+	}
+
+	// the invite is delivered to the requester's inboxes if they are offline
+	if t.datastore.Contacts().Get(req.PeerId) == nil {
+		if err := t.datastore.Contacts().Add(&repo.Contact{
+			Id:       req.PeerId,
+			Address:  req.Address,
+			Username: req.Username,
+			Inboxes:  req.Inboxes,
+			Added:    time.Now(),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := t.AddInvite(pid); err != nil {
+		return nil, err
+	}
+
+	return t.decideJoinRequest(req, repo.ApprovedJoinRequest)
+}
+
+// DenyJoinRequest denies a pending join request and lets the requester know
+func (t *Thread) DenyJoinRequest(id string, note string) (*repo.ThreadJoinRequest, error) {
+	req, err := t.pendingJoinRequest(id)
+	if err != nil {
+		return nil, err
+	}
+	pid, err := peer.IDB58Decode(req.PeerId)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := t.service().NewJoinDenialEnvelope(&pb.ThreadJoinDenial{
+		Thread: t.Id,
+		Note:   note,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := t.threadsOutbox.Add(pid, env); err != nil {
+		return nil, err
+	}
+	go t.threadsOutbox.Flush()
+
+	return t.decideJoinRequest(req, repo.DeniedJoinRequest)
+}
+
+// handleJoinRequest handles an incoming join request.
+// Only admins keep requests, and only one pending request per peer.
+func (t *Thread) handleJoinRequest(pid peer.ID, msg *pb.ThreadJoinRequest) (*repo.ThreadJoinRequest, error) {
+	if t.Type == repo.PrivateThread || !t.canDecideJoins() {
+		return nil, nil
+	}
+	if t.hasPeer(pid.Pretty()) || t.datastore.ThreadJoinRequests().GetPending(t.Id, pid.Pretty()) != nil {
+		return nil, nil
+	}
+
+	date, err := ptypes.Timestamp(msg.Date)
+	if err != nil {
+		return nil, err
+	}
+	req := &repo.ThreadJoinRequest{
+		Id:       ksuid.New().String(),
+		ThreadId: t.Id,
+		PeerId:   pid.Pretty(),
+		Address:  msg.Address,
+		Username: msg.Username,
+		Inboxes:  msg.Inboxes,
+		Note:     msg.Note,
+		Date:     date,
+		Status:   repo.PendingJoinRequest,
+	}
+	if err := t.datastore.ThreadJoinRequests().Add(req); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// pendingJoinRequest returns a join request the local peer may still decide
+func (t *Thread) pendingJoinRequest(id string) (*repo.ThreadJoinRequest, error) {
+	if !t.canDecideJoins() {
+		return nil, ErrJoinRequestNotAllowed
+	}
+	req := t.datastore.ThreadJoinRequests().Get(id)
+	if req == nil || req.ThreadId != t.Id {
+		return nil, ErrJoinRequestNotFound
+	}
+	if req.Status != repo.PendingJoinRequest {
+		return nil, ErrJoinRequestDecided
+	}
+	return req, nil
+}
+
+// decideJoinRequest records a decision on a join request for the audit trail,
+// then shares it with the other admins, who may hold a request from the same peer
+func (t *Thread) decideJoinRequest(req *repo.ThreadJoinRequest, status repo.JoinRequestStatus) (*repo.ThreadJoinRequest, error) {
+	date := time.Now()
+	decider := t.node().Identity.Pretty()
+	if err := t.datastore.ThreadJoinRequests().Decide(req.Id, status, decider, date); err != nil {
+		return nil, err
+	}
+	req.Status = status
+	req.DeciderId = decider
+	req.Decided = &date
+
+	log.Debugf("%s join request from %s to %s", status.Description(), req.PeerId, t.Id)
+
+	if _, err := t.shareJoinDecision(req.PeerId, status); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// shareJoinDecision adds an outgoing join decision block targeted at a requester
+func (t *Thread) shareJoinDecision(peerId string, status repo.JoinRequestStatus) (mh.Multihash, error) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	msg := &pb.ThreadJoinDecision{
+		Target:   peerId,
+		Approved: status == repo.ApprovedJoinRequest,
+	}
+
+	res, err := t.commitBlock(msg, pb.ThreadBlock_JOIN_DECISION, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.indexBlock(res, repo.JoinDecisionBlock, joinDecisionTarget(peerId), status.Description()); err != nil {
+		return nil, err
+	}
+
+	if err := t.updateHead(res.hash); err != nil {
+		return nil, err
+	}
+
+	if err := t.post(res, t.Peers()); err != nil {
+		return nil, err
+	}
+
+	log.Debugf("added JOIN_DECISION to %s: %s", t.Id, res.hash.B58String())
+
+	return res.hash, nil
+}
+
+// handleJoinDecisionBlock handles an incoming join decision block.
+// A pending request from the same peer is marked as decided by the block author.
+func (t *Thread) handleJoinDecisionBlock(hash mh.Multihash, block *pb.ThreadBlock) (*pb.ThreadJoinDecision, error) {
+	msg := new(pb.ThreadJoinDecision)
+	if err := ptypes.UnmarshalAny(block.Payload, msg); err != nil {
+		return nil, err
+	}
+
+	status := repo.DeniedJoinRequest
+	if msg.Approved {
+		status = repo.ApprovedJoinRequest
+	}
+
+	if err := t.indexBlock(&commitResult{
+		hash:   hash,
+		header: block.Header,
+	}, repo.JoinDecisionBlock, joinDecisionTarget(msg.Target), status.Description()); err != nil {
+		return nil, err
+	}
+
+	req := t.datastore.ThreadJoinRequests().GetPending(t.Id, msg.Target)
+	if req == nil {
+		return msg, nil
+	}
+	date, err := ptypes.Timestamp(block.Header.Date)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.datastore.ThreadJoinRequests().Decide(req.Id, status, block.Header.Author, date); err != nil {
+		return nil, err
+	}
+
+	log.Debugf("%s join request from %s to %s by %s", status.Description(), req.PeerId, t.Id, block.Header.Author)
+
+	return msg, nil
+}
+
+// joinDecisionTarget returns the indexed target of a join decision
+func joinDecisionTarget(peerId string) string {
+	return fmt.Sprintf("request-%s", peerId)
+}
+
+// canDecideJoins returns whether or not the local peer is an admin of this thread
+func (t *Thread) canDecideJoins() bool {
+	return t.peerRole(t.node().Identity.Pretty(), t.account.Address()) == repo.AdminRole
+}
@@ -14,7 +14,7 @@ func (t *Thread) joinInitial() (mh.Multihash, error) {
 	t.mux.Lock()
 	defer t.mux.Unlock()
 
-	msg, err := t.buildJoin(t.node().Identity.Pretty())
+	msg, err := t.buildJoin(t.node().Identity.Pretty(), "", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -37,12 +37,13 @@ func (t *Thread) joinInitial() (mh.Multihash, error) {
 	return res.hash, nil
 }
 
-// join creates an outgoing join block
-func (t *Thread) join(inviterId peer.ID) (mh.Multihash, error) {
+// join creates an outgoing join block, referencing the external invite used, if any,
+// along with the inviter's record of the invite
+func (t *Thread) join(inviterId peer.ID, inviteId string, record *pb.ThreadInviteRecord) (mh.Multihash, error) {
 	t.mux.Lock()
 	defer t.mux.Unlock()
 
-	msg, err := t.buildJoin(inviterId.Pretty())
+	msg, err := t.buildJoin(inviterId.Pretty(), inviteId, record)
 	if err != nil {
 		return nil, err
 	}
@@ -52,7 +53,7 @@ func (t *Thread) join(inviterId peer.ID) (mh.Multihash, error) {
 		return nil, err
 	}
 
-	if err := t.indexBlock(res, repo.JoinBlock, "", ""); err != nil {
+	if err := t.indexBlock(res, repo.JoinBlock, msg.Invite, joinBody(msg)); err != nil {
 		return nil, err
 	}
 
@@ -76,10 +77,11 @@ func (t *Thread) handleJoinBlock(hash mh.Multihash, block *pb.ThreadBlock) (*pb.
 		return nil, err
 	}
 
+	// joins are indexed by external invite and invite record to count their uses
 	if err := t.indexBlock(&commitResult{
 		hash:   hash,
 		header: block.Header,
-	}, repo.JoinBlock, "", ""); err != nil {
+	}, repo.JoinBlock, msg.Invite, joinBody(msg)); err != nil {
 		return nil, err
 	}
 
@@ -96,9 +98,11 @@ func (t *Thread) handleJoinBlock(hash mh.Multihash, block *pb.ThreadBlock) (*pb.
 }
 
 // buildJoin builds up a join block
-func (t *Thread) buildJoin(inviterId string) (*pb.ThreadJoin, error) {
+func (t *Thread) buildJoin(inviterId string, inviteId string, record *pb.ThreadInviteRecord) (*pb.ThreadJoin, error) {
 	msg := &pb.ThreadJoin{
 		Inviter: inviterId,
+		Invite:  inviteId,
+		Record:  record,
 	}
 	username, err := t.datastore.Profile().GetUsername()
 	if err != nil {
@@ -112,3 +116,29 @@ func (t *Thread) buildJoin(inviterId string) (*pb.ThreadJoin, error) {
 	}
 	return msg, nil
 }
+
+// joinAllowed returns whether or not an incoming join used a valid invite record.
+// Joins without one are from the initiator or peers invited before invite records,
+// which can't be told apart from an external invite that omits its record
+// once the current key was handed out with one.
+func (t *Thread) joinAllowed(hash mh.Multihash, block *pb.ThreadBlock) bool {
+	msg := new(pb.ThreadJoin)
+	if err := ptypes.UnmarshalAny(block.Payload, msg); err != nil {
+		return false
+	}
+	if msg.Record == nil {
+		if msg.Invite != "" {
+			return false
+		}
+		return block.Header.Address == t.initiator || !t.externalKeyIssued()
+	}
+	return t.inviteRecordAllowed(hash, block, msg.Record)
+}
+
+// joinBody returns the indexed body of a join, which is its invite record nonce, if any
+func joinBody(msg *pb.ThreadJoin) string {
+	if msg.Record == nil {
+		return ""
+	}
+	return msg.Record.Nonce
+}
@@ -0,0 +1,240 @@
+package core
+
+import (
+	"crypto/rand"
+	"sort"
+	"time"
+
+	mh "gx/ipfs/QmPnFwZ2JXKnXgMw8CdBPxn7FWh6LLdjUjxV1fKHuJnkr8/go-multihash"
+	libp2pc "gx/ipfs/QmPvyPwuCgJ7pDmrKDxRtsScJgBaM5h4EpRL2qQJsmXf4n/go-libp2p-crypto"
+	"gx/ipfs/QmTRhk7cgjUf2gfQ3p2M9KPECNZEW9XUrmHcFCgog4cPgB/go-libp2p-peer"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/textileio/textile-go/crypto"
+	"github.com/textileio/textile-go/pb"
+	"github.com/textileio/textile-go/repo"
+)
+
+// rotateKey creates an outgoing key block, which distributes a new thread key to
+// the remaining peers. The block itself is encrypted with the old key,
+// but each copy of the new key is only readable by its recipient.
+func (t *Thread) rotateKey() (mh.Multihash, error) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	sk, _, err := libp2pc.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	skb, err := sk.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &pb.ThreadKey{
+		Keys: make(map[string][]byte),
+	}
+	peers := t.Peers()
+	for _, tp := range peers {
+		pid, err := peer.IDB58Decode(tp.Id)
+		if err != nil {
+			return nil, err
+		}
+		pk, err := pid.ExtractPublicKey()
+		if err != nil {
+			return nil, err
+		}
+		ciphertext, err := crypto.Encrypt(pk, skb)
+		if err != nil {
+			return nil, err
+		}
+		msg.Keys[tp.Id] = ciphertext
+	}
+
+	res, err := t.commitBlock(msg, pb.ThreadBlock_KEY, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.indexBlock(res, repo.KeyBlock, "", ""); err != nil {
+		return nil, err
+	}
+
+	date, err := ptypes.Timestamp(res.header.Date)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.addKey(skb, date, res.hash.B58String()); err != nil {
+		return nil, err
+	}
+
+	if err := t.updateHead(res.hash); err != nil {
+		return nil, err
+	}
+
+	if err := t.post(res, peers); err != nil {
+		return nil, err
+	}
+
+	log.Debugf("added KEY to %s: %s", t.Id, res.hash.B58String())
+
+	return res.hash, nil
+}
+
+// handleKeyBlock handles an incoming key block
+func (t *Thread) handleKeyBlock(hash mh.Multihash, block *pb.ThreadBlock) (*pb.ThreadKey, error) {
+	msg := new(pb.ThreadKey)
+	if err := ptypes.UnmarshalAny(block.Payload, msg); err != nil {
+		return nil, err
+	}
+
+	// a missing entry means we were not a member when the key was rotated
+	ciphertext, ok := msg.Keys[t.node().Identity.Pretty()]
+	if ok {
+		skb, err := crypto.Decrypt(t.node().PrivateKey, ciphertext)
+		if err != nil {
+			return nil, err
+		}
+		date, err := ptypes.Timestamp(block.Header.Date)
+		if err != nil {
+			return nil, err
+		}
+		if err := t.addKey(skb, date, hash.B58String()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := t.indexBlock(&commitResult{
+		hash:   hash,
+		header: block.Header,
+	}, repo.KeyBlock, "", ""); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// shouldRotateKey returns whether or not this peer is responsible for rotating the key
+// after a membership change, which is the initiator if still a member, otherwise the
+// remaining member with the lowest peer id
+func (t *Thread) shouldRotateKey() bool {
+	peers := t.Peers()
+	if len(peers) == 0 {
+		return false
+	}
+	if t.initiator == t.account.Address() {
+		return true
+	}
+
+	self := t.node().Identity.Pretty()
+	ids := []string{self}
+	for _, tp := range peers {
+		contact := t.datastore.Contacts().Get(tp.Id)
+		if contact != nil && contact.Address == t.initiator {
+			return false
+		}
+		ids = append(ids, tp.Id)
+	}
+	sort.Strings(ids)
+	return ids[0] == self
+}
+
+// addKey stores a rotated thread key, along with the key block that introduced it,
+// and reloads the current key
+func (t *Thread) addKey(skb []byte, date time.Time, blockId string) error {
+	sk, err := libp2pc.UnmarshalPrivateKey(skb)
+	if err != nil {
+		return err
+	}
+	id, err := peer.IDFromPrivateKey(sk)
+	if err != nil {
+		return err
+	}
+
+	if err := t.datastore.ThreadKeys().Add(&repo.ThreadKey{
+		Id:       id.Pretty(),
+		ThreadId: t.Id,
+		PrivKey:  skb,
+		Date:     date,
+		BlockId:  blockId,
+	}); err != nil {
+		if !repo.ConflictError(err) {
+			return err
+		}
+		// exists, abort
+		return nil
+	}
+
+	return t.loadKeys(t.keys[len(t.keys)-1])
+}
+
+// addInviteKeys stores rotated thread keys received with an invite
+func (t *Thread) addInviteKeys(keys []*pb.ThreadInviteKey) error {
+	for _, key := range keys {
+		date, err := ptypes.Timestamp(key.Date)
+		if err != nil {
+			return err
+		}
+		if err := t.addKey(key.Sk, date, key.Block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inviteKeys returns the initial thread key and all rotated keys for an invite
+func (t *Thread) inviteKeys() ([]byte, []*pb.ThreadInviteKey, error) {
+	sk, err := t.keys[len(t.keys)-1].Bytes()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var keys []*pb.ThreadInviteKey
+	for _, key := range t.datastore.ThreadKeys().ListByThread(t.Id) {
+		date, err := ptypes.TimestampProto(key.Date)
+		if err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, &pb.ThreadInviteKey{
+			Sk:    key.PrivKey,
+			Date:  date,
+			Block: key.BlockId,
+		})
+	}
+	return sk, keys, nil
+}
+
+// loadKeys sets the newest rotated key as current, keeping the rest for decryption
+func (t *Thread) loadKeys(initial libp2pc.PrivKey) error {
+	var keys []libp2pc.PrivKey
+	var blocks []string
+	for _, key := range t.datastore.ThreadKeys().ListByThread(t.Id) {
+		sk, err := libp2pc.UnmarshalPrivateKey(key.PrivKey)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, sk)
+		blocks = append(blocks, key.BlockId)
+	}
+	keys = append(keys, initial)
+	blocks = append(blocks, "")
+
+	t.keys = keys
+	t.keyBlocks = blocks
+	t.privKey = keys[0]
+	return nil
+}
+
+// keyAllowed returns whether or not a block encrypted with a retired key was written
+// before the key was rotated, since removed peers may still hold the old key.
+// The key block itself is encrypted with the key it retires.
+func (t *Thread) keyAllowed(hash mh.Multihash, block *pb.ThreadBlock, retiredBy string) bool {
+	if retiredBy == "" || retiredBy == hash.B58String() {
+		return true
+	}
+	rotation := t.datastore.Blocks().Get(retiredBy)
+	if rotation == nil {
+		// blocks built on the key block are handled after it, so this block is concurrent at worst
+		return true
+	}
+	return block.Header.Clock < rotation.Clock && t.precedes(hash.B58String(), block.Header.Clock, retiredBy)
+}
@@ -0,0 +1,173 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	mh "gx/ipfs/QmPnFwZ2JXKnXgMw8CdBPxn7FWh6LLdjUjxV1fKHuJnkr8/go-multihash"
+	"gx/ipfs/QmTRhk7cgjUf2gfQ3p2M9KPECNZEW9XUrmHcFCgog4cPgB/go-libp2p-peer"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/textileio/textile-go/pb"
+	"github.com/textileio/textile-go/repo"
+)
+
+// ErrKickNotAllowed indicates a peer removal was attempted without permission
+var ErrKickNotAllowed = errors.New("not allowed to remove peers from this thread")
+
+// ErrPeerNotFound indicates a peer removal targeted a peer outside the thread
+var ErrPeerNotFound = errors.New("thread peer not found")
+
+// AddKick removes a peer from the thread, then rotates the thread key
+// so the removed peer can't read new blocks
+func (t *Thread) AddKick(peerId string) (mh.Multihash, error) {
+	hash, err := t.kick(peerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.shouldRotateKey() {
+		if _, err := t.rotateKey(); err != nil {
+			return nil, err
+		}
+	}
+	return hash, nil
+}
+
+// kick adds an outgoing kick block targeted at a thread peer
+func (t *Thread) kick(peerId string) (mh.Multihash, error) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	if !t.canWrite(pb.ThreadBlock_KICK) {
+		return nil, ErrKickNotAllowed
+	}
+	pid, err := peer.IDB58Decode(peerId)
+	if err != nil {
+		return nil, err
+	}
+	if !t.hasPeer(peerId) {
+		return nil, ErrPeerNotFound
+	}
+	if !t.outranks(t.node().Identity.Pretty(), t.account.Address(), peerId) {
+		return nil, ErrKickNotAllowed
+	}
+
+	// adding a kick specific prefix here to ensure future flexibility
+	target := fmt.Sprintf("kick-%s", peerId)
+
+	msg := &pb.ThreadKick{
+		Target: target,
+	}
+
+	res, err := t.commitBlock(msg, pb.ThreadBlock_KICK, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.indexBlock(res, repo.KickBlock, target, ""); err != nil {
+		return nil, err
+	}
+
+	if err := t.removePeer(pid); err != nil {
+		return nil, err
+	}
+
+	if err := t.updateHead(res.hash); err != nil {
+		return nil, err
+	}
+
+	if err := t.post(res, t.Peers()); err != nil {
+		return nil, err
+	}
+
+	log.Debugf("added KICK to %s: %s", t.Id, res.hash.B58String())
+
+	return res.hash, nil
+}
+
+// handleKickBlock handles an incoming kick block
+func (t *Thread) handleKickBlock(hash mh.Multihash, block *pb.ThreadBlock) (*pb.ThreadKick, error) {
+	msg := new(pb.ThreadKick)
+	if err := ptypes.UnmarshalAny(block.Payload, msg); err != nil {
+		return nil, err
+	}
+
+	peerId := strings.Replace(msg.Target, "kick-", "", 1)
+	if !t.outranks(block.Header.Author, block.Header.Address, peerId) {
+		return msg, t.handleDisallowedBlock(hash, block)
+	}
+
+	if err := t.indexBlock(&commitResult{
+		hash:   hash,
+		header: block.Header,
+	}, repo.KickBlock, msg.Target, ""); err != nil {
+		return nil, err
+	}
+
+	pid, err := peer.IDB58Decode(peerId)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.removePeer(pid); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// kicked returns the causally latest kick targeting a peer,
+// or nil if the peer was never kicked or has since joined again
+func (t *Thread) kicked(peerId string) *repo.Block {
+	query := t.indexQuery(repo.KickBlock)
+	query.Target = "kick-" + peerId
+	query.Order = repo.CausalOrder
+	query.Limit = 1
+	kicks := t.datastore.Blocks().ListByQuery(query)
+	if len(kicks) == 0 {
+		return nil
+	}
+	kick := &kicks[0]
+
+	query = t.indexQuery(repo.JoinBlock)
+	query.AuthorIds = []string{peerId}
+	for _, join := range t.datastore.Blocks().ListByQuery(query) {
+		if t.isAncestor(kick.Id, join.Id) {
+			return nil
+		}
+	}
+	return kick
+}
+
+// kickAllowed returns whether or not an incoming block may be written by its author
+// with respect to kicks. A kicked peer's blocks must causally precede the kick,
+// unless the block is a join built on the kick, i.e., the peer was invited back.
+func (t *Thread) kickAllowed(hash mh.Multihash, block *pb.ThreadBlock) bool {
+	kick := t.kicked(block.Header.Author)
+	if kick == nil {
+		return true
+	}
+	if block.Type == pb.ThreadBlock_JOIN && t.descends(block.Header.Parents, kick.Id) {
+		return true
+	}
+	return block.Header.Clock < kick.Clock && t.precedes(hash.B58String(), block.Header.Clock, kick.Id)
+}
+
+// hasPeer returns whether or not a peer is a member of this thread
+func (t *Thread) hasPeer(peerId string) bool {
+	for _, tp := range t.Peers() {
+		if tp.Id == peerId {
+			return true
+		}
+	}
+	return false
+}
+
+// removePeer drops a peer from the thread, along with any messages still queued for it
+func (t *Thread) removePeer(pid peer.ID) error {
+	if err := t.datastore.ThreadPeers().Delete(pid.Pretty(), t.Id); err != nil {
+		return err
+	}
+	return t.threadsOutbox.RemoveByThread(pid, t.Id)
+}
@@ -1,7 +1,6 @@
 package core
 
 import (
-	"fmt"
 	mh "gx/ipfs/QmPnFwZ2JXKnXgMw8CdBPxn7FWh6LLdjUjxV1fKHuJnkr8/go-multihash"
 
 	"github.com/textileio/textile-go/pb"
@@ -31,8 +30,7 @@ func (t *Thread) leave() (mh.Multihash, error) {
 	}
 
 	// cleanup
-	query := fmt.Sprintf("threadId='%s'", t.Id)
-	for _, block := range t.datastore.Blocks().List("", -1, query) {
+	for _, block := range t.datastore.Blocks().ListByQuery(t.indexQuery()) {
 		if err := t.ignoreBlockTarget(&block); err != nil {
 			return nil, err
 		}
@@ -43,6 +41,24 @@ func (t *Thread) leave() (mh.Multihash, error) {
 	if err := t.datastore.ThreadPeers().DeleteByThread(t.Id); err != nil {
 		return nil, err
 	}
+	if err := t.datastore.ThreadKeys().DeleteByThread(t.Id); err != nil {
+		return nil, err
+	}
+	if err := t.datastore.ThreadSyncs().DeleteByThread(t.Id); err != nil {
+		return nil, err
+	}
+	if err := t.datastore.ThreadReceipts().DeleteByThread(t.Id); err != nil {
+		return nil, err
+	}
+	if err := t.datastore.ThreadExternalInvites().DeleteByThread(t.Id); err != nil {
+		return nil, err
+	}
+	if err := t.datastore.ThreadJoinRequests().DeleteByThread(t.Id); err != nil {
+		return nil, err
+	}
+	if err := t.datastore.Search().DeleteByThread(t.Id); err != nil {
+		return nil, err
+	}
 	if err := t.datastore.Notifications().DeleteBySubject(t.Id); err != nil {
 		return nil, err
 	}
@@ -1,6 +1,11 @@
 package core
 
 import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
 	mh "gx/ipfs/QmPnFwZ2JXKnXgMw8CdBPxn7FWh6LLdjUjxV1fKHuJnkr8/go-multihash"
 
 	"github.com/golang/protobuf/ptypes"
@@ -8,21 +13,57 @@ import (
 	"github.com/textileio/textile-go/repo"
 )
 
+// DefaultReaction is the reaction of a plain like
+const DefaultReaction = "like"
+
+// maxReactionLength is the max byte length of a reaction
+const maxReactionLength = 32
+
+// ErrInvalidReaction indicates a reaction was empty, too long, or contained whitespace
+var ErrInvalidReaction = errors.New("reactions must be a single emoji or short code")
+
+// ErrReactionExists indicates the same reaction was already added to a block
+var ErrReactionExists = errors.New("reaction already added")
+
+// ErrReactionNotFound indicates a reaction removal targeted a reaction that was not added locally
+var ErrReactionNotFound = errors.New("reaction not found")
+
 // AddLike adds an outgoing like block
 func (t *Thread) AddLike(target string) (mh.Multihash, error) {
+	return t.AddReaction(target, DefaultReaction)
+}
+
+// AddReaction adds an outgoing like block with an emoji or short code reaction
+func (t *Thread) AddReaction(target string, reaction string) (mh.Multihash, error) {
 	t.mux.Lock()
 	defer t.mux.Unlock()
 
+	if !t.canWrite(pb.ThreadBlock_LIKE) {
+		return nil, ErrWriteNotAllowed
+	}
+
+	reaction = strings.TrimSpace(reaction)
+	if !validReaction(reaction) {
+		return nil, ErrInvalidReaction
+	}
+	if t.reactionBlock(target, reaction) != nil {
+		return nil, ErrReactionExists
+	}
+
 	msg := &pb.ThreadLike{
 		Target: target,
 	}
+	// plain likes are sent without a reaction so older peers read them the same way
+	if reaction != DefaultReaction {
+		msg.Reaction = reaction
+	}
 
 	res, err := t.commitBlock(msg, pb.ThreadBlock_LIKE, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := t.indexBlock(res, repo.LikeBlock, target, ""); err != nil {
+	if err := t.indexBlock(res, repo.LikeBlock, target, reaction); err != nil {
 		return nil, err
 	}
 
@@ -39,6 +80,23 @@ func (t *Thread) AddLike(target string) (mh.Multihash, error) {
 	return res.hash, nil
 }
 
+// RemoveLike undoes a like previously added to a block by the local peer
+func (t *Thread) RemoveLike(target string) (mh.Multihash, error) {
+	return t.RemoveReaction(target, DefaultReaction)
+}
+
+// RemoveReaction undoes a reaction previously added to a block by the local peer
+func (t *Thread) RemoveReaction(target string, reaction string) (mh.Multihash, error) {
+	t.mux.Lock()
+	like := t.reactionBlock(target, strings.TrimSpace(reaction))
+	t.mux.Unlock()
+
+	if like == nil {
+		return nil, ErrReactionNotFound
+	}
+	return t.AddUndo(like.Id)
+}
+
 // handleLikeBlock handles an incoming like block
 func (t *Thread) handleLikeBlock(hash mh.Multihash, block *pb.ThreadBlock) (*pb.ThreadLike, error) {
 	msg := new(pb.ThreadLike)
@@ -46,11 +104,49 @@ func (t *Thread) handleLikeBlock(hash mh.Multihash, block *pb.ThreadBlock) (*pb.
 		return nil, err
 	}
 
+	// plain likes have no reaction, others are held to the same limits as local ones
+	if msg.Reaction != "" && !validReaction(msg.Reaction) {
+		return msg, t.handleDisallowedBlock(hash, block)
+	}
+
 	if err := t.indexBlock(&commitResult{
 		hash:   hash,
 		header: block.Header,
-	}, repo.LikeBlock, msg.Target, ""); err != nil {
+	}, repo.LikeBlock, msg.Target, reactionName(msg.Reaction)); err != nil {
 		return nil, err
 	}
 	return msg, nil
 }
+
+// reactionBlock returns the local peer's active like block with the given reaction, if any
+func (t *Thread) reactionBlock(target string, reaction string) *repo.Block {
+	self := t.node().Identity.Pretty()
+	query := t.indexQuery(repo.LikeBlock)
+	query.Target = target
+	query.AuthorIds = []string{self}
+	for _, like := range t.datastore.Blocks().ListByQuery(query) {
+		if reactionName(like.Body) != reaction {
+			continue
+		}
+		if !t.ignored(like.Id) {
+			return &like
+		}
+	}
+	return nil
+}
+
+// reactionName returns the reaction of a like, where likes without one are the default reaction
+func reactionName(reaction string) string {
+	if reaction == "" {
+		return DefaultReaction
+	}
+	return reaction
+}
+
+// validReaction returns whether or not a reaction is a short, single token
+func validReaction(reaction string) bool {
+	if reaction == "" || len(reaction) > maxReactionLength || !utf8.ValidString(reaction) {
+		return false
+	}
+	return strings.IndexFunc(reaction, unicode.IsSpace) == -1
+}
@@ -13,44 +13,41 @@ import (
 	"github.com/textileio/textile-go/repo"
 )
 
-// merge adds a merge block, which are kept local until subsequent updates, avoiding possibly endless echoes
-func (t *Thread) merge(head mh.Multihash) (mh.Multihash, error) {
+// merge adds a merge block, which are kept local until subsequent updates, avoiding possibly endless echoes.
+// Any number of concurrent heads are folded into a single block, which is identical across peers.
+func (t *Thread) merge(heads []string) (mh.Multihash, error) {
 	t.mux.Lock()
 	defer t.mux.Unlock()
 
-	// build custom merge header
-	header, err := t.newBlockHeader()
-	if err != nil {
-		return nil, err
-	}
-	// delete author since we want these identical across peers
-	header.Author = ""
-	// add a second parent
-	header.Parents = append(header.Parents, head.B58String())
 	// sort to ensure a deterministic (the order may be reversed on other peers)
-	sort.Strings(header.Parents)
+	parents := make([]string, len(heads))
+	copy(parents, heads)
+	sort.Strings(parents)
+
 	// choose newest to use for date
-	p1b := t.datastore.Blocks().Get(header.Parents[0])
-	if p1b == nil {
-		return nil, errors.New("first merge parent not found")
-	}
-	p2b := t.datastore.Blocks().Get(header.Parents[1])
-	if p2b == nil {
-		return nil, errors.New("second merge parent not found")
-	}
 	var date time.Time
-	if p1b.Date.Before(p2b.Date) {
-		date = p2b.Date
-	} else {
-		date = p1b.Date
+	for _, p := range parents {
+		parent := t.datastore.Blocks().Get(p)
+		if parent == nil {
+			return nil, errors.New("merge parent not found: " + p)
+		}
+		if parent.Date.After(date) {
+			date = parent.Date
+		}
 	}
-	// add a small amount to date to keep it ahead of both parents
+	// add a small amount to date to keep it ahead of all parents
 	date = date.Add(time.Millisecond)
 	pdate, err := ptypes.TimestampProto(date)
 	if err != nil {
 		return nil, err
 	}
-	header.Date = pdate
+
+	// no author, address, or sig since we want these identical across peers
+	header := &pb.ThreadBlockHeader{
+		Date:    pdate,
+		Parents: parents,
+		Clock:   t.nextClock(parents),
+	}
 
 	block := &pb.ThreadBlock{
 		Header: header,
@@ -71,7 +68,10 @@ func (t *Thread) merge(head mh.Multihash) (mh.Multihash, error) {
 		hash:   hash,
 		header: header,
 	}, repo.MergeBlock, "", ""); err != nil {
-		return nil, err
+		if !repo.ConflictError(err) {
+			return nil, err
+		}
+		// another peer's identical merge is already known
 	}
 
 	if err := t.updateHead(hash); err != nil {
@@ -85,8 +85,157 @@ func (t *Thread) merge(head mh.Multihash) (mh.Multihash, error) {
 
 // handleMergeBlock handles an incoming merge block
 func (t *Thread) handleMergeBlock(hash mh.Multihash, block *pb.ThreadBlock) error {
+	// merge blocks are unsigned, so they're only accepted on top of known parents
+	if err := t.followParents(block.Header.Parents); err != nil {
+		return err
+	}
+	return t.indexMergeBlock(hash, block)
+}
+
+// indexMergeBlock indexes a merge block whose parents are known
+func (t *Thread) indexMergeBlock(hash mh.Multihash, block *pb.ThreadBlock) error {
+	if !t.parentsIndexed(block.Header.Parents) {
+		return ErrInvalidMergeBlock
+	}
+
 	return t.indexBlock(&commitResult{
 		hash:   hash,
 		header: block.Header,
 	}, repo.MergeBlock, "", "")
 }
+
+// foldHeads returns the sorted heads of a thread after an inbound block arrives,
+// dropping any head that is an ancestor of another.
+// current holds the existing head, or the parents of the existing head if it is a merge block.
+func foldHeads(current []string, inbound string, isAncestor func(a string, b string) bool) []string {
+	var candidates []string
+	seen := make(map[string]bool)
+	for _, h := range append(append([]string{}, current...), inbound) {
+		if h == "" || seen[h] {
+			continue
+		}
+		seen[h] = true
+		candidates = append(candidates, h)
+	}
+
+	heads := make([]string, 0)
+	for _, h := range candidates {
+		var descended bool
+		for _, o := range candidates {
+			if o != h && isAncestor(h, o) {
+				descended = true
+				break
+			}
+		}
+		if !descended {
+			heads = append(heads, h)
+		}
+	}
+	sort.Strings(heads)
+	return heads
+}
+
+// nextHeads decides how an inbound block with the given parents moves a thread head.
+// merged holds the parents of the head if it is a merge block, otherwise nil.
+// A single returned head is a fast-forward, several need a merge, and nil means the
+// inbound block is already part of the history. replaceMerge is true when the
+// merge block at head is folded into the returned heads and should be dropped.
+func nextHeads(head string, merged []string, inbound string, parents []string, isAncestor func(a string, b string) bool) (heads []string, replaceMerge bool) {
+	// fast-forward is possible if the head is empty or one of the inbound parents
+	if head == "" {
+		return []string{inbound}, false
+	}
+	for _, parent := range parents {
+		if parent == head {
+			return []string{inbound}, false
+		}
+	}
+
+	// fold the inbound block into the current heads, replacing the local merge block, if any
+	current := []string{head}
+	if len(merged) > 0 {
+		current = merged
+	}
+	heads = foldHeads(current, inbound, isAncestor)
+	if sameHeads(heads, current) {
+		return nil, false
+	}
+	return heads, len(merged) > 0
+}
+
+// sameHeads returns whether or not two lists hold the same heads
+func sameHeads(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sa := append([]string{}, a...)
+	sb := append([]string{}, b...)
+	sort.Strings(sa)
+	sort.Strings(sb)
+	for i := range sa {
+		if sa[i] != sb[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// isAncestor returns whether or not block a is reachable from the parents of block b
+func (t *Thread) isAncestor(a string, b string) bool {
+	target := t.datastore.Blocks().Get(a)
+	if target == nil {
+		return false
+	}
+	return t.precedes(a, target.Clock, b)
+}
+
+// descends returns whether or not a block with the given parents descends from block a
+func (t *Thread) descends(parents []string, a string) bool {
+	for _, p := range parents {
+		if p == a || t.isAncestor(a, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// precedes returns whether or not block a, which need not be indexed, is reachable from the
+// parents of block b. Blocks with a clock no higher than a's can't descend from it,
+// so their history is skipped.
+func (t *Thread) precedes(a string, clock int64, b string) bool {
+	seen := make(map[string]bool)
+	queue := []string{b}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		index := t.datastore.Blocks().Get(id)
+		if index == nil {
+			continue
+		}
+		for _, p := range index.Parents {
+			if p == a {
+				return true
+			}
+		}
+		if clock > 0 && index.Clock > 0 && index.Clock <= clock {
+			continue
+		}
+		queue = append(queue, index.Parents...)
+	}
+	return false
+}
+
+// dropMerge removes the index of a merge block that was replaced before anything was built on it
+func (t *Thread) dropMerge(id string) error {
+	query := t.indexQuery()
+	query.Parent = id
+	if t.datastore.Blocks().CountByQuery(query) > 0 {
+		return nil
+	}
+	return t.datastore.Blocks().Delete(id)
+}
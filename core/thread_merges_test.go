@@ -0,0 +1,242 @@
+package core
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// mergeSim models thread heads across peers without a network or datastore
+type mergeSim struct {
+	parents map[string][]string
+	merges  map[string]bool
+	posted  []string
+}
+
+// mergeSimPeer is a peer's view of a thread head
+type mergeSimPeer struct {
+	head string
+}
+
+func newMergeSim() *mergeSim {
+	return &mergeSim{
+		parents: map[string][]string{"genesis": nil},
+		merges:  make(map[string]bool),
+		posted:  []string{"genesis"},
+	}
+}
+
+// isAncestor returns whether or not a is reachable from the parents of b
+func (s *mergeSim) isAncestor(a string, b string) bool {
+	seen := make(map[string]bool)
+	queue := append([]string{}, s.parents[b]...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if id == a {
+			return true
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		queue = append(queue, s.parents[id]...)
+	}
+	return false
+}
+
+// merge returns the id of the merge block for a set of heads, which is the same for every peer
+func (s *mergeSim) merge(heads []string) string {
+	id := "merge(" + strings.Join(heads, ",") + ")"
+	s.parents[id] = heads
+	s.merges[id] = true
+	return id
+}
+
+// post adds a block authored by a peer on top of its head
+func (s *mergeSim) post(p *mergeSimPeer, id string) string {
+	s.parents[id] = []string{p.head}
+	s.posted = append(s.posted, id)
+	p.head = id
+	return id
+}
+
+// receive applies nextHeads the same way Thread.handleHead does
+func (s *mergeSim) receive(p *mergeSimPeer, inbound string) {
+	var merged []string
+	if s.merges[p.head] {
+		merged = s.parents[p.head]
+	}
+	heads, _ := nextHeads(p.head, merged, inbound, s.parents[inbound], s.isAncestor)
+	if heads == nil {
+		return
+	}
+	if len(heads) == 1 {
+		p.head = heads[0]
+		return
+	}
+	p.head = s.merge(heads)
+}
+
+// expectedHead returns the head every peer should converge on after receiving all posted blocks
+func (s *mergeSim) expectedHead() string {
+	var tips []string
+	for _, id := range s.posted {
+		var descended bool
+		for _, other := range s.posted {
+			if other != id && s.isAncestor(id, other) {
+				descended = true
+				break
+			}
+		}
+		if !descended {
+			tips = append(tips, id)
+		}
+	}
+	sort.Strings(tips)
+	if len(tips) == 1 {
+		return tips[0]
+	}
+	return s.merge(tips)
+}
+
+func TestFoldHeads(t *testing.T) {
+	sim := newMergeSim()
+	a := sim.post(&mergeSimPeer{head: "genesis"}, "a")
+	b := sim.post(&mergeSimPeer{head: "genesis"}, "b")
+	c := sim.post(&mergeSimPeer{head: a}, "c")
+
+	heads := foldHeads([]string{b}, a, sim.isAncestor)
+	if !sameHeads(heads, []string{a, b}) {
+		t.Errorf("expected concurrent heads to be kept, got %v", heads)
+	}
+	if heads[0] != a {
+		t.Error("heads should be sorted")
+	}
+
+	heads = foldHeads([]string{a, b}, c, sim.isAncestor)
+	if !sameHeads(heads, []string{b, c}) {
+		t.Errorf("expected ancestor to be dropped, got %v", heads)
+	}
+
+	heads = foldHeads([]string{b, c}, a, sim.isAncestor)
+	if !sameHeads(heads, []string{b, c}) {
+		t.Errorf("expected known history to be ignored, got %v", heads)
+	}
+
+	heads = foldHeads([]string{a}, a, sim.isAncestor)
+	if len(heads) != 1 {
+		t.Errorf("expected duplicate heads to be dropped, got %v", heads)
+	}
+}
+
+func TestNextHeads(t *testing.T) {
+	sim := newMergeSim()
+	a := sim.post(&mergeSimPeer{head: "genesis"}, "a")
+	b := sim.post(&mergeSimPeer{head: "genesis"}, "b")
+	c := sim.post(&mergeSimPeer{head: a}, "c")
+
+	heads, replace := nextHeads(a, nil, c, sim.parents[c], sim.isAncestor)
+	if !sameHeads(heads, []string{c}) || replace {
+		t.Errorf("expected a fast-forward, got %v", heads)
+	}
+
+	heads, replace = nextHeads(a, nil, b, sim.parents[b], sim.isAncestor)
+	if !sameHeads(heads, []string{a, b}) || replace {
+		t.Errorf("expected a merge of concurrent heads, got %v", heads)
+	}
+
+	merge := sim.merge([]string{a, b})
+	heads, replace = nextHeads(merge, sim.parents[merge], c, sim.parents[c], sim.isAncestor)
+	if !sameHeads(heads, []string{b, c}) || !replace {
+		t.Errorf("expected the merge to be replaced, got %v", heads)
+	}
+
+	heads, _ = nextHeads(merge, sim.parents[merge], a, sim.parents[a], sim.isAncestor)
+	if heads != nil {
+		t.Errorf("expected known history to leave the head alone, got %v", heads)
+	}
+}
+
+func TestMerge_ConcurrentWriters(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		rnd := rand.New(rand.NewSource(seed))
+		sim := newMergeSim()
+
+		// every peer posts while offline
+		peers := make([]*mergeSimPeer, 8)
+		var blocks []string
+		for i := range peers {
+			peers[i] = &mergeSimPeer{head: "genesis"}
+			blocks = append(blocks, sim.post(peers[i], fmt.Sprintf("w%d", i)))
+		}
+
+		// then they reconnect, receiving each other's blocks in any order
+		for i, p := range peers {
+			for _, j := range rnd.Perm(len(blocks)) {
+				if j != i {
+					sim.receive(p, blocks[j])
+				}
+			}
+		}
+
+		expected := sim.expectedHead()
+		for i, p := range peers {
+			if p.head != expected {
+				t.Errorf("seed %d: peer %d has head %s, expected %s", seed, i, p.head, expected)
+			}
+		}
+		if len(sim.parents[expected]) != len(peers) {
+			t.Errorf("seed %d: expected a single merge of %d heads, got %d parents",
+				seed, len(peers), len(sim.parents[expected]))
+		}
+	}
+}
+
+func TestMerge_InterleavedWriters(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		rnd := rand.New(rand.NewSource(seed))
+		sim := newMergeSim()
+
+		peers := make([]*mergeSimPeer, 6)
+		inboxes := make([][]string, len(peers))
+		for i := range peers {
+			peers[i] = &mergeSimPeer{head: "genesis"}
+		}
+
+		// peers post on top of whatever they have seen so far, while deliveries lag behind
+		for n := 0; n < 60; n++ {
+			i := rnd.Intn(len(peers))
+			if rnd.Intn(3) == 0 {
+				id := sim.post(peers[i], fmt.Sprintf("b%d", n))
+				for j := range peers {
+					if j != i {
+						inboxes[j] = append(inboxes[j], id)
+					}
+				}
+				continue
+			}
+			if len(inboxes[i]) > 0 {
+				k := rnd.Intn(len(inboxes[i]))
+				sim.receive(peers[i], inboxes[i][k])
+				inboxes[i] = append(inboxes[i][:k], inboxes[i][k+1:]...)
+			}
+		}
+
+		// drain
+		for i, p := range peers {
+			for _, k := range rnd.Perm(len(inboxes[i])) {
+				sim.receive(p, inboxes[i][k])
+			}
+		}
+
+		expected := sim.expectedHead()
+		for i, p := range peers {
+			if p.head != expected {
+				t.Errorf("seed %d: peer %d has head %s, expected %s", seed, i, p.head, expected)
+			}
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"errors"
+
 	mh "gx/ipfs/QmPnFwZ2JXKnXgMw8CdBPxn7FWh6LLdjUjxV1fKHuJnkr8/go-multihash"
 
 	"github.com/golang/protobuf/ptypes"
@@ -8,13 +10,48 @@ import (
 	"github.com/textileio/textile-go/repo"
 )
 
+// ErrInvalidReplyTarget indicates a reply targeted a block that is not a message or comment in the thread
+var ErrInvalidReplyTarget = errors.New("replies must target a message or comment in the same thread")
+
 // AddMessage adds an outgoing message block
 func (t *Thread) AddMessage(body string) (mh.Multihash, error) {
+	return t.addMessage(body, "", 0)
+}
+
+// AddReply adds an outgoing message block in reply to a message or comment
+func (t *Thread) AddReply(replyTo string, body string) (mh.Multihash, error) {
+	return t.addMessage(body, replyTo, 0)
+}
+
+// AddExpiringReply adds an outgoing message block with an optional parent, which expires
+// after ttl seconds. A zero ttl falls back to the thread ttl.
+func (t *Thread) AddExpiringReply(replyTo string, body string, ttl int64) (mh.Multihash, error) {
+	return t.addMessage(body, replyTo, ttl)
+}
+
+// addMessage adds an outgoing message block with an optional parent and ttl
+func (t *Thread) addMessage(body string, replyTo string, ttl int64) (mh.Multihash, error) {
 	t.mux.Lock()
 	defer t.mux.Unlock()
 
+	if !t.canWrite(pb.ThreadBlock_MESSAGE) {
+		return nil, ErrWriteNotAllowed
+	}
+	if ttl < 0 {
+		return nil, ErrInvalidTtl
+	}
+
+	if replyTo != "" {
+		parent := t.datastore.Blocks().Get(replyTo)
+		if parent == nil || parent.ThreadId != t.Id || !repliable(parent.Type) {
+			return nil, ErrInvalidReplyTarget
+		}
+	}
+
 	msg := &pb.ThreadMessage{
-		Body: body,
+		Body:    body,
+		ReplyTo: replyTo,
+		Ttl:     t.blockTtl(ttl),
 	}
 
 	res, err := t.commitBlock(msg, pb.ThreadBlock_MESSAGE, nil)
@@ -22,7 +59,7 @@ func (t *Thread) AddMessage(body string) (mh.Multihash, error) {
 		return nil, err
 	}
 
-	if err := t.indexBlock(res, repo.MessageBlock, "", body); err != nil {
+	if err := t.indexExpiringBlock(res, repo.MessageBlock, replyTo, body, msg.Ttl); err != nil {
 		return nil, err
 	}
 
@@ -46,11 +83,21 @@ func (t *Thread) handleMessageBlock(hash mh.Multihash, block *pb.ThreadBlock) (*
 		return nil, err
 	}
 
-	if err := t.indexBlock(&commitResult{
+	if err := t.indexExpiringBlock(&commitResult{
 		hash:   hash,
 		header: block.Header,
-	}, repo.MessageBlock, "", msg.Body); err != nil {
+	}, repo.MessageBlock, msg.ReplyTo, msg.Body, msg.Ttl); err != nil {
 		return nil, err
 	}
 	return msg, nil
 }
+
+// repliable returns whether or not a block type can be the parent of a reply
+func repliable(btype repo.BlockType) bool {
+	switch btype {
+	case repo.MessageBlock, repo.CommentBlock:
+		return true
+	default:
+		return false
+	}
+}
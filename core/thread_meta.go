@@ -0,0 +1,182 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	mh "gx/ipfs/QmPnFwZ2JXKnXgMw8CdBPxn7FWh6LLdjUjxV1fKHuJnkr8/go-multihash"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/textileio/textile-go/pb"
+	"github.com/textileio/textile-go/repo"
+)
+
+// ErrMetaNotAllowed indicates a thread update was attempted without permission
+var ErrMetaNotAllowed = errors.New("not allowed to update this thread")
+
+// ErrMissingThreadName indicates a thread update with an empty name
+var ErrMissingThreadName = errors.New("thread name is required")
+
+// ErrCoverNotFound indicates a thread cover that is not a files target in the thread
+var ErrCoverNotFound = errors.New("cover must be a file target in this thread")
+
+// ErrInvalidFlagThreshold indicates a negative flag threshold
+var ErrInvalidFlagThreshold = errors.New("flag threshold must not be negative")
+
+// UpdateMeta adds an outgoing meta block, which sets the thread name, description, cover,
+// and the default ttl in seconds of new messages and files.
+// Each meta block carries the full thread metadata, so the latest one wins.
+func (t *Thread) UpdateMeta(name string, description string, cover string, ttl int64) (mh.Multihash, error) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	if !t.canWrite(pb.ThreadBlock_META) {
+		return nil, ErrMetaNotAllowed
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrMissingThreadName
+	}
+	if ttl < 0 {
+		return nil, ErrInvalidTtl
+	}
+	if cover != "" {
+		query := t.indexQuery(repo.FilesBlock)
+		query.Target = cover
+		if t.datastore.Blocks().CountByQuery(query) == 0 {
+			return nil, ErrCoverNotFound
+		}
+	}
+
+	mod := t.datastore.Threads().Get(t.Id)
+	if mod == nil {
+		return nil, errThreadReload
+	}
+
+	return t.addMeta(&pb.ThreadMeta{
+		Name:          name,
+		Description:   description,
+		Cover:         cover,
+		Ttl:           ttl,
+		FlagThreshold: int32(mod.FlagThreshold),
+	})
+}
+
+// SetFlagThreshold adds an outgoing meta block, which sets the number of flags
+// from distinct peers that hide a block. Flags from moderators always hide a block.
+// Use 0 to only honor moderator flags.
+func (t *Thread) SetFlagThreshold(threshold int) (mh.Multihash, error) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	if !t.canWrite(pb.ThreadBlock_META) {
+		return nil, ErrMetaNotAllowed
+	}
+	if threshold < 0 {
+		return nil, ErrInvalidFlagThreshold
+	}
+
+	mod := t.datastore.Threads().Get(t.Id)
+	if mod == nil {
+		return nil, errThreadReload
+	}
+
+	return t.addMeta(&pb.ThreadMeta{
+		Name:          mod.Name,
+		Description:   mod.Description,
+		Cover:         mod.Cover,
+		Ttl:           mod.Ttl,
+		FlagThreshold: int32(threshold),
+	})
+}
+
+// addMeta commits, applies, and posts a meta block
+func (t *Thread) addMeta(msg *pb.ThreadMeta) (mh.Multihash, error) {
+	res, err := t.commitBlock(msg, pb.ThreadBlock_META, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.applyMeta(msg); err != nil {
+		return nil, err
+	}
+
+	if err := t.indexBlock(res, repo.MetaBlock, "", msg.Name); err != nil {
+		return nil, err
+	}
+
+	if err := t.updateHead(res.hash); err != nil {
+		return nil, err
+	}
+
+	if err := t.post(res, t.Peers()); err != nil {
+		return nil, err
+	}
+
+	log.Debugf("added META to %s: %s", t.Id, res.hash.B58String())
+
+	return res.hash, nil
+}
+
+// handleMetaBlock handles an incoming meta block.
+// Older meta blocks may arrive during back prop, so only the latest is applied.
+func (t *Thread) handleMetaBlock(hash mh.Multihash, block *pb.ThreadBlock) (*pb.ThreadMeta, error) {
+	msg := new(pb.ThreadMeta)
+	if err := ptypes.UnmarshalAny(block.Payload, msg); err != nil {
+		return nil, err
+	}
+
+	date, err := ptypes.Timestamp(block.Header.Date)
+	if err != nil {
+		return nil, err
+	}
+	if msg.Name != "" && t.metaIsLatest(date) {
+		if err := t.applyMeta(msg); err != nil {
+			return nil, err
+		}
+	}
+
+	// indexing after applying ensures the pushed update carries the new name
+	if err := t.indexBlock(&commitResult{
+		hash:   hash,
+		header: block.Header,
+	}, repo.MetaBlock, "", msg.Name); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// metaIsLatest returns whether or not a meta block date is newer than all indexed meta blocks
+func (t *Thread) metaIsLatest(date time.Time) bool {
+	query := t.indexQuery(repo.MetaBlock)
+	query.Limit = 1
+	metas := t.datastore.Blocks().ListByQuery(query)
+	if len(metas) == 0 {
+		return true
+	}
+	return date.After(metas[0].Date)
+}
+
+// applyMeta saves thread metadata.
+// A new flag threshold may hide or reveal flagged blocks.
+func (t *Thread) applyMeta(msg *pb.ThreadMeta) error {
+	mod := t.datastore.Threads().Get(t.Id)
+	if mod == nil {
+		return errThreadReload
+	}
+	if err := t.datastore.Threads().UpdateMeta(t.Id, msg.Name, msg.Description, msg.Cover, msg.Ttl); err != nil {
+		return err
+	}
+	t.Name = msg.Name
+
+	threshold := int(msg.FlagThreshold)
+	if threshold == mod.FlagThreshold || threshold < 0 {
+		return nil
+	}
+	if err := t.datastore.Threads().UpdateFlagThreshold(t.Id, threshold); err != nil {
+		return err
+	}
+	return t.moderateFlagTargets()
+}
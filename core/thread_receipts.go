@@ -0,0 +1,167 @@
+package core
+
+import (
+	"errors"
+	"time"
+
+	mh "gx/ipfs/QmPnFwZ2JXKnXgMw8CdBPxn7FWh6LLdjUjxV1fKHuJnkr8/go-multihash"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/textileio/textile-go/pb"
+	"github.com/textileio/textile-go/repo"
+)
+
+// ErrReceiptsOff indicates a read marker was attempted in a thread with receipts turned off
+var ErrReceiptsOff = errors.New("read receipts are turned off for this thread")
+
+// ThreadReceiptInfo reports the newest block a peer has read in a thread
+type ThreadReceiptInfo struct {
+	PeerId   string    `json:"peer_id"`
+	Username string    `json:"username,omitempty"`
+	BlockId  string    `json:"block_id"`
+	Date     time.Time `json:"date"`
+}
+
+// MarkRead announces the newest block read in this thread to thread peers.
+// Read markers are signed and encrypted like any other block, but are sent
+// directly to peers and never become part of the thread history.
+func (t *Thread) MarkRead(blockId string) (*repo.ThreadReceipt, error) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	mod := t.datastore.Threads().Get(t.Id)
+	if mod == nil {
+		return nil, errThreadReload
+	}
+	if mod.ReceiptsOff {
+		return nil, ErrReceiptsOff
+	}
+
+	rblock := t.datastore.Blocks().Get(blockId)
+	if rblock == nil || rblock.ThreadId != t.Id {
+		return nil, ErrBlockNotFound
+	}
+
+	res, err := t.sealRead(&pb.ThreadRead{Target: blockId})
+	if err != nil {
+		return nil, err
+	}
+	date, err := ptypes.Timestamp(res.header.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt := &repo.ThreadReceipt{
+		ThreadId: t.Id,
+		PeerId:   t.node().Identity.Pretty(),
+		BlockId:  blockId,
+		Date:     date,
+	}
+	if err := t.datastore.ThreadReceipts().AddOrUpdate(receipt); err != nil {
+		return nil, err
+	}
+
+	if err := t.post(res, t.Peers()); err != nil {
+		return nil, err
+	}
+
+	log.Debugf("added READ to %s: %s", t.Id, blockId)
+
+	return receipt, nil
+}
+
+// SetReceiptsOff opts this thread in or out of sending read markers
+func (t *Thread) SetReceiptsOff(off bool) error {
+	return t.datastore.Threads().UpdateReceiptsOff(t.Id, off)
+}
+
+// sealRead signs and encrypts a read marker without adding it to ipfs.
+// The marker has no parents, so peers never try to link it into history.
+func (t *Thread) sealRead(msg *pb.ThreadRead) (*commitResult, error) {
+	pdate, err := ptypes.TimestampProto(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	payload, err := ptypes.MarshalAny(msg)
+	if err != nil {
+		return nil, err
+	}
+	block := &pb.ThreadBlock{
+		Header: &pb.ThreadBlockHeader{
+			Date:    pdate,
+			Author:  t.node().Identity.Pretty(),
+			Address: t.config.Account.Address,
+		},
+		Type:    pb.ThreadBlock_READ,
+		Payload: payload,
+	}
+	if err := t.signBlock(block); err != nil {
+		return nil, err
+	}
+	plaintext, err := proto.Marshal(block)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := t.Encrypt(plaintext)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := mh.Sum(ciphertext, mh.SHA2_256, -1)
+	if err != nil {
+		return nil, err
+	}
+	return &commitResult{hash, ciphertext, block.Header}, nil
+}
+
+// readAllowed returns whether or not an incoming read marker may be handled.
+// Markers are signed like any other block, but are never part of the history,
+// so a kicked peer or a retired key can't be shown to precede the kick or rotation.
+func (t *Thread) readAllowed(hash mh.Multihash, block *pb.ThreadBlock, retiredBy string) bool {
+	return t.kickAllowed(hash, block) && t.keyAllowed(hash, block, retiredBy)
+}
+
+// handleReadBlock handles an incoming read marker from a thread peer.
+// Markers can arrive out of order, so only a newer marker replaces the last one.
+func (t *Thread) handleReadBlock(block *pb.ThreadBlock) (*pb.ThreadRead, error) {
+	msg := new(pb.ThreadRead)
+	if err := ptypes.UnmarshalAny(block.Payload, msg); err != nil {
+		return nil, err
+	}
+
+	author := block.Header.Author
+	if !t.hasPeer(author) {
+		return msg, nil
+	}
+
+	date, err := ptypes.Timestamp(block.Header.Date)
+	if err != nil {
+		return nil, err
+	}
+	last := t.datastore.ThreadReceipts().Get(t.Id, author)
+	if last != nil && !date.After(last.Date) {
+		return msg, nil
+	}
+
+	if err := t.datastore.ThreadReceipts().AddOrUpdate(&repo.ThreadReceipt{
+		ThreadId: t.Id,
+		PeerId:   author,
+		BlockId:  msg.Target,
+		Date:     date,
+	}); err != nil {
+		return nil, err
+	}
+
+	t.sendUpdate(ThreadUpdate{
+		ThreadId:   t.Id,
+		ThreadName: t.Name,
+		Info: ThreadReceiptInfo{
+			PeerId:   author,
+			Username: t.contactUsername(author),
+			BlockId:  msg.Target,
+			Date:     date,
+		},
+	})
+
+	return msg, nil
+}
@@ -0,0 +1,171 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	mh "gx/ipfs/QmPnFwZ2JXKnXgMw8CdBPxn7FWh6LLdjUjxV1fKHuJnkr8/go-multihash"
+	"gx/ipfs/QmTRhk7cgjUf2gfQ3p2M9KPECNZEW9XUrmHcFCgog4cPgB/go-libp2p-peer"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/textileio/textile-go/pb"
+	"github.com/textileio/textile-go/repo"
+)
+
+// ErrRoleNotAllowed indicates a role change was attempted without permission
+var ErrRoleNotAllowed = errors.New("not allowed to grant roles in this thread")
+
+// ErrInvalidThreadRole indicates a role outside the known thread roles
+var ErrInvalidThreadRole = errors.New("invalid thread role")
+
+// AddRole adds an outgoing role block, which grants a role to a peer,
+// or revokes any granted role with the default role
+func (t *Thread) AddRole(peerId string, role repo.ThreadRole) (mh.Multihash, error) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	if !t.canWrite(pb.ThreadBlock_ROLE) {
+		return nil, ErrRoleNotAllowed
+	}
+	if _, err := peer.IDB58Decode(peerId); err != nil {
+		return nil, err
+	}
+	if !validRole(role) {
+		return nil, ErrInvalidThreadRole
+	}
+
+	// adding a role specific prefix here to ensure future flexibility
+	target := fmt.Sprintf("role-%s", peerId)
+
+	msg := &pb.ThreadRole{
+		Target: target,
+		Role:   pb.ThreadRole_Role(role),
+	}
+
+	res, err := t.commitBlock(msg, pb.ThreadBlock_ROLE, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.indexBlock(res, repo.RoleBlock, target, role.Description()); err != nil {
+		return nil, err
+	}
+
+	if err := t.datastore.ThreadPeers().UpdateRole(peerId, t.Id, role); err != nil {
+		return nil, err
+	}
+
+	if err := t.updateHead(res.hash); err != nil {
+		return nil, err
+	}
+
+	if err := t.post(res, t.Peers()); err != nil {
+		return nil, err
+	}
+
+	log.Debugf("added ROLE to %s: %s", t.Id, res.hash.B58String())
+
+	return res.hash, nil
+}
+
+// handleRoleBlock handles an incoming role block
+func (t *Thread) handleRoleBlock(hash mh.Multihash, block *pb.ThreadBlock) (*pb.ThreadRole, error) {
+	msg := new(pb.ThreadRole)
+	if err := ptypes.UnmarshalAny(block.Payload, msg); err != nil {
+		return nil, err
+	}
+	role := repo.ThreadRole(msg.Role)
+
+	peerId, ok := rolePeer(msg.Target)
+	if !ok || !validRole(role) {
+		return msg, t.handleDisallowedBlock(hash, block)
+	}
+
+	if err := t.indexBlock(&commitResult{
+		hash:   hash,
+		header: block.Header,
+	}, repo.RoleBlock, msg.Target, role.Description()); err != nil {
+		return nil, err
+	}
+
+	// the latest role wins, which might not be this one during back prop
+	if err := t.datastore.ThreadPeers().UpdateRole(peerId, t.Id, t.peerRole(peerId, "")); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// peerRole returns the causally latest role granted to a peer.
+// The initiator account is always an admin.
+func (t *Thread) peerRole(peerId string, address string) repo.ThreadRole {
+	if address != "" && address == t.initiator {
+		return repo.AdminRole
+	}
+	if peerId == "" {
+		return repo.DefaultRole
+	}
+
+	query := t.indexQuery(repo.RoleBlock)
+	query.Target = "role-" + peerId
+	query.Order = repo.CausalOrder
+	query.Limit = 1
+	roles := t.datastore.Blocks().ListByQuery(query)
+	if len(roles) == 0 {
+		return repo.DefaultRole
+	}
+	role, err := repo.ThreadRoleFromString(roles[0].Body)
+	if err != nil {
+		return repo.DefaultRole
+	}
+	return role
+}
+
+// outranks returns whether or not an author holds a higher role than a target peer
+func (t *Thread) outranks(author string, address string, peerId string) bool {
+	return t.peerRole(author, address) > t.contactRole(peerId)
+}
+
+// contactRole returns the latest role granted to a peer, looking up its account address
+// for the initiator check
+func (t *Thread) contactRole(peerId string) repo.ThreadRole {
+	var address string
+	if peerId == t.node().Identity.Pretty() {
+		address = t.account.Address()
+	} else if contact := t.datastore.Contacts().Get(peerId); contact != nil {
+		address = contact.Address
+	}
+	return t.peerRole(peerId, address)
+}
+
+// ignoreAllowed returns whether or not an author may ignore a block.
+// Authors may always ignore their own blocks, otherwise moderation rights are needed.
+// Blocks are handled in causal order, so an unknown target was never seen by the author.
+func (t *Thread) ignoreAllowed(author string, address string, target *repo.Block) bool {
+	if target == nil {
+		return false
+	}
+	if target.AuthorId == author {
+		return true
+	}
+	return t.peerRole(author, address) >= repo.ModeratorRole
+}
+
+// rolePeer returns the peer id of a role target, which must be a valid peer id
+// with the role prefix
+func rolePeer(target string) (string, bool) {
+	if !strings.HasPrefix(target, "role-") {
+		return "", false
+	}
+	peerId := strings.TrimPrefix(target, "role-")
+	if _, err := peer.IDB58Decode(peerId); err != nil {
+		return "", false
+	}
+	return peerId, true
+}
+
+// validRole returns whether or not a role is one of the known thread roles
+func validRole(role repo.ThreadRole) bool {
+	return role >= repo.DefaultRole && role <= repo.AdminRole
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/textileio/textile-go/repo"
+)
+
+// kSearchBodyField is the search doc field for block bodies, e.g., a message or caption
+const kSearchBodyField = "body"
+
+// indexSearch adds the searchable text of a block to the search index.
+// Edits replace the indexed body of their target.
+func (t *Thread) indexSearch(block *repo.Block) error {
+	switch block.Type {
+	case repo.MessageBlock, repo.CommentBlock, repo.FilesBlock:
+		if block.Body == "" || block.Hidden || t.ignored(block.Id) {
+			return nil
+		}
+		return t.addSearchDoc(block, kSearchBodyField, block.Body)
+
+	case repo.EditBlock:
+		target := t.datastore.Blocks().Get(strings.TrimPrefix(block.Target, "edit-"))
+		if target == nil || target.AuthorId != block.AuthorId || target.Hidden || t.ignored(target.Id) {
+			return nil
+		}
+
+		// edits can arrive out of order, so only the latest edit counts
+		query := t.indexQuery(repo.EditBlock)
+		query.Target = block.Target
+		query.AuthorIds = []string{target.AuthorId}
+		query.Order = repo.CausalOrder
+		query.Limit = 1
+		if edits := t.datastore.Blocks().ListByQuery(query); len(edits) > 0 {
+			return t.addSearchDoc(target, kSearchBodyField, edits[0].Body)
+		}
+		return nil
+
+	default:
+		return nil
+	}
+}
+
+// indexSearchFile adds the name and meta of a file to the search index
+// for each files block in this thread with the given target
+func (t *Thread) indexSearchFile(hash string, target string) error {
+	file := t.datastore.Files().Get(hash)
+	if file == nil {
+		return nil
+	}
+	text := fileSearchText(file)
+	if text == "" {
+		return nil
+	}
+
+	query := t.indexQuery(repo.FilesBlock)
+	query.Target = target
+	for _, block := range t.datastore.Blocks().ListByQuery(query) {
+		if block.Hidden || t.ignored(block.Id) {
+			continue
+		}
+		if err := t.addSearchDoc(&block, "file:"+hash, text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reindexSearch adds a block back to the search index, along with its latest edit
+// and file names, e.g., after it is revealed
+func (t *Thread) reindexSearch(block *repo.Block) error {
+	if err := t.indexSearch(block); err != nil {
+		return err
+	}
+
+	query := t.indexQuery(repo.EditBlock)
+	query.Target = "edit-" + block.Id
+	query.AuthorIds = []string{block.AuthorId}
+	query.Order = repo.CausalOrder
+	query.Limit = 1
+	if edits := t.datastore.Blocks().ListByQuery(query); len(edits) > 0 {
+		if err := t.indexSearch(&edits[0]); err != nil {
+			return err
+		}
+	}
+
+	if block.Type != repo.FilesBlock {
+		return nil
+	}
+	for _, file := range t.datastore.Files().ListByTarget(block.Target) {
+		if err := t.indexSearchFile(file.Hash, block.Target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addSearchDoc indexes text for a block, replacing any text indexed for the same field
+func (t *Thread) addSearchDoc(block *repo.Block, field string, text string) error {
+	return t.datastore.Search().Add(&repo.SearchDoc{
+		BlockId:  block.Id,
+		ThreadId: block.ThreadId,
+		AuthorId: block.AuthorId,
+		Type:     block.Type,
+		Date:     block.Date,
+		Field:    field,
+		Text:     text,
+	})
+}
+
+// fileSearchText returns the name of a file followed by its string meta values
+func fileSearchText(file *repo.File) string {
+	var keys []string
+	for k := range file.Meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := []string{file.Name}
+	for _, k := range keys {
+		if v, ok := file.Meta[k].(string); ok {
+			parts = append(parts, v)
+		}
+	}
+	return strings.TrimSpace(strings.Join(parts, " "))
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	mh "gx/ipfs/QmPnFwZ2JXKnXgMw8CdBPxn7FWh6LLdjUjxV1fKHuJnkr8/go-multihash"
 	libp2pc "gx/ipfs/QmPvyPwuCgJ7pDmrKDxRtsScJgBaM5h4EpRL2qQJsmXf4n/go-libp2p-crypto"
@@ -71,6 +73,14 @@ func (h *ThreadsService) Ping(pid peer.ID) (service.PeerStatus, error) {
 
 // Handle is called by the underlying service handler method
 func (h *ThreadsService) Handle(pid peer.ID, env *pb.Envelope) (*pb.Envelope, error) {
+	switch env.Message.Type {
+	case pb.Message_THREAD_SIGNAL:
+		return nil, h.handleSignal(pid, env)
+	case pb.Message_THREAD_JOIN_REQUEST:
+		return nil, h.handleJoinRequest(pid, env)
+	case pb.Message_THREAD_JOIN_DENIAL:
+		return nil, h.handleJoinDenial(pid, env)
+	}
 	if env.Message.Type != pb.Message_THREAD_ENVELOPE {
 		return nil, nil
 	}
@@ -92,7 +102,7 @@ func (h *ThreadsService) Handle(pid peer.ID, env *pb.Envelope) (*pb.Envelope, er
 		return nil, nil
 	}
 
-	block, err := thrd.handleBlock(hash, tenv.Ciphertext)
+	block, retiredBy, err := thrd.handleBlock(hash, tenv.Ciphertext)
 	if err != nil {
 		return nil, err
 	}
@@ -101,45 +111,102 @@ func (h *ThreadsService) Handle(pid peer.ID, env *pb.Envelope) (*pb.Envelope, er
 		return nil, nil
 	}
 
-	switch block.Type {
-	case pb.ThreadBlock_MERGE:
-		log.Debugf("handling MERGE from %s", block.Header.Author)
-		err = h.handleMerge(thrd, hash, block)
-	case pb.ThreadBlock_IGNORE:
-		log.Debugf("handling IGNORE from %s", block.Header.Author)
-		err = h.handleIgnore(thrd, hash, block)
-	case pb.ThreadBlock_FLAG:
-		log.Debugf("handling FLAG from %s", block.Header.Author)
-		err = h.handleFlag(thrd, hash, block)
-	case pb.ThreadBlock_JOIN:
-		log.Debugf("handling JOIN from %s", block.Header.Author)
-		err = h.handleJoin(thrd, hash, block)
-	case pb.ThreadBlock_ANNOUNCE:
-		log.Debugf("handling ANNOUNCE from %s", block.Header.Author)
-		err = h.handleAnnounce(thrd, hash, block)
-	case pb.ThreadBlock_LEAVE:
-		log.Debugf("handling LEAVE from %s", block.Header.Author)
-		err = h.handleLeave(thrd, hash, block)
-	case pb.ThreadBlock_MESSAGE:
-		log.Debugf("handling MESSAGE from %s", block.Header.Author)
-		err = h.handleMessage(thrd, hash, block)
-	case pb.ThreadBlock_FILES:
-		log.Debugf("handling FILES from %s", block.Header.Author)
-		err = h.handleFiles(thrd, hash, block)
-	case pb.ThreadBlock_COMMENT:
-		log.Debugf("handling COMMENT from %s", block.Header.Author)
-		err = h.handleComment(thrd, hash, block)
-	case pb.ThreadBlock_LIKE:
-		log.Debugf("handling LIKE from %s", block.Header.Author)
-		err = h.handleLike(thrd, hash, block)
-	default:
-		return nil, nil
+	// read markers are not part of the thread history, so disallowed ones are just dropped
+	if block.Type == pb.ThreadBlock_READ {
+		if !thrd.readAllowed(hash, block, retiredBy) {
+			log.Warningf("ignoring READ from %s in thread %s", block.Header.Author, thrd.Id)
+			return nil, nil
+		}
+		log.Debugf("handling READ from %s", block.Header.Author)
+		_, err := thrd.handleReadBlock(block)
+		return nil, err
 	}
-	if err != nil {
+
+	// permissions are checked against the history a block was written on,
+	// except for checkpoints, whose history is loaded lazily
+	if !thrd.trustedCheckpoint(hash, block) {
+		err := thrd.followParents(block.Header.Parents)
+		if err == ErrParentsPending {
+			// the block is handled along with its parents when they are retried
+			log.Debugf("deferring %s until its parents are synced", hash.B58String())
+			_, err = thrd.queueSync([]string{hash.B58String()})
+			return nil, err
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := thrd.verifyClock(block); err != nil {
 		return nil, err
 	}
 
-	if err := thrd.followParents(block.Header.Parents); err != nil {
+	if !thrd.blockAllowed(hash, block) || !thrd.keyAllowed(hash, block, retiredBy) {
+		err = thrd.handleDisallowedBlock(hash, block)
+	} else {
+		switch block.Type {
+		case pb.ThreadBlock_MERGE:
+			log.Debugf("handling MERGE from %s", block.Header.Author)
+			err = h.handleMerge(thrd, hash, block)
+		case pb.ThreadBlock_IGNORE:
+			log.Debugf("handling IGNORE from %s", block.Header.Author)
+			err = h.handleIgnore(thrd, hash, block)
+		case pb.ThreadBlock_FLAG:
+			log.Debugf("handling FLAG from %s", block.Header.Author)
+			err = h.handleFlag(thrd, hash, block)
+		case pb.ThreadBlock_UNDO:
+			log.Debugf("handling UNDO from %s", block.Header.Author)
+			err = h.handleUndo(thrd, hash, block)
+		case pb.ThreadBlock_JOIN:
+			log.Debugf("handling JOIN from %s", block.Header.Author)
+			err = h.handleJoin(thrd, hash, block)
+		case pb.ThreadBlock_ANNOUNCE:
+			log.Debugf("handling ANNOUNCE from %s", block.Header.Author)
+			err = h.handleAnnounce(thrd, hash, block)
+		case pb.ThreadBlock_LEAVE:
+			log.Debugf("handling LEAVE from %s", block.Header.Author)
+			err = h.handleLeave(thrd, hash, block)
+		case pb.ThreadBlock_MESSAGE:
+			log.Debugf("handling MESSAGE from %s", block.Header.Author)
+			err = h.handleMessage(thrd, hash, block)
+		case pb.ThreadBlock_FILES:
+			log.Debugf("handling FILES from %s", block.Header.Author)
+			err = h.handleFiles(thrd, hash, block)
+		case pb.ThreadBlock_COMMENT:
+			log.Debugf("handling COMMENT from %s", block.Header.Author)
+			err = h.handleComment(thrd, hash, block)
+		case pb.ThreadBlock_LIKE:
+			log.Debugf("handling LIKE from %s", block.Header.Author)
+			err = h.handleLike(thrd, hash, block)
+		case pb.ThreadBlock_KEY:
+			log.Debugf("handling KEY from %s", block.Header.Author)
+			err = h.handleKey(thrd, hash, block)
+		case pb.ThreadBlock_KICK:
+			log.Debugf("handling KICK from %s", block.Header.Author)
+			err = h.handleKick(thrd, hash, block)
+		case pb.ThreadBlock_ROLE:
+			log.Debugf("handling ROLE from %s", block.Header.Author)
+			err = h.handleRole(thrd, hash, block)
+		case pb.ThreadBlock_EDIT:
+			log.Debugf("handling EDIT from %s", block.Header.Author)
+			err = h.handleEdit(thrd, hash, block)
+		case pb.ThreadBlock_META:
+			log.Debugf("handling META from %s", block.Header.Author)
+			err = h.handleMeta(thrd, hash, block)
+		case pb.ThreadBlock_CHECKPOINT:
+			log.Debugf("handling CHECKPOINT from %s", block.Header.Author)
+			err = h.handleCheckpoint(thrd, hash, block)
+		case pb.ThreadBlock_EXTERNAL_INVITE:
+			log.Debugf("handling EXTERNAL_INVITE from %s", block.Header.Author)
+			err = h.handleExternalInvite(thrd, hash, block)
+		case pb.ThreadBlock_JOIN_DECISION:
+			log.Debugf("handling JOIN_DECISION from %s", block.Header.Author)
+			err = h.handleJoinDecision(thrd, hash, block)
+		default:
+			return nil, nil
+		}
+	}
+	if err != nil {
 		return nil, err
 	}
 
@@ -152,6 +219,13 @@ func (h *ThreadsService) Handle(pid peer.ID, env *pb.Envelope) (*pb.Envelope, er
 		return nil, err
 	}
 
+	// departed peers still hold the thread key, so replace it
+	if block.Type == pb.ThreadBlock_LEAVE && thrd.shouldRotateKey() {
+		if _, err := thrd.rotateKey(); err != nil {
+			return nil, err
+		}
+	}
+
 	// flush cafe queue _at the very end_
 	go thrd.cafeOutbox.Flush()
 
@@ -173,6 +247,109 @@ func (h *ThreadsService) NewEnvelope(threadId string, hash mh.Multihash, ciphert
 	return h.service.NewEnvelope(pb.Message_THREAD_ENVELOPE, tenv, nil, false)
 }
 
+// NewSignalEnvelope signs and wraps an encrypted signal for transport
+func (h *ThreadsService) NewSignalEnvelope(threadId string, ciphertext []byte) (*pb.Envelope, error) {
+	senv := &pb.ThreadSignalEnvelope{
+		Thread:     threadId,
+		Ciphertext: ciphertext,
+	}
+	return h.service.NewEnvelope(pb.Message_THREAD_SIGNAL, senv, nil, false)
+}
+
+// NewJoinRequestEnvelope signs and wraps a join request for transport
+func (h *ThreadsService) NewJoinRequestEnvelope(msg *pb.ThreadJoinRequest) (*pb.Envelope, error) {
+	return h.service.NewEnvelope(pb.Message_THREAD_JOIN_REQUEST, msg, nil, false)
+}
+
+// NewJoinDenialEnvelope signs and wraps a join request denial for transport
+func (h *ThreadsService) NewJoinDenialEnvelope(msg *pb.ThreadJoinDenial) (*pb.Envelope, error) {
+	return h.service.NewEnvelope(pb.Message_THREAD_JOIN_DENIAL, msg, nil, false)
+}
+
+// handleSignal receives a signal message
+func (h *ThreadsService) handleSignal(pid peer.ID, env *pb.Envelope) error {
+	senv := new(pb.ThreadSignalEnvelope)
+	if err := ptypes.UnmarshalAny(env.Message.Payload, senv); err != nil {
+		return err
+	}
+	thrd := h.getThread(senv.Thread)
+	if thrd == nil {
+		return nil
+	}
+	return thrd.handleSignal(pid, senv.Ciphertext)
+}
+
+// handleJoinRequest receives a request to join a thread
+func (h *ThreadsService) handleJoinRequest(pid peer.ID, env *pb.Envelope) error {
+	msg := new(pb.ThreadJoinRequest)
+	if err := ptypes.UnmarshalAny(env.Message.Payload, msg); err != nil {
+		return err
+	}
+	thrd := h.getThread(msg.Thread)
+	if thrd == nil {
+		return nil
+	}
+
+	log.Debugf("handling THREAD_JOIN_REQUEST from %s", pid.Pretty())
+
+	req, err := thrd.handleJoinRequest(pid, msg)
+	if err != nil {
+		return err
+	}
+	if req == nil {
+		return nil
+	}
+
+	return h.sendNotification(&repo.Notification{
+		Id:        ksuid.New().String(),
+		Date:      req.Date,
+		ActorId:   req.PeerId,
+		Subject:   thrd.Name,
+		SubjectId: thrd.Id,
+		Target:    req.Id,
+		Type:      repo.JoinRequestReceivedNotification,
+		Body:      "requested to join",
+	})
+}
+
+// handleJoinDenial receives a denial of a request to join a thread
+func (h *ThreadsService) handleJoinDenial(pid peer.ID, env *pb.Envelope) error {
+	msg := new(pb.ThreadJoinDenial)
+	if err := ptypes.UnmarshalAny(env.Message.Payload, msg); err != nil {
+		return err
+	}
+	if h.getThread(msg.Thread) != nil {
+		return nil
+	}
+
+	// only the admins a request was sent to may deny it
+	req := h.datastore.ThreadJoinRequests().GetSent(msg.Thread, pid.Pretty())
+	if req == nil {
+		log.Warningf("ignoring THREAD_JOIN_DENIAL from %s, no request was sent", pid.Pretty())
+		return nil
+	}
+
+	log.Debugf("handling THREAD_JOIN_DENIAL from %s", pid.Pretty())
+
+	if err := h.datastore.ThreadJoinRequests().Decide(req.Id, repo.DeniedJoinRequest, pid.Pretty(), time.Now()); err != nil {
+		return err
+	}
+
+	body := "denied your request to join"
+	if msg.Note != "" {
+		body += ": " + msg.Note
+	}
+	return h.sendNotification(&repo.Notification{
+		Id:        ksuid.New().String(),
+		Date:      time.Now(),
+		ActorId:   pid.Pretty(),
+		Subject:   msg.Thread,
+		SubjectId: msg.Thread,
+		Type:      repo.JoinRequestDeniedNotification,
+		Body:      body,
+	})
+}
+
 // handleInvite receives an invite message
 func (h *ThreadsService) handleInvite(hash mh.Multihash, tenv *pb.ThreadEnvelope) error {
 	plaintext, err := crypto.Decrypt(h.service.Node.PrivateKey, tenv.Ciphertext)
@@ -187,6 +364,9 @@ func (h *ThreadsService) handleInvite(hash mh.Multihash, tenv *pb.ThreadEnvelope
 	if block.Type != pb.ThreadBlock_INVITE {
 		return ErrInvalidThreadBlock
 	}
+	if err := verifyBlockSig(block); err != nil {
+		return err
+	}
 	msg := new(pb.ThreadInvite)
 	if err := ptypes.UnmarshalAny(block.Payload, msg); err != nil {
 		return err
@@ -237,9 +417,45 @@ func (h *ThreadsService) handleIgnore(thrd *Thread, hash mh.Multihash, block *pb
 	return nil
 }
 
-// handleFlag receives a flag message
+// handleFlag receives a flag message.
+// Only the initiator and moderators are notified, since they review flags.
 func (h *ThreadsService) handleFlag(thrd *Thread, hash mh.Multihash, block *pb.ThreadBlock) error {
-	if _, err := thrd.handleFlagBlock(hash, block); err != nil {
+	msg, err := thrd.handleFlagBlock(hash, block)
+	if err != nil {
+		return err
+	}
+
+	if thrd.contactRole(h.service.Node.Identity.Pretty()) < repo.ModeratorRole || thrd.ignored(hash.B58String()) {
+		return nil
+	}
+	target := h.datastore.Blocks().Get(strings.TrimPrefix(msg.Target, "flag-"))
+	if target == nil {
+		return nil
+	}
+	var desc string
+	if target.AuthorId == h.service.Node.Identity.Pretty() {
+		desc = "your " + strings.ToLower(target.Type.Description())
+	} else {
+		desc = "a " + strings.ToLower(target.Type.Description())
+	}
+	notification, err := h.newNotification(block.Header, repo.FlagAddedNotification)
+	if err != nil {
+		return err
+	}
+	notification.Body = "flagged " + desc
+	if msg.Reason != pb.ThreadFlag_UNSPECIFIED {
+		notification.Body += " as " + strings.ToLower(msg.Reason.String())
+	}
+	notification.BlockId = hash.B58String()
+	notification.Target = target.Id
+	notification.Subject = thrd.Name
+	notification.SubjectId = thrd.Id
+	return h.sendNotification(notification)
+}
+
+// handleUndo receives an undo message
+func (h *ThreadsService) handleUndo(thrd *Thread, hash mh.Multihash, block *pb.ThreadBlock) error {
+	if _, err := thrd.handleUndoBlock(hash, block); err != nil {
 		return err
 	}
 	return nil
@@ -360,6 +576,10 @@ func (h *ThreadsService) handleLike(thrd *Thread, hash mh.Multihash, block *pb.T
 		return err
 	}
 
+	// an undo may have arrived first
+	if thrd.ignored(hash.B58String()) {
+		return nil
+	}
 	target := h.datastore.Blocks().Get(msg.Target)
 	if target == nil {
 		return nil
@@ -374,7 +594,11 @@ func (h *ThreadsService) handleLike(thrd *Thread, hash mh.Multihash, block *pb.T
 	if err != nil {
 		return err
 	}
-	notification.Body = "liked " + desc
+	if msg.Reaction == "" {
+		notification.Body = "liked " + desc
+	} else {
+		notification.Body = "reacted " + msg.Reaction + " to " + desc
+	}
 	notification.BlockId = hash.B58String()
 	notification.Target = target.Target
 	notification.Subject = thrd.Name
@@ -382,6 +606,70 @@ func (h *ThreadsService) handleLike(thrd *Thread, hash mh.Multihash, block *pb.T
 	return h.sendNotification(notification)
 }
 
+// handleKey receives a key message
+func (h *ThreadsService) handleKey(thrd *Thread, hash mh.Multihash, block *pb.ThreadBlock) error {
+	if _, err := thrd.handleKeyBlock(hash, block); err != nil {
+		return err
+	}
+	return nil
+}
+
+// handleKick receives a kick message
+func (h *ThreadsService) handleKick(thrd *Thread, hash mh.Multihash, block *pb.ThreadBlock) error {
+	if _, err := thrd.handleKickBlock(hash, block); err != nil {
+		return err
+	}
+	return nil
+}
+
+// handleRole receives a role message
+func (h *ThreadsService) handleRole(thrd *Thread, hash mh.Multihash, block *pb.ThreadBlock) error {
+	if _, err := thrd.handleRoleBlock(hash, block); err != nil {
+		return err
+	}
+	return nil
+}
+
+// handleEdit receives an edit message
+func (h *ThreadsService) handleEdit(thrd *Thread, hash mh.Multihash, block *pb.ThreadBlock) error {
+	if _, err := thrd.handleEditBlock(hash, block); err != nil {
+		return err
+	}
+	return nil
+}
+
+// handleMeta receives a meta message
+func (h *ThreadsService) handleMeta(thrd *Thread, hash mh.Multihash, block *pb.ThreadBlock) error {
+	if _, err := thrd.handleMetaBlock(hash, block); err != nil {
+		return err
+	}
+	return nil
+}
+
+// handleCheckpoint receives a checkpoint message
+func (h *ThreadsService) handleCheckpoint(thrd *Thread, hash mh.Multihash, block *pb.ThreadBlock) error {
+	if _, err := thrd.handleCheckpointBlock(hash, block); err != nil {
+		return err
+	}
+	return nil
+}
+
+// handleExternalInvite receives an external invite message
+func (h *ThreadsService) handleExternalInvite(thrd *Thread, hash mh.Multihash, block *pb.ThreadBlock) error {
+	if _, err := thrd.handleExternalInviteBlock(hash, block); err != nil {
+		return err
+	}
+	return nil
+}
+
+// handleJoinDecision receives a join decision message
+func (h *ThreadsService) handleJoinDecision(thrd *Thread, hash mh.Multihash, block *pb.ThreadBlock) error {
+	if _, err := thrd.handleJoinDecisionBlock(hash, block); err != nil {
+		return err
+	}
+	return nil
+}
+
 // newNotification returns new thread notification
 func (h *ThreadsService) newNotification(header *pb.ThreadBlockHeader, ntype repo.NotificationType) (*repo.Notification, error) {
 	date, err := ptypes.Timestamp(header.Date)
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"gx/ipfs/QmTRhk7cgjUf2gfQ3p2M9KPECNZEW9XUrmHcFCgog4cPgB/go-libp2p-peer"
+	inet "gx/ipfs/QmXuRkCR7BNQa9uqfpTiFWsTQLzmTWYg91Ja1w95gnqb6u/go-libp2p-net"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/textileio/textile-go/pb"
+)
+
+// kSignalTimeout is how long to wait on a peer when sending a signal
+const kSignalTimeout = time.Second * 5
+
+// ErrInvalidSignalType indicates an unknown signal type
+var ErrInvalidSignalType = errors.New("invalid signal type")
+
+// ThreadSignalInfo reports a transient signal from a thread peer, e.g., typing or presence
+type ThreadSignalInfo struct {
+	ThreadId string    `json:"thread_id"`
+	PeerId   string    `json:"peer_id"`
+	Username string    `json:"username,omitempty"`
+	Type     string    `json:"type"`
+	Data     string    `json:"data,omitempty"`
+	Date     time.Time `json:"date"`
+}
+
+// SignalTypeFromString returns a signal type by name
+func SignalTypeFromString(name string) (pb.ThreadSignal_Type, error) {
+	stype, ok := pb.ThreadSignal_Type_value[strings.ToUpper(name)]
+	if !ok {
+		return 0, ErrInvalidSignalType
+	}
+	return pb.ThreadSignal_Type(stype), nil
+}
+
+// SendSignal sends a transient signal to connected thread peers.
+// Signals are encrypted with the thread key, but are never stored or queued,
+// so peers that are not connected simply miss them.
+func (t *Thread) SendSignal(stype pb.ThreadSignal_Type, data string) error {
+	if !t.node().OnlineMode() {
+		return ErrOffline
+	}
+
+	date, err := ptypes.TimestampProto(time.Now())
+	if err != nil {
+		return err
+	}
+	plaintext, err := proto.Marshal(&pb.ThreadSignal{
+		Type: stype,
+		Data: data,
+		Date: date,
+	})
+	if err != nil {
+		return err
+	}
+	ciphertext, err := t.Encrypt(plaintext)
+	if err != nil {
+		return err
+	}
+	env, err := t.service().NewSignalEnvelope(t.Id, ciphertext)
+	if err != nil {
+		return err
+	}
+
+	network := t.node().PeerHost.Network()
+	for _, tp := range t.Peers() {
+		pid, err := peer.IDB58Decode(tp.Id)
+		if err != nil {
+			return err
+		}
+		if network.Connectedness(pid) != inet.Connected {
+			continue
+		}
+
+		go func(pid peer.ID) {
+			ctx, cancel := context.WithTimeout(context.Background(), kSignalTimeout)
+			defer cancel()
+			if err := t.service().SendMessage(ctx, pid, env); err != nil {
+				log.Debugf("error sending signal to %s: %s", pid.Pretty(), err)
+			}
+		}(pid)
+	}
+
+	return nil
+}
+
+// handleSignal handles an incoming signal from a thread peer
+func (t *Thread) handleSignal(pid peer.ID, ciphertext []byte) error {
+	if !t.hasPeer(pid.Pretty()) {
+		return nil
+	}
+
+	plaintext, err := t.Decrypt(ciphertext)
+	if err != nil {
+		return err
+	}
+	msg := new(pb.ThreadSignal)
+	if err := proto.Unmarshal(plaintext, msg); err != nil {
+		return err
+	}
+	date, err := ptypes.Timestamp(msg.Date)
+	if err != nil {
+		return err
+	}
+
+	t.sendSignal(ThreadSignalInfo{
+		ThreadId: t.Id,
+		PeerId:   pid.Pretty(),
+		Username: t.contactUsername(pid.Pretty()),
+		Type:     msg.Type.String(),
+		Data:     msg.Data,
+		Date:     date,
+	})
+
+	return nil
+}
@@ -0,0 +1,397 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	mh "gx/ipfs/QmPnFwZ2JXKnXgMw8CdBPxn7FWh6LLdjUjxV1fKHuJnkr8/go-multihash"
+
+	"github.com/textileio/textile-go/ipfs"
+	"github.com/textileio/textile-go/pb"
+	"github.com/textileio/textile-go/repo"
+)
+
+// defaultSyncConcurrency is the number of parallel block fetches used when not configured
+const defaultSyncConcurrency = 8
+
+// maxSyncAttempts is the number of syncs that may fail to follow a block before it's dropped
+const maxSyncAttempts = 5
+
+// ThreadSyncInfo reports the progress of a thread history sync
+type ThreadSyncInfo struct {
+	Pending int `json:"pending"`
+	Synced  int `json:"synced"`
+}
+
+// syncFetch holds the result of fetching a queued block
+type syncFetch struct {
+	ciphertext []byte
+	err        error
+}
+
+// ErrParentsPending indicates a block's parents are still queued for a sync retry
+var ErrParentsPending = errors.New("parents are pending sync")
+
+// syncPendingBlock is a fetched block waiting for its parents to be handled.
+// Only what's needed to order it is kept, the block is reloaded from the local
+// blockstore, where it was added when fetched, once it's handled.
+type syncPendingBlock struct {
+	clock      int64
+	parents    []string
+	checkpoint bool // trusted checkpoint, whose history is loaded lazily
+}
+
+// followParents queues a list of block ids for sync, returning once
+// they and all of their unknown ancestors have been processed.
+// The queue is persisted, so an interrupted sync can be resumed with sync.
+// ErrParentsPending is returned if any of them are left queued for a retry.
+func (t *Thread) followParents(parents []string) error {
+	queued, err := t.queueSync(parents)
+	if err != nil {
+		return err
+	}
+	if err := t.sync(); err != nil {
+		return err
+	}
+	if len(queued) == 0 {
+		return nil
+	}
+	for _, item := range t.datastore.ThreadSyncs().ListByThread(t.Id, -1) {
+		for _, id := range queued {
+			if item.Id == id {
+				return ErrParentsPending
+			}
+		}
+	}
+	return nil
+}
+
+// queueSync adds block ids that are not yet indexed to the sync queue,
+// returning the ids that were queued
+func (t *Thread) queueSync(ids []string) ([]string, error) {
+	var queued []string
+	for _, id := range ids {
+		if id == "" {
+			log.Debugf("found genesis block, aborting")
+			continue
+		}
+		if _, err := mh.FromB58String(id); err != nil {
+			return nil, err
+		}
+		if t.datastore.Blocks().Get(id) != nil {
+			continue
+		}
+
+		if err := t.datastore.ThreadSyncs().Add(&repo.ThreadSync{
+			Id:       id,
+			ThreadId: t.Id,
+			Date:     time.Now(),
+		}); err != nil {
+			if !repo.ConflictError(err) {
+				return nil, err
+			}
+		}
+		queued = append(queued, id)
+	}
+	return queued, nil
+}
+
+// sync drains the sync queue. Blocks are fetched breadth first from the newest, with each
+// batch fetched in parallel, bounded by the configured concurrency. Fetched blocks are
+// then handled in causal order, once their parents have been handled, so that
+// permissions are always checked against the history a block was written on.
+// A block that can't be followed stays queued, holding back its children, until the
+// next sync retries it. It's only dropped after maxSyncAttempts failed syncs.
+func (t *Thread) sync() error {
+	t.syncMux.Lock()
+	defer t.syncMux.Unlock()
+
+	// queued holds blocks that are fetching, pending, or awaiting a retry,
+	// a parent that is neither is indexed or was dropped
+	queued := make(map[string]bool)
+	attempts := make(map[string]int)
+	var todo []string
+	for _, item := range t.datastore.ThreadSyncs().ListByThread(t.Id, -1) {
+		queued[item.Id] = true
+		attempts[item.Id] = item.Attempts
+		todo = append(todo, item.Id)
+	}
+
+	pending := make(map[string]*syncPendingBlock)
+	children := make(map[string][]string)
+	var ready []string
+	var synced int
+
+	settled := func(id string) bool {
+		p := pending[id]
+		if p.checkpoint {
+			return true
+		}
+		for _, parent := range p.parents {
+			if parent != "" && queued[parent] {
+				return false
+			}
+		}
+		return true
+	}
+	drop := func(id string) error {
+		delete(queued, id)
+		if err := t.datastore.ThreadSyncs().Delete(id, t.Id); err != nil {
+			return err
+		}
+		for _, child := range children[id] {
+			if pending[child] != nil && settled(child) {
+				ready = append(ready, child)
+			}
+		}
+		delete(children, id)
+		return nil
+	}
+
+	for len(todo) > 0 || len(ready) > 0 {
+		if len(todo) > 0 {
+			limit := t.syncConcurrency()
+			if limit > len(todo) {
+				limit = len(todo)
+			}
+			batch := todo[:limit]
+			todo = todo[limit:]
+
+			fetched := t.fetchBlocks(batch)
+			for i, id := range batch {
+				hash, block, _, err := t.decodeSyncBlock(id, fetched[i])
+				if err != nil {
+					if attempts[id]+1 < maxSyncAttempts {
+						log.Warningf("failed to follow parent %s, will retry: %s", id, err)
+						if err := t.datastore.ThreadSyncs().AddAttempt(id, t.Id); err != nil {
+							return err
+						}
+						continue
+					}
+					log.Warningf("failed to follow parent %s, dropping: %s", id, err)
+					if err := drop(id); err != nil {
+						return err
+					}
+					continue
+				}
+
+				// exists, but its parents may not have been queued if a sync was interrupted
+				var parents []string
+				if block == nil {
+					if index := t.datastore.Blocks().Get(id); index != nil {
+						parents = index.Parents
+					}
+					if err := drop(id); err != nil {
+						return err
+					}
+				} else {
+					p := &syncPendingBlock{
+						clock:      block.Header.Clock,
+						parents:    block.Header.Parents,
+						checkpoint: t.trustedCheckpoint(hash, block),
+					}
+					pending[id] = p
+					// history behind a trusted checkpoint is loaded lazily
+					if !p.checkpoint {
+						parents = block.Header.Parents
+					}
+				}
+
+				added, err := t.queueSync(parents)
+				if err != nil {
+					return err
+				}
+				for _, a := range added {
+					if !queued[a] {
+						queued[a] = true
+						todo = append(todo, a)
+					}
+				}
+
+				if block != nil {
+					for _, parent := range block.Header.Parents {
+						if queued[parent] {
+							children[parent] = append(children[parent], id)
+						}
+					}
+					if settled(id) {
+						ready = append(ready, id)
+					}
+				}
+			}
+		}
+
+		// handle ready blocks oldest first, which may in turn ready their children
+		for len(ready) > 0 {
+			sort.Slice(ready, func(i, j int) bool {
+				ci := pending[ready[i]].clock
+				cj := pending[ready[j]].clock
+				if ci != cj {
+					return ci < cj
+				}
+				return ready[i] < ready[j]
+			})
+			id := ready[0]
+			ready = ready[1:]
+			p := pending[id]
+			if p == nil {
+				continue
+			}
+			delete(pending, id)
+
+			if err := t.syncBlock(id, t.fetchBlocks([]string{id})[0]); err != nil {
+				log.Warningf("failed to handle parent %s: %s", id, err)
+			} else {
+				synced++
+			}
+			if err := drop(id); err != nil {
+				return err
+			}
+		}
+
+		t.pushSyncUpdate(synced)
+	}
+
+	return nil
+}
+
+// fetchBlocks fetches the ciphertext of each block in parallel
+func (t *Thread) fetchBlocks(ids []string) []syncFetch {
+	fetched := make([]syncFetch, len(ids))
+	wg := sync.WaitGroup{}
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			ciphertext, err := ipfs.DataAtPath(t.node(), id)
+			fetched[i] = syncFetch{ciphertext: ciphertext, err: err}
+		}(i, id)
+	}
+	wg.Wait()
+	return fetched
+}
+
+// decodeSyncBlock decodes and verifies a fetched block, along with the key block that
+// retired its key, if any. The block is nil if it's already indexed.
+func (t *Thread) decodeSyncBlock(id string, fetched syncFetch) (mh.Multihash, *pb.ThreadBlock, string, error) {
+	if fetched.err != nil {
+		return nil, nil, "", fetched.err
+	}
+	hash, err := mh.FromB58String(id)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	block, retiredBy, err := t.handleBlock(hash, fetched.ciphertext)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return hash, block, retiredBy, nil
+}
+
+// syncBlock handles a fetched block right away, without waiting on its parents,
+// which is only safe once its parents are handled or it's vouched for by a trusted checkpoint
+func (t *Thread) syncBlock(id string, fetched syncFetch) error {
+	hash, block, retiredBy, err := t.decodeSyncBlock(id, fetched)
+	if err != nil {
+		return err
+	}
+	if block == nil {
+		return nil
+	}
+	return t.applySyncBlock(hash, block, retiredBy)
+}
+
+// applySyncBlock handles a synced block whose parents have been handled
+func (t *Thread) applySyncBlock(hash mh.Multihash, block *pb.ThreadBlock, retiredBy string) error {
+	if err := t.verifyClock(block); err != nil {
+		return err
+	}
+
+	var err error
+	if !t.blockAllowed(hash, block) || !t.keyAllowed(hash, block, retiredBy) {
+		return t.handleDisallowedBlock(hash, block)
+	}
+
+	switch block.Type {
+	case pb.ThreadBlock_MERGE:
+		err = t.indexMergeBlock(hash, block)
+	case pb.ThreadBlock_IGNORE:
+		_, err = t.handleIgnoreBlock(hash, block)
+	case pb.ThreadBlock_FLAG:
+		_, err = t.handleFlagBlock(hash, block)
+	case pb.ThreadBlock_UNDO:
+		_, err = t.handleUndoBlock(hash, block)
+	case pb.ThreadBlock_JOIN:
+		_, err = t.handleJoinBlock(hash, block)
+	case pb.ThreadBlock_ANNOUNCE:
+		_, err = t.handleAnnounceBlock(hash, block)
+	case pb.ThreadBlock_LEAVE:
+		err = t.handleLeaveBlock(hash, block)
+	case pb.ThreadBlock_MESSAGE:
+		_, err = t.handleMessageBlock(hash, block)
+	case pb.ThreadBlock_FILES:
+		_, err = t.handleFilesBlock(hash, block)
+	case pb.ThreadBlock_COMMENT:
+		_, err = t.handleCommentBlock(hash, block)
+	case pb.ThreadBlock_LIKE:
+		_, err = t.handleLikeBlock(hash, block)
+	case pb.ThreadBlock_KEY:
+		_, err = t.handleKeyBlock(hash, block)
+	case pb.ThreadBlock_KICK:
+		_, err = t.handleKickBlock(hash, block)
+	case pb.ThreadBlock_ROLE:
+		_, err = t.handleRoleBlock(hash, block)
+	case pb.ThreadBlock_EDIT:
+		_, err = t.handleEditBlock(hash, block)
+	case pb.ThreadBlock_META:
+		_, err = t.handleMetaBlock(hash, block)
+	case pb.ThreadBlock_CHECKPOINT:
+		_, err = t.handleCheckpointBlock(hash, block)
+	case pb.ThreadBlock_EXTERNAL_INVITE:
+		_, err = t.handleExternalInviteBlock(hash, block)
+	case pb.ThreadBlock_JOIN_DECISION:
+		_, err = t.handleJoinDecisionBlock(hash, block)
+	default:
+		err = errors.New(fmt.Sprintf("invalid message type: %s", block.Type))
+	}
+	return err
+}
+
+// parentsIndexed returns whether or not all parents have been indexed
+func (t *Thread) parentsIndexed(parents []string) bool {
+	for _, p := range parents {
+		if t.datastore.Blocks().Get(p) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// syncPending returns the number of blocks waiting to be synced
+func (t *Thread) syncPending() int {
+	return t.datastore.ThreadSyncs().CountByThread(t.Id)
+}
+
+// syncConcurrency returns the maximum number of parallel block fetches
+func (t *Thread) syncConcurrency() int {
+	if t.config == nil || t.config.Threads.Sync.Concurrency <= 0 {
+		return defaultSyncConcurrency
+	}
+	return t.config.Threads.Sync.Concurrency
+}
+
+// pushSyncUpdate pushes sync progress to UI listeners
+func (t *Thread) pushSyncUpdate(synced int) {
+	t.sendUpdate(ThreadUpdate{
+		ThreadId:   t.Id,
+		ThreadName: t.Name,
+		Info: ThreadSyncInfo{
+			Pending: t.syncPending(),
+			Synced:  synced,
+		},
+	})
+}
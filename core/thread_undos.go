@@ -0,0 +1,305 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	mh "gx/ipfs/QmPnFwZ2JXKnXgMw8CdBPxn7FWh6LLdjUjxV1fKHuJnkr8/go-multihash"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/segmentio/ksuid"
+	"github.com/textileio/textile-go/ipfs"
+	"github.com/textileio/textile-go/pb"
+	"github.com/textileio/textile-go/repo"
+)
+
+// ErrUndoNotAllowed indicates an undo targeted a block that is not an own ignore, like, or flag
+var ErrUndoNotAllowed = errors.New("only your own ignores, likes, and flags can be undone")
+
+// ErrUndoExists indicates an undo targeted a block that was already undone
+var ErrUndoExists = errors.New("block already undone")
+
+// ErrIgnoreNotFound indicates a restore targeted a block that was not ignored by the local peer
+var ErrIgnoreNotFound = errors.New("ignore not found")
+
+// AddUndo adds an outgoing undo block, which reverses an ignore, like, or flag
+// previously added by the local peer
+func (t *Thread) AddUndo(block string) (mh.Multihash, error) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	if !t.canWrite(pb.ThreadBlock_UNDO) {
+		return nil, ErrWriteNotAllowed
+	}
+
+	rblock := t.datastore.Blocks().Get(block)
+	if rblock == nil || rblock.ThreadId != t.Id {
+		return nil, ErrBlockNotFound
+	}
+	if !undoable(rblock, t.node().Identity.Pretty()) {
+		return nil, ErrUndoNotAllowed
+	}
+	if t.undone(block) {
+		return nil, ErrUndoExists
+	}
+
+	// adding an undo specific prefix here to ensure future flexibility
+	target := fmt.Sprintf("undo-%s", block)
+
+	msg := &pb.ThreadUndo{
+		Target: target,
+	}
+
+	res, err := t.commitBlock(msg, pb.ThreadBlock_UNDO, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.indexBlock(res, repo.UndoBlock, target, rblock.Type.Description()); err != nil {
+		return nil, err
+	}
+
+	if err := t.applyUndo(rblock); err != nil {
+		return nil, err
+	}
+
+	if err := t.updateHead(res.hash); err != nil {
+		return nil, err
+	}
+
+	if err := t.post(res, t.Peers()); err != nil {
+		return nil, err
+	}
+
+	log.Debugf("added UNDO to %s: %s", t.Id, res.hash.B58String())
+
+	return res.hash, nil
+}
+
+// RestoreIgnored undoes the local peer's ignore of a block
+func (t *Thread) RestoreIgnored(block string) (mh.Multihash, error) {
+	return t.undoOwn(repo.IgnoreBlock, "ignore-"+block, ErrIgnoreNotFound)
+}
+
+// RemoveFlag undoes the local peer's flag of a block
+func (t *Thread) RemoveFlag(block string) (mh.Multihash, error) {
+	return t.undoOwn(repo.FlagBlock, "flag-"+block, ErrFlagNotFound)
+}
+
+// undoOwn undoes the local peer's active block of a type with the given target
+func (t *Thread) undoOwn(btype repo.BlockType, target string, notFound error) (mh.Multihash, error) {
+	t.mux.Lock()
+	query := t.indexQuery(btype)
+	query.Target = target
+	query.AuthorIds = []string{t.node().Identity.Pretty()}
+	var id string
+	for _, block := range t.datastore.Blocks().ListByQuery(query) {
+		if !t.undone(block.Id) {
+			id = block.Id
+			break
+		}
+	}
+	t.mux.Unlock()
+
+	if id == "" {
+		return nil, notFound
+	}
+	return t.AddUndo(id)
+}
+
+// handleUndoBlock handles an incoming undo block.
+// Blocks are handled in causal order, so an unknown target was never seen by the author.
+func (t *Thread) handleUndoBlock(hash mh.Multihash, block *pb.ThreadBlock) (*pb.ThreadUndo, error) {
+	msg := new(pb.ThreadUndo)
+	if err := ptypes.UnmarshalAny(block.Payload, msg); err != nil {
+		return nil, err
+	}
+
+	rblock := t.datastore.Blocks().Get(strings.TrimPrefix(msg.Target, "undo-"))
+	if rblock == nil || !undoable(rblock, block.Header.Author) {
+		return msg, t.handleDisallowedBlock(hash, block)
+	}
+
+	if err := t.indexBlock(&commitResult{
+		hash:   hash,
+		header: block.Header,
+	}, repo.UndoBlock, msg.Target, rblock.Type.Description()); err != nil {
+		return nil, err
+	}
+
+	if err := t.applyUndo(rblock); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// applyUndo reverses the effects of an undone block
+func (t *Thread) applyUndo(block *repo.Block) error {
+	switch block.Type {
+	case repo.IgnoreBlock:
+		id := strings.TrimPrefix(block.Target, "ignore-")
+		target := t.datastore.Blocks().Get(id)
+		if target == nil || t.ignored(id) {
+			return nil
+		}
+		return t.restoreBlock(target)
+
+	case repo.LikeBlock:
+		return t.datastore.Notifications().DeleteByBlock(block.Id)
+
+	case repo.FlagBlock:
+		if err := t.datastore.Notifications().DeleteByBlock(block.Id); err != nil {
+			return err
+		}
+		return t.moderateFlagTarget(strings.TrimPrefix(block.Target, "flag-"))
+
+	default:
+		return nil
+	}
+}
+
+// restoreBlock restores the index entries of a block that is no longer ignored.
+// File targets are reloaded from the files block in the dag.
+func (t *Thread) restoreBlock(block *repo.Block) error {
+	if block.Type == repo.FilesBlock && t.Schema != nil {
+		ciphertext, err := ipfs.DataAtPath(t.node(), block.Id)
+		if err != nil {
+			return err
+		}
+		pblock, err := t.decodeBlock(ciphertext)
+		if err != nil {
+			return err
+		}
+		msg := new(pb.ThreadFiles)
+		if err := ptypes.UnmarshalAny(pblock.Payload, msg); err != nil {
+			return err
+		}
+
+		node, err := t.loadFiles(msg)
+		if err != nil {
+			return err
+		}
+		if err := t.indexFiles(node, msg.Target); err != nil {
+			return err
+		}
+	}
+
+	if err := t.reindexSearch(block); err != nil {
+		return err
+	}
+
+	log.Debugf("restored %s in %s", block.Id, t.Id)
+
+	return t.restoreNotification(block)
+}
+
+// restoreNotification re-adds the notification removed when a block was ignored.
+// It is restored as read, since it was already delivered once.
+func (t *Thread) restoreNotification(block *repo.Block) error {
+	if block.AuthorId == t.node().Identity.Pretty() {
+		return nil
+	}
+
+	notification := &repo.Notification{
+		Id:        ksuid.New().String(),
+		Date:      block.Date,
+		ActorId:   block.AuthorId,
+		Subject:   t.Name,
+		SubjectId: t.Id,
+		BlockId:   block.Id,
+		Read:      true,
+	}
+
+	var schema string
+	if t.Schema != nil {
+		schema = t.Schema.Name
+	}
+	subject := threadSubject(schema)
+
+	switch block.Type {
+	case repo.MessageBlock:
+		notification.Type = repo.MessageAddedNotification
+		notification.Body = block.Body
+
+	case repo.FilesBlock:
+		notification.Type = repo.FilesAddedNotification
+		notification.Target = block.Target
+		notification.Body = "added a " + subject
+
+	case repo.CommentBlock, repo.LikeBlock:
+		target := t.datastore.Blocks().Get(block.Target)
+		if target == nil {
+			return nil
+		}
+		var desc string
+		if target.AuthorId == t.node().Identity.Pretty() {
+			desc = "your " + subject
+		} else {
+			desc = "a " + subject
+		}
+		notification.Target = target.Target
+
+		if block.Type == repo.CommentBlock {
+			notification.Type = repo.CommentAddedNotification
+			notification.Body = fmt.Sprintf("commented on %s: \"%s\"", desc, block.Body)
+		} else {
+			notification.Type = repo.LikeAddedNotification
+			if reactionName(block.Body) == DefaultReaction {
+				notification.Body = "liked " + desc
+			} else {
+				notification.Body = "reacted " + block.Body + " to " + desc
+			}
+		}
+
+	default:
+		return nil
+	}
+
+	return t.datastore.Notifications().Add(notification)
+}
+
+// undone returns whether or not a block was undone by a verified undo from its author
+func (t *Thread) undone(id string) bool {
+	block := t.datastore.Blocks().Get(id)
+	if block == nil {
+		return false
+	}
+	query := t.indexQuery(repo.UndoBlock)
+	query.Target = "undo-" + id
+	query.AuthorIds = []string{block.AuthorId}
+	query.Verified = true
+	return t.datastore.Blocks().CountByQuery(query) > 0
+}
+
+// activeIgnores returns the ignores targeting a block that were not undone, newest first.
+// An ignore of a disallowed block targets itself and can't be undone.
+func (t *Thread) activeIgnores(id string) []repo.Block {
+	var ignores []repo.Block
+	query := t.indexQuery(repo.IgnoreBlock)
+	query.Target = "ignore-" + id
+	for _, ignore := range t.datastore.Blocks().ListByQuery(query) {
+		if ignore.Id == id || !t.undone(ignore.Id) {
+			ignores = append(ignores, ignore)
+		}
+	}
+	return ignores
+}
+
+// undoable returns whether or not a block can be undone by an author,
+// which is the case for the author's own ignores, likes, and flags
+func undoable(block *repo.Block, author string) bool {
+	if block.AuthorId != author {
+		return false
+	}
+	switch block.Type {
+	case repo.IgnoreBlock:
+		// an ignore of a disallowed block targets itself
+		return block.Target != "ignore-"+block.Id
+	case repo.LikeBlock, repo.FlagBlock:
+		return true
+	default:
+		return false
+	}
+}
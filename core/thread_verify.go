@@ -0,0 +1,196 @@
+package core
+
+import (
+	"gx/ipfs/QmPSQnBKM9g7BaUcZCvswUJVscQ1ipjmwxN5PXCjkp9EQ7/go-cid"
+
+	"github.com/textileio/textile-go/ipfs"
+	"github.com/textileio/textile-go/repo"
+)
+
+// ThreadVerifyResult reports the integrity of a thread's history
+type ThreadVerifyResult struct {
+	ThreadId       string   `json:"thread_id"`
+	Head           string   `json:"head"`
+	BlockCount     int      `json:"block_cnt"`
+	MissingParents []string `json:"missing_parents"`
+	Orphans        []string `json:"orphans"`
+	Undecryptable  []string `json:"undecryptable"`
+	MissingTargets []string `json:"missing_targets"`
+	Repaired       []string `json:"repaired,omitempty"`
+}
+
+// Ok returns whether or not the thread history is complete
+func (r *ThreadVerifyResult) Ok() bool {
+	return len(r.MissingParents) == 0 &&
+		len(r.Orphans) == 0 &&
+		len(r.Undecryptable) == 0 &&
+		len(r.MissingTargets) == 0
+}
+
+// Verify walks the thread history from head using only local data, reporting
+// parents missing from the ipfs blockstore, blocks that are not indexed (orphans),
+// blocks that can't be decrypted, and file targets missing from the ipfs blockstore.
+// With repair, missing pieces are re-fetched from the network and orphans are re-indexed.
+func (t *Thread) Verify(repair bool) (*ThreadVerifyResult, error) {
+	res, err := t.verify()
+	if err != nil {
+		return nil, err
+	}
+	if !repair || res.Ok() {
+		return res, nil
+	}
+
+	var repaired []string
+
+	// syncing a block fetches it if needed, then indexes it and any unindexed ancestors
+	ids := append(res.MissingParents, res.Orphans...)
+	if err := t.followParents(ids); err != nil && err != ErrParentsPending {
+		return nil, err
+	}
+	for _, id := range ids {
+		if t.datastore.Blocks().Get(id) == nil {
+			log.Warningf("failed to repair block %s", id)
+			continue
+		}
+		if _, err := ipfs.DataAtPath(t.node(), id); err != nil {
+			log.Warningf("failed to repair block %s: %s", id, err)
+			continue
+		}
+		repaired = append(repaired, id)
+	}
+
+	for _, id := range res.MissingTargets {
+		if err := t.fetchNode(id); err != nil {
+			log.Warningf("failed to repair file node %s: %s", id, err)
+			continue
+		}
+		repaired = append(repaired, id)
+	}
+
+	res, err = t.verify()
+	if err != nil {
+		return nil, err
+	}
+	res.Repaired = repaired
+
+	return res, nil
+}
+
+// verify walks the thread history from head without touching the network
+func (t *Thread) verify() (*ThreadVerifyResult, error) {
+	head, err := t.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ThreadVerifyResult{
+		ThreadId:       t.Id,
+		Head:           head,
+		MissingParents: make([]string, 0),
+		Orphans:        make([]string, 0),
+		Undecryptable:  make([]string, 0),
+		MissingTargets: make([]string, 0),
+	}
+	if head == "" {
+		return res, nil
+	}
+
+	seen := make(map[string]bool)
+	queue := []string{head}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		local, err := ipfs.HasBlock(t.node(), id)
+		if err != nil {
+			return nil, err
+		}
+		if !local {
+			res.MissingParents = append(res.MissingParents, id)
+			continue
+		}
+
+		ciphertext, err := ipfs.DataAtPath(t.node(), id)
+		if err != nil {
+			return nil, err
+		}
+		block, err := t.decodeBlock(ciphertext)
+		if err != nil {
+			res.Undecryptable = append(res.Undecryptable, id)
+			continue
+		}
+		res.BlockCount++
+
+		index := t.datastore.Blocks().Get(id)
+		if index == nil {
+			res.Orphans = append(res.Orphans, id)
+		} else if index.Type == repo.FilesBlock && !t.ignored(id) {
+			missing, err := t.missingNodes(index.Target, make(map[string]bool))
+			if err != nil {
+				return nil, err
+			}
+			res.MissingTargets = append(res.MissingTargets, missing...)
+		}
+
+		queue = append(queue, block.Header.Parents...)
+	}
+
+	return res, nil
+}
+
+// missingNodes returns the nodes of a dag that are missing from the local blockstore
+func (t *Thread) missingNodes(id string, seen map[string]bool) ([]string, error) {
+	if seen[id] {
+		return nil, nil
+	}
+	seen[id] = true
+
+	local, err := ipfs.HasBlock(t.node(), id)
+	if err != nil {
+		return nil, err
+	}
+	if !local {
+		return []string{id}, nil
+	}
+
+	dec, err := cid.Decode(id)
+	if err != nil {
+		return nil, err
+	}
+	node, err := ipfs.NodeAtCid(t.node(), dec)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, link := range node.Links() {
+		lmissing, err := t.missingNodes(link.Cid.Hash().B58String(), seen)
+		if err != nil {
+			return nil, err
+		}
+		missing = append(missing, lmissing...)
+	}
+	return missing, nil
+}
+
+// fetchNode fetches and pins a node from the network
+func (t *Thread) fetchNode(id string) error {
+	dec, err := cid.Decode(id)
+	if err != nil {
+		return err
+	}
+	node, err := ipfs.NodeAtCid(t.node(), dec)
+	if err != nil {
+		return err
+	}
+	return ipfs.PinNode(t.node(), node, false)
+}
+
+// ignored returns whether or not a block has been ignored or undone
+func (t *Thread) ignored(id string) bool {
+	return t.undone(id) || len(t.activeIgnores(id)) > 0
+}
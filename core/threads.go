@@ -3,6 +3,7 @@ package core
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	mh "gx/ipfs/QmPnFwZ2JXKnXgMw8CdBPxn7FWh6LLdjUjxV1fKHuJnkr8/go-multihash"
 	libp2pc "gx/ipfs/QmPvyPwuCgJ7pDmrKDxRtsScJgBaM5h4EpRL2qQJsmXf4n/go-libp2p-crypto"
@@ -201,7 +202,7 @@ func (t *Textile) AcceptThreadInvite(inviteId string) (mh.Multihash, error) {
 		return nil, ErrThreadInviteNotFound
 	}
 
-	hash, err := t.handleThreadInvite(invite.Block)
+	hash, err := t.handleThreadInvite(invite.Block, "")
 	if err != nil {
 		return nil, err
 	}
@@ -214,8 +215,25 @@ func (t *Textile) AcceptThreadInvite(inviteId string) (mh.Multihash, error) {
 }
 
 // AcceptExternalThreadInvite attemps to download an encrypted thread key from an external invite,
-// adds a new thread, and notifies the inviter of the join
+// adds a new thread, and notifies the inviter of the join.
+// An invite link can be given in place of the invite id, in which case the key is not needed.
 func (t *Textile) AcceptExternalThreadInvite(inviteId string, key []byte) (mh.Multihash, error) {
+	if len(key) == 0 {
+		link, err := DecodeInviteLink(inviteId)
+		if err != nil {
+			return nil, err
+		}
+		inviteId = link.Id
+		key = link.Key
+
+		// the invite is stored with the inviter's cafes, which may not be connected yet
+		for _, inbox := range link.Inboxes {
+			if _, err := ipfs.SwarmConnect(t.node, []string{"/ipfs/" + inbox}); err != nil {
+				log.Warningf("unable to connect to invite inbox %s: %s", inbox, err)
+			}
+		}
+	}
+
 	ciphertext, err := ipfs.DataAtPath(t.node, fmt.Sprintf("%s", inviteId))
 	if err != nil {
 		return nil, err
@@ -226,7 +244,7 @@ func (t *Textile) AcceptExternalThreadInvite(inviteId string, key []byte) (mh.Mu
 	if err != nil {
 		return nil, ErrInvalidThreadBlock
 	}
-	return t.handleThreadInvite(plaintext)
+	return t.handleThreadInvite(plaintext, inviteId)
 }
 
 // IgnoreThreadInvite deletes the invite and removes the associated notification.
@@ -237,8 +255,10 @@ func (t *Textile) IgnoreThreadInvite(inviteId string) error {
 	return t.datastore.Notifications().DeleteByBlock(inviteId)
 }
 
-// handleThreadInvite uses an invite block to join a thread
-func (t *Textile) handleThreadInvite(plaintext []byte) (mh.Multihash, error) {
+// handleThreadInvite uses an invite block to join a thread.
+// External invites are referenced by id in the join, and the inviter's record of the invite
+// is passed along, so peers can check its limits.
+func (t *Textile) handleThreadInvite(plaintext []byte, externalId string) (mh.Multihash, error) {
 	block := new(pb.ThreadBlock)
 	if err := proto.Unmarshal(plaintext, block); err != nil {
 		return nil, err
@@ -246,10 +266,30 @@ func (t *Textile) handleThreadInvite(plaintext []byte) (mh.Multihash, error) {
 	if block.Type != pb.ThreadBlock_INVITE {
 		return nil, ErrInvalidThreadBlock
 	}
+	if err := verifyBlockSig(block); err != nil {
+		return nil, err
+	}
 	msg := new(pb.ThreadInvite)
 	if err := ptypes.UnmarshalAny(block.Payload, msg); err != nil {
 		return nil, err
 	}
+	if msg.Record != nil {
+		if msg.Record.Inviter != block.Header.Author {
+			return nil, ErrInvalidInviteRecord
+		}
+		if err := verifyInviteRecord(msg.Record); err != nil {
+			return nil, err
+		}
+	}
+	if msg.Expires != nil {
+		expires, err := ptypes.Timestamp(msg.Expires)
+		if err != nil {
+			return nil, err
+		}
+		if time.Now().After(expires) {
+			return nil, ErrInviteExpired
+		}
+	}
 
 	sk, err := libp2pc.UnmarshalPrivateKey(msg.Sk)
 	if err != nil {
@@ -285,6 +325,11 @@ func (t *Textile) handleThreadInvite(plaintext []byte) (mh.Multihash, error) {
 		return nil, err
 	}
 
+	// rotated keys are needed to read blocks written since the thread was created
+	if err := thrd.addInviteKeys(msg.Keys); err != nil {
+		return nil, err
+	}
+
 	// follow parents, update head
 	if err := thrd.handleInviteMessage(block); err != nil {
 		return nil, err
@@ -301,7 +346,7 @@ func (t *Textile) handleThreadInvite(plaintext []byte) (mh.Multihash, error) {
 	if err != nil {
 		return nil, err
 	}
-	hash, err := thrd.join(author)
+	hash, err := thrd.join(author, externalId, msg.Record)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,284 @@
+package core
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"gx/ipfs/QmZMWMvWMVKCbHetJ4RgndbuEF1io2UpUxwQwtNjtYPzSC/go-ipfs-files"
+
+	"github.com/textileio/textile-go/ipfs"
+	"github.com/textileio/textile-go/repo"
+)
+
+// ErrInvalidThreadArchive indicates an archive is missing its manifest or is malformed
+var ErrInvalidThreadArchive = errors.New("invalid thread archive")
+
+// ErrArchiveCidMismatch indicates an archive entry did not resolve to its archived cid
+var ErrArchiveCidMismatch = errors.New("archive cids do not match")
+
+// threadArchiveManifest is the name of the archive entry holding thread rows
+const threadArchiveManifest = "thread.json"
+
+// threadArchiveData is the archive directory holding raw ipfs data, re-added as unixfs files
+const threadArchiveData = "data/"
+
+// threadArchiveObjects is the archive directory holding ipfs dag objects, e.g., directories
+const threadArchiveObjects = "objects/"
+
+// threadArchive describes the datastore rows needed to rebuild a thread
+type threadArchive struct {
+	Thread repo.Thread       `json:"thread"`
+	Keys   []repo.ThreadKey  `json:"keys"`
+	Peers  []repo.ThreadPeer `json:"peers"`
+	Blocks []repo.Block      `json:"blocks"`
+	Files  []repo.File       `json:"files"`
+}
+
+// ExportThread writes a thread to a tar archive, including the thread keys,
+// every encrypted block, the schema, and all referenced file dags with their keys
+func (t *Textile) ExportThread(id string, w io.Writer) error {
+	thrd := t.Thread(id)
+	if thrd == nil {
+		return ErrThreadNotFound
+	}
+	mod := t.datastore.Threads().Get(thrd.Id)
+	if mod == nil {
+		return errThreadReload
+	}
+
+	archive := &threadArchive{
+		Thread: *mod,
+		Keys:   t.datastore.ThreadKeys().ListByThread(thrd.Id),
+		Peers:  t.datastore.ThreadPeers().ListByThread(thrd.Id),
+		Blocks: t.datastore.Blocks().ListByQuery(thrd.indexQuery()),
+	}
+
+	// include ourselves so the thread keeps us as a peer when imported elsewhere
+	archive.Peers = append(archive.Peers, repo.ThreadPeer{
+		Id:       t.node.Identity.Pretty(),
+		ThreadId: thrd.Id,
+		Welcomed: true,
+		Role:     thrd.peerRole(t.node.Identity.Pretty(), t.account.Address()),
+	})
+
+	var targets []string
+	query := &repo.BlockQuery{
+		ThreadIds: []string{thrd.Id},
+		Types:     []repo.BlockType{repo.FilesBlock},
+		Expired:   true,
+		Hidden:    true,
+	}
+	for _, block := range t.Blocks(query) {
+		targets = append(targets, block.Target)
+		archive.Files = append(archive.Files, t.datastore.Files().ListByTarget(block.Target)...)
+	}
+
+	manifest, err := json.Marshal(archive)
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(w)
+	if err := writeArchiveEntry(tw, threadArchiveManifest, manifest); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	if mod.Schema != "" {
+		if err := t.archiveNode(tw, mod.Schema, seen); err != nil {
+			return err
+		}
+	}
+	for _, block := range archive.Blocks {
+		if err := t.archiveNode(tw, block.Id, seen); err != nil {
+			return err
+		}
+	}
+	for _, target := range targets {
+		if err := t.archiveNode(tw, target, seen); err != nil {
+			return err
+		}
+	}
+
+	return tw.Close()
+}
+
+// ImportThread rebuilds a thread from a tar archive created by ExportThread.
+// All data is read from the archive, so no network access is needed.
+// An entry that does not resolve to its archived cid aborts the import.
+func (t *Textile) ImportThread(r io.Reader) (*Thread, error) {
+	var archive *threadArchive
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case header.Name == threadArchiveManifest:
+			archive = new(threadArchive)
+			if err := json.Unmarshal(data, archive); err != nil {
+				return nil, err
+			}
+
+		case strings.HasPrefix(header.Name, threadArchiveData):
+			id, err := ipfs.AddData(t.node, bytes.NewReader(data), true)
+			if err != nil {
+				return nil, err
+			}
+			if err := checkArchiveCid(header.Name, threadArchiveData, id.Hash().B58String()); err != nil {
+				return nil, err
+			}
+
+		case strings.HasPrefix(header.Name, threadArchiveObjects):
+			id, err := ipfs.AddObject(t.node, bytes.NewReader(data), true)
+			if err != nil {
+				return nil, err
+			}
+			if err := checkArchiveCid(header.Name, threadArchiveObjects, id.Hash().B58String()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if archive == nil || archive.Thread.Id == "" {
+		return nil, ErrInvalidThreadArchive
+	}
+
+	if t.datastore.Threads().Get(archive.Thread.Id) != nil {
+		return nil, ErrThreadLoaded
+	}
+
+	mod := archive.Thread
+	mod.State = repo.ThreadLoaded
+	if err := t.datastore.Threads().Add(&mod); err != nil {
+		return nil, err
+	}
+
+	for _, key := range archive.Keys {
+		if err := t.datastore.ThreadKeys().Add(&key); err != nil && !repo.ConflictError(err) {
+			return nil, err
+		}
+	}
+
+	self := t.node.Identity.Pretty()
+	for _, tp := range archive.Peers {
+		if tp.Id == self {
+			continue
+		}
+		if err := t.datastore.ThreadPeers().Add(&tp); err != nil && !repo.ConflictError(err) {
+			return nil, err
+		}
+		if err := t.datastore.ThreadPeers().UpdateRole(tp.Id, tp.ThreadId, tp.Role); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, block := range archive.Blocks {
+		if err := t.datastore.Blocks().Add(&block); err != nil && !repo.ConflictError(err) {
+			return nil, err
+		}
+	}
+
+	for _, file := range archive.Files {
+		if err := t.datastore.Files().Add(&file); err != nil {
+			if !repo.ConflictError(err) {
+				return nil, err
+			}
+			// exists, just add the targets
+			for _, target := range file.Targets {
+				if err := t.datastore.Files().AddTarget(file.Hash, target); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	thrd, err := t.loadThread(&mod)
+	if err != nil {
+		return nil, err
+	}
+
+	t.sendUpdate(Update{Id: thrd.Id, Name: thrd.Name, Type: ThreadAdded})
+
+	log.Debugf("imported thread %s with name %s", thrd.Id, thrd.Name)
+
+	return thrd, nil
+}
+
+// archiveNode writes an ipfs node to a thread archive, following links of non-file nodes
+func (t *Textile) archiveNode(tw *tar.Writer, id string, seen map[string]bool) error {
+	if seen[id] {
+		return nil
+	}
+	seen[id] = true
+
+	data, err := ipfs.DataAtPath(t.node, id)
+	if err == nil {
+		return writeArchiveEntry(tw, threadArchiveData+id, data)
+	}
+	if err != files.ErrNotReader {
+		return err
+	}
+
+	obj, err := ipfs.GetObjectAtPath(t.node, id)
+	if err != nil {
+		return err
+	}
+	if err := writeArchiveEntry(tw, threadArchiveObjects+id, obj); err != nil {
+		return err
+	}
+
+	links, err := ipfs.LinksAtPath(t.node, id)
+	if err != nil {
+		return err
+	}
+	for _, link := range links {
+		if err := t.archiveNode(tw, link.Cid.Hash().B58String(), seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeArchiveEntry writes a regular file to a tar archive
+func writeArchiveEntry(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0600,
+		Size:     int64(len(data)),
+		ModTime:  time.Now(),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// checkArchiveCid returns an error when a re-added archive entry resolves to a different cid
+func checkArchiveCid(name string, prefix string, resolved string) error {
+	if expected := strings.TrimPrefix(name, prefix); expected != resolved {
+		return fmt.Errorf("%s: archived %s, resolved %s", ErrArchiveCidMismatch, expected, resolved)
+	}
+	return nil
+}
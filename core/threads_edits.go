@@ -0,0 +1,65 @@
+package core
+
+import (
+	"time"
+
+	"github.com/textileio/textile-go/repo"
+)
+
+type ThreadEditInfo struct {
+	Id       string    `json:"id"`
+	Date     time.Time `json:"date"`
+	AuthorId string    `json:"author_id"`
+	Username string    `json:"username,omitempty"`
+	Body     string    `json:"body"`
+}
+
+// ThreadEdits lists the edit history of a block, causally oldest first,
+// starting with the original body of the block
+func (t *Textile) ThreadEdits(blockId string) ([]ThreadEditInfo, error) {
+	block, err := t.Block(blockId)
+	if err != nil {
+		return nil, err
+	}
+
+	edits := []ThreadEditInfo{{
+		Id:       block.Id,
+		Date:     block.Date,
+		AuthorId: block.AuthorId,
+		Username: t.ContactUsername(block.AuthorId),
+		Body:     block.Body,
+	}}
+	latest := t.blockEdits(*block)
+	for i := len(latest) - 1; i >= 0; i-- {
+		edit := latest[i]
+		edits = append(edits, ThreadEditInfo{
+			Id:       edit.Id,
+			Date:     edit.Date,
+			AuthorId: edit.AuthorId,
+			Username: t.ContactUsername(edit.AuthorId),
+			Body:     edit.Body,
+		})
+	}
+
+	return edits, nil
+}
+
+// blockEdits returns edits made to a block by its author, causally newest first,
+// since edit dates are chosen by the author's clock
+func (t *Textile) blockEdits(block repo.Block) []repo.Block {
+	return t.Blocks(&repo.BlockQuery{
+		Types:     []repo.BlockType{repo.EditBlock},
+		AuthorIds: []string{block.AuthorId},
+		Target:    "edit-" + block.Id,
+		Order:     repo.CausalOrder,
+	})
+}
+
+// latestBody returns the latest body of a block, along with the date of the last edit, if any
+func (t *Textile) latestBody(block repo.Block) (string, *time.Time) {
+	edits := t.blockEdits(block)
+	if len(edits) == 0 {
+		return block.Body, nil
+	}
+	return edits[0].Body, &edits[0].Date
+}
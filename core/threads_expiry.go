@@ -0,0 +1,80 @@
+package core
+
+import (
+	"time"
+
+	"gx/ipfs/QmPSQnBKM9g7BaUcZCvswUJVscQ1ipjmwxN5PXCjkp9EQ7/go-cid"
+
+	"github.com/textileio/textile-go/repo"
+)
+
+// kExpirySweepFreq how often to sweep expired blocks
+const kExpirySweepFreq = time.Minute
+
+// runSweeper periodically sweeps expired blocks
+func (t *Textile) runSweeper() {
+	tick := time.NewTicker(kExpirySweepFreq)
+	defer tick.Stop()
+
+	t.sweepExpired()
+
+	for {
+		select {
+		case <-tick.C:
+			t.sweepExpired()
+		case <-t.done:
+			return
+		}
+	}
+}
+
+// sweepExpired sweeps blocks that are past their expiry
+func (t *Textile) sweepExpired() {
+	now := time.Now()
+	query := &repo.BlockQuery{Due: &now, Ignored: true, Expired: true, Hidden: true}
+	for _, block := range t.datastore.Blocks().ListByQuery(query) {
+		thrd := t.Thread(block.ThreadId)
+		if thrd == nil {
+			if err := t.datastore.Blocks().Delete(block.Id); err != nil {
+				log.Errorf("error deleting expired block %s: %s", block.Id, err)
+			}
+			continue
+		}
+		if err := thrd.expireBlock(block); err != nil {
+			log.Errorf("error expiring block %s: %s", block.Id, err)
+		}
+	}
+}
+
+// TargetExpired returns whether or not a files target, or a file within one,
+// is only referenced by expired blocks. Ids that are not valid cids are never expired.
+func (t *Textile) TargetExpired(id string) bool {
+	dec, err := cid.Decode(id)
+	if err != nil {
+		return false
+	}
+	id = dec.Hash().B58String()
+
+	targets := []string{id}
+	if file := t.datastore.Files().Get(id); file != nil {
+		targets = append(targets, file.Targets...)
+	}
+
+	var found bool
+	for _, target := range targets {
+		query := &repo.BlockQuery{
+			Types:   []repo.BlockType{repo.FilesBlock},
+			Target:  target,
+			Ignored: true,
+			Hidden:  true,
+		}
+		if t.datastore.Blocks().CountByQuery(query) > 0 {
+			return false
+		}
+		query.Expired = true
+		if t.datastore.Blocks().CountByQuery(query) > 0 {
+			found = true
+		}
+	}
+	return found
+}
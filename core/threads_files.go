@@ -1,7 +1,6 @@
 package core
 
 import (
-	"fmt"
 	"strconv"
 	"time"
 
@@ -22,6 +21,9 @@ type ThreadFilesInfo struct {
 	AuthorId string              `json:"author_id"`
 	Username string              `json:"username,omitempty"`
 	Caption  string              `json:"caption,omitempty"`
+	Edited   bool                `json:"edited"`
+	EditDate *time.Time          `json:"edit_date,omitempty"`
+	Expires  *time.Time          `json:"expires,omitempty"`
 	Files    []ThreadFileInfo    `json:"files"`
 	Comments []ThreadCommentInfo `json:"comments"`
 	Likes    []ThreadLikeInfo    `json:"likes"`
@@ -29,11 +31,14 @@ type ThreadFilesInfo struct {
 }
 
 type ThreadCommentInfo struct {
-	Id       string    `json:"id"`
-	Date     time.Time `json:"date"`
-	AuthorId string    `json:"author_id"`
-	Username string    `json:"username,omitempty"`
-	Body     string    `json:"body"`
+	Id       string     `json:"id"`
+	Date     time.Time  `json:"date"`
+	AuthorId string     `json:"author_id"`
+	Username string     `json:"username,omitempty"`
+	Body     string     `json:"body"`
+	Edited   bool       `json:"edited"`
+	EditDate *time.Time `json:"edit_date,omitempty"`
+	Replies  int        `json:"replies"`
 }
 
 type ThreadLikeInfo struct {
@@ -41,22 +46,21 @@ type ThreadLikeInfo struct {
 	Date     time.Time `json:"date"`
 	AuthorId string    `json:"author_id"`
 	Username string    `json:"username,omitempty"`
+	Reaction string    `json:"reaction"`
 }
 
-func (t *Textile) ThreadFiles(offset string, limit int, threadId string) ([]ThreadFilesInfo, error) {
-	var query string
-	if threadId != "" {
-		if t.Thread(threadId) == nil {
+// ThreadFiles paginates files matching a block query, ignoring its types
+func (t *Textile) ThreadFiles(query *repo.BlockQuery) ([]ThreadFilesInfo, error) {
+	for _, id := range query.ThreadIds {
+		if t.Thread(id) == nil {
 			return nil, ErrThreadNotFound
 		}
-		query = fmt.Sprintf("threadId='%s' and type=%d", threadId, repo.FilesBlock)
-	} else {
-		query = fmt.Sprintf("type=%d", repo.FilesBlock)
 	}
+	query.Types = []repo.BlockType{repo.FilesBlock}
 
 	list := make([]ThreadFilesInfo, 0)
 
-	blocks := t.Blocks(offset, limit, query)
+	blocks := t.Blocks(query)
 	for _, block := range blocks {
 		file, err := t.threadFile(block)
 		if err != nil {
@@ -73,6 +77,9 @@ func (t *Textile) ThreadFile(blockId string) (*ThreadFilesInfo, error) {
 	if err != nil {
 		return nil, err
 	}
+	if expired(*block) {
+		return nil, ErrBlockNotFound
+	}
 
 	return t.threadFile(*block)
 }
@@ -80,8 +87,11 @@ func (t *Textile) ThreadFile(blockId string) (*ThreadFilesInfo, error) {
 func (t *Textile) ThreadComments(target string) ([]ThreadCommentInfo, error) {
 	comments := make([]ThreadCommentInfo, 0)
 
-	query := fmt.Sprintf("type=%d and target='%s'", repo.CommentBlock, target)
-	for _, block := range t.Blocks("", -1, query) {
+	query := &repo.BlockQuery{
+		Types:  []repo.BlockType{repo.CommentBlock},
+		Target: target,
+	}
+	for _, block := range t.Blocks(query) {
 		info, err := t.ThreadComment(block)
 		if err != nil {
 			continue
@@ -97,20 +107,28 @@ func (t *Textile) ThreadComment(block repo.Block) (*ThreadCommentInfo, error) {
 		return nil, ErrBlockWrongType
 	}
 
+	body, edited := t.latestBody(block)
+
 	return &ThreadCommentInfo{
 		Id:       block.Id,
 		Date:     block.Date,
 		AuthorId: block.AuthorId,
 		Username: t.ContactUsername(block.AuthorId),
-		Body:     block.Body,
+		Body:     body,
+		Edited:   edited != nil,
+		EditDate: edited,
+		Replies:  len(t.replyBlocks(block.Id)),
 	}, nil
 }
 
 func (t *Textile) ThreadLikes(target string) ([]ThreadLikeInfo, error) {
 	likes := make([]ThreadLikeInfo, 0)
 
-	query := fmt.Sprintf("type=%d and target='%s'", repo.LikeBlock, target)
-	for _, block := range t.Blocks("", -1, query) {
+	query := &repo.BlockQuery{
+		Types:  []repo.BlockType{repo.LikeBlock},
+		Target: target,
+	}
+	for _, block := range t.Blocks(query) {
 		info, err := t.ThreadLike(block)
 		if err != nil {
 			continue
@@ -131,6 +149,7 @@ func (t *Textile) ThreadLike(block repo.Block) (*ThreadLikeInfo, error) {
 		Date:     block.Date,
 		AuthorId: block.AuthorId,
 		Username: t.ContactUsername(block.AuthorId),
+		Reaction: reactionName(block.Body),
 	}, nil
 }
 
@@ -207,13 +226,18 @@ func (t *Textile) threadFile(block repo.Block) (*ThreadFilesInfo, error) {
 	threads := make([]string, 0)
 	threads = t.fileThreads(block.Target)
 
+	caption, edited := t.latestBody(block)
+
 	return &ThreadFilesInfo{
 		Block:    block.Id,
 		Target:   block.Target,
 		Date:     block.Date,
 		AuthorId: block.AuthorId,
 		Username: t.ContactUsername(block.AuthorId),
-		Caption:  block.Body,
+		Caption:  caption,
+		Edited:   edited != nil,
+		EditDate: edited,
+		Expires:  block.Expires,
 		Files:    files,
 		Comments: comments,
 		Likes:    likes,
@@ -225,7 +249,12 @@ func (t *Textile) threadFile(block repo.Block) (*ThreadFilesInfo, error) {
 func (t *Textile) fileThreads(target string) []string {
 	var unique []string
 
-	blocks := t.datastore.Blocks().List("", -1, "target='"+target+"'")
+	blocks := t.datastore.Blocks().ListByQuery(&repo.BlockQuery{
+		Target:  target,
+		Ignored: true,
+		Expired: true,
+		Hidden:  true,
+	})
 outer:
 	for _, b := range blocks {
 		for _, f := range unique {
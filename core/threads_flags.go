@@ -0,0 +1,114 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/textileio/textile-go/repo"
+)
+
+// ErrReviewNotAllowed indicates a flag review was attempted without moderation rights
+var ErrReviewNotAllowed = errors.New("not allowed to review flags in this thread")
+
+// ThreadFlagInfo is a flag added to a block
+type ThreadFlagInfo struct {
+	Id       string    `json:"id"`
+	Date     time.Time `json:"date"`
+	AuthorId string    `json:"author_id"`
+	Username string    `json:"username,omitempty"`
+	Reason   string    `json:"reason,omitempty"`
+}
+
+// ThreadFlaggedInfo is a flagged block with its reporters and reasons, newest flag first
+type ThreadFlaggedInfo struct {
+	Block BlockInfo        `json:"block"`
+	Flags []ThreadFlagInfo `json:"flags"`
+}
+
+// ThreadFlags lists the flags targeting a block, newest first
+func (t *Textile) ThreadFlags(blockId string) ([]ThreadFlagInfo, error) {
+	flags := make([]ThreadFlagInfo, 0)
+
+	query := &repo.BlockQuery{
+		Types:  []repo.BlockType{repo.FlagBlock},
+		Target: "flag-" + blockId,
+	}
+	for _, block := range t.Blocks(query) {
+		info, err := t.ThreadFlag(block)
+		if err != nil {
+			continue
+		}
+		flags = append(flags, *info)
+	}
+
+	return flags, nil
+}
+
+// ThreadFlag returns info about a flag block
+func (t *Textile) ThreadFlag(block repo.Block) (*ThreadFlagInfo, error) {
+	if block.Type != repo.FlagBlock {
+		return nil, ErrBlockWrongType
+	}
+
+	return &ThreadFlagInfo{
+		Id:       block.Id,
+		Date:     block.Date,
+		AuthorId: block.AuthorId,
+		Username: t.ContactUsername(block.AuthorId),
+		Reason:   block.Body,
+	}, nil
+}
+
+// ThreadFlagged is the moderation review queue of a thread. It lists flagged blocks,
+// including those hidden by flags, most recently flagged first.
+// Only the initiator and moderators may review flags.
+func (t *Textile) ThreadFlagged(threadId string) ([]ThreadFlaggedInfo, error) {
+	thrd := t.Thread(threadId)
+	if thrd == nil {
+		return nil, ErrThreadNotFound
+	}
+	if thrd.contactRole(t.node.Identity.Pretty()) < repo.ModeratorRole {
+		return nil, ErrReviewNotAllowed
+	}
+
+	index := make(map[string]int)
+	queue := make([]ThreadFlaggedInfo, 0)
+	query := &repo.BlockQuery{
+		ThreadIds: []string{threadId},
+		Types:     []repo.BlockType{repo.FlagBlock},
+	}
+	for _, flag := range t.Blocks(query) {
+		blockId := strings.TrimPrefix(flag.Target, "flag-")
+		i, ok := index[blockId]
+		if !ok {
+			// ignored and expired blocks no longer need review
+			block := t.datastore.Blocks().Get(blockId)
+			if block == nil || expired(*block) || thrd.ignored(blockId) {
+				index[blockId] = -1
+				continue
+			}
+			binfo, err := t.BlockInfo(blockId)
+			if err != nil {
+				return nil, err
+			}
+			i = len(queue)
+			index[blockId] = i
+			queue = append(queue, ThreadFlaggedInfo{
+				Block: *binfo,
+				Flags: make([]ThreadFlagInfo, 0),
+			})
+		}
+		if i < 0 {
+			continue
+		}
+
+		info, err := t.ThreadFlag(flag)
+		if err != nil {
+			continue
+		}
+		queue[i].Flags = append(queue[i].Flags, *info)
+	}
+
+	return queue, nil
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"time"
+)
+
+// ThreadExternalInviteInfo describes an outstanding external invite and how often it was used
+type ThreadExternalInviteInfo struct {
+	Id        string     `json:"id"`
+	ThreadId  string     `json:"thread_id"`
+	InviterId string     `json:"inviter_id"`
+	Username  string     `json:"username,omitempty"`
+	Expires   *time.Time `json:"expires,omitempty"`
+	MaxUses   int        `json:"max_uses"`
+	Uses      int        `json:"uses"`
+	Date      time.Time  `json:"date"`
+}
+
+// ThreadExternalInvites lists the external invites to a thread that are not revoked,
+// expired, or used up, newest first
+func (t *Textile) ThreadExternalInvites(threadId string) ([]ThreadExternalInviteInfo, error) {
+	thrd := t.Thread(threadId)
+	if thrd == nil {
+		return nil, ErrThreadNotFound
+	}
+
+	now := time.Now()
+	invites := make([]ThreadExternalInviteInfo, 0)
+	for _, invite := range t.datastore.ThreadExternalInvites().ListByThread(threadId) {
+		if invite.Revoked || (invite.Expires != nil && now.After(*invite.Expires)) {
+			continue
+		}
+		uses := thrd.inviteUses(invite.Id)
+		if invite.MaxUses > 0 && uses >= invite.MaxUses {
+			continue
+		}
+		invites = append(invites, ThreadExternalInviteInfo{
+			Id:        invite.Id,
+			ThreadId:  invite.ThreadId,
+			InviterId: invite.InviterId,
+			Username:  t.ContactUsername(invite.InviterId),
+			Expires:   invite.Expires,
+			MaxUses:   invite.MaxUses,
+			Uses:      uses,
+			Date:      invite.Date,
+		})
+	}
+
+	return invites, nil
+}
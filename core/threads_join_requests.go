@@ -0,0 +1,159 @@
+package core
+
+import (
+	"errors"
+	"time"
+
+	"gx/ipfs/QmTRhk7cgjUf2gfQ3p2M9KPECNZEW9XUrmHcFCgog4cPgB/go-libp2p-peer"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/segmentio/ksuid"
+	"github.com/textileio/textile-go/pb"
+	"github.com/textileio/textile-go/repo"
+)
+
+// ErrThreadJoined indicates a join request was attempted for a thread that is already joined
+var ErrThreadJoined = errors.New("thread already joined")
+
+// ThreadJoinRequestInfo describes a request to join a thread and any decision made on it
+type ThreadJoinRequestInfo struct {
+	Id        string     `json:"id"`
+	ThreadId  string     `json:"thread_id"`
+	PeerId    string     `json:"peer_id"`
+	Username  string     `json:"username,omitempty"`
+	Note      string     `json:"note,omitempty"`
+	Date      time.Time  `json:"date"`
+	Status    string     `json:"status"`
+	DeciderId string     `json:"decider_id,omitempty"`
+	Decided   *time.Time `json:"decided,omitempty"`
+}
+
+// RequestThreadJoin asks a thread admin for an invite to a thread.
+// The request is sent directly, or to the admin's cafe inboxes if known.
+func (t *Textile) RequestThreadJoin(threadId string, peerId string, note string) error {
+	if t.Thread(threadId) != nil {
+		return ErrThreadJoined
+	}
+	pid, err := peer.IDB58Decode(peerId)
+	if err != nil {
+		return err
+	}
+
+	date, err := ptypes.TimestampProto(time.Now())
+	if err != nil {
+		return err
+	}
+	msg := &pb.ThreadJoinRequest{
+		Thread:  threadId,
+		Address: t.account.Address(),
+		Note:    note,
+		Date:    date,
+	}
+	username, err := t.datastore.Profile().GetUsername()
+	if err != nil {
+		return err
+	}
+	if username != nil {
+		msg.Username = *username
+	}
+	for _, ses := range t.datastore.CafeSessions().List() {
+		msg.Inboxes = append(msg.Inboxes, ses.CafeId)
+	}
+
+	env, err := t.threadsService.NewJoinRequestEnvelope(msg)
+	if err != nil {
+		return err
+	}
+	if err := t.threadsOutbox.Add(pid, env); err != nil {
+		return err
+	}
+	go t.threadsOutbox.Flush()
+
+	// sent requests are kept so that only their recipients can deny them
+	if t.datastore.ThreadJoinRequests().GetSent(threadId, peerId) == nil {
+		if err := t.datastore.ThreadJoinRequests().Add(&repo.ThreadJoinRequest{
+			Id:       ksuid.New().String(),
+			ThreadId: threadId,
+			PeerId:   peerId,
+			Note:     note,
+			Date:     time.Now(),
+			Status:   repo.PendingJoinRequest,
+			Outgoing: true,
+		}); err != nil {
+			return err
+		}
+	}
+
+	log.Debugf("sent join request to %s for %s", peerId, threadId)
+
+	return nil
+}
+
+// ThreadJoinRequests lists the join requests to a thread, newest first.
+// Decided requests are kept as an audit trail and are only listed with all.
+func (t *Textile) ThreadJoinRequests(threadId string, all bool) ([]ThreadJoinRequestInfo, error) {
+	if t.Thread(threadId) == nil {
+		return nil, ErrThreadNotFound
+	}
+
+	reqs := make([]ThreadJoinRequestInfo, 0)
+	for _, req := range t.datastore.ThreadJoinRequests().ListByThread(threadId) {
+		if !all && req.Status != repo.PendingJoinRequest {
+			continue
+		}
+		reqs = append(reqs, t.joinRequestInfo(req))
+	}
+
+	return reqs, nil
+}
+
+// joinRequestInfo returns info for a join request
+func (t *Textile) joinRequestInfo(req repo.ThreadJoinRequest) ThreadJoinRequestInfo {
+	username := req.Username
+	if username == "" {
+		username = t.ContactUsername(req.PeerId)
+	}
+	return ThreadJoinRequestInfo{
+		Id:        req.Id,
+		ThreadId:  req.ThreadId,
+		PeerId:    req.PeerId,
+		Username:  username,
+		Note:      req.Note,
+		Date:      req.Date,
+		Status:    req.Status.Description(),
+		DeciderId: req.DeciderId,
+		Decided:   req.Decided,
+	}
+}
+
+// ApproveThreadJoinRequest approves a pending join request, inviting the requester
+func (t *Textile) ApproveThreadJoinRequest(threadId string, id string) (*ThreadJoinRequestInfo, error) {
+	thrd := t.Thread(threadId)
+	if thrd == nil {
+		return nil, ErrThreadNotFound
+	}
+
+	req, err := thrd.ApproveJoinRequest(id)
+	if err != nil {
+		return nil, err
+	}
+
+	info := t.joinRequestInfo(*req)
+	return &info, nil
+}
+
+// DenyThreadJoinRequest denies a pending join request
+func (t *Textile) DenyThreadJoinRequest(threadId string, id string, note string) (*ThreadJoinRequestInfo, error) {
+	thrd := t.Thread(threadId)
+	if thrd == nil {
+		return nil, ErrThreadNotFound
+	}
+
+	req, err := thrd.DenyJoinRequest(id, note)
+	if err != nil {
+		return nil, err
+	}
+
+	info := t.joinRequestInfo(*req)
+	return &info, nil
+}
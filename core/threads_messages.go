@@ -1,34 +1,37 @@
 package core
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/textileio/textile-go/repo"
 )
 
 type ThreadMessageInfo struct {
-	Id       string    `json:"id"`
-	Date     time.Time `json:"date"`
-	AuthorId string    `json:"author_id"`
-	Username string    `json:"username,omitempty"`
-	Body     string    `json:"body"`
+	Id       string     `json:"id"`
+	Date     time.Time  `json:"date"`
+	AuthorId string     `json:"author_id"`
+	Username string     `json:"username,omitempty"`
+	Body     string     `json:"body"`
+	Edited   bool       `json:"edited"`
+	EditDate *time.Time `json:"edit_date,omitempty"`
+	Expires  *time.Time `json:"expires,omitempty"`
+	ReplyTo  string     `json:"reply_to,omitempty"`
+	Replies  int        `json:"replies"`
+	SeenBy   []string   `json:"seen_by"`
 }
 
-func (t *Textile) ThreadMessages(offset string, limit int, threadId string) ([]ThreadMessageInfo, error) {
-	var query string
-	if threadId != "" {
-		if t.Thread(threadId) == nil {
+// ThreadMessages paginates messages matching a block query, ignoring its types
+func (t *Textile) ThreadMessages(query *repo.BlockQuery) ([]ThreadMessageInfo, error) {
+	for _, id := range query.ThreadIds {
+		if t.Thread(id) == nil {
 			return nil, ErrThreadNotFound
 		}
-		query = fmt.Sprintf("threadId='%s' and type=%d", threadId, repo.MessageBlock)
-	} else {
-		query = fmt.Sprintf("type=%d", repo.MessageBlock)
 	}
+	query.Types = []repo.BlockType{repo.MessageBlock}
 
 	list := make([]ThreadMessageInfo, 0)
 
-	blocks := t.Blocks(offset, limit, query)
+	blocks := t.Blocks(query)
 	for _, block := range blocks {
 		msg, err := t.ThreadMessage(block)
 		if err != nil {
@@ -45,11 +48,19 @@ func (t *Textile) ThreadMessage(block repo.Block) (*ThreadMessageInfo, error) {
 		return nil, ErrBlockWrongType
 	}
 
+	body, edited := t.latestBody(block)
+
 	return &ThreadMessageInfo{
 		Id:       block.Id,
 		Date:     block.Date,
 		AuthorId: block.AuthorId,
 		Username: t.ContactUsername(block.AuthorId),
-		Body:     block.Body,
+		Body:     body,
+		Edited:   edited != nil,
+		EditDate: edited,
+		Expires:  block.Expires,
+		ReplyTo:  block.Target,
+		Replies:  len(t.replyBlocks(block.Id)),
+		SeenBy:   t.seenBy(block),
 	}, nil
 }
@@ -8,6 +8,7 @@ import (
 	"gx/ipfs/QmTRhk7cgjUf2gfQ3p2M9KPECNZEW9XUrmHcFCgog4cPgB/go-libp2p-peer"
 	"gx/ipfs/QmUJYo4etAQqFfSS2rarFAE97eNGB8ej64YkRT2SmsYD4r/go-ipfs/core"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/segmentio/ksuid"
 	"github.com/textileio/textile-go/pb"
 	"github.com/textileio/textile-go/repo"
@@ -53,6 +54,26 @@ func (q *ThreadsOutbox) Add(pid peer.ID, env *pb.Envelope) error {
 	})
 }
 
+// RemoveByThread removes pending messages for a peer in a thread
+func (q *ThreadsOutbox) RemoveByThread(pid peer.ID, threadId string) error {
+	for _, msg := range q.datastore.ThreadMessages().ListByPeer(pid.Pretty()) {
+		if msg.Envelope.Message.Type != pb.Message_THREAD_ENVELOPE {
+			continue
+		}
+		tenv := new(pb.ThreadEnvelope)
+		if err := ptypes.UnmarshalAny(msg.Envelope.Message.Payload, tenv); err != nil {
+			return err
+		}
+		if tenv.Thread != threadId {
+			continue
+		}
+		if err := q.datastore.ThreadMessages().Delete(msg.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Flush processes pending messages
 func (q *ThreadsOutbox) Flush() {
 	q.mux.Lock()
@@ -0,0 +1,44 @@
+package core
+
+import "sort"
+
+type ThreadReactionInfo struct {
+	Reaction  string   `json:"reaction"`
+	Count     int      `json:"count"`
+	AuthorIds []string `json:"author_ids"`
+	Reacted   bool     `json:"reacted"`
+}
+
+// ThreadReactions aggregates the likes targeting a block by reaction, most popular first
+func (t *Textile) ThreadReactions(target string) ([]ThreadReactionInfo, error) {
+	likes, err := t.ThreadLikes(target)
+	if err != nil {
+		return nil, err
+	}
+
+	self := t.node.Identity.Pretty()
+	index := make(map[string]int)
+	reactions := make([]ThreadReactionInfo, 0)
+	for _, like := range likes {
+		i, ok := index[like.Reaction]
+		if !ok {
+			i = len(reactions)
+			index[like.Reaction] = i
+			reactions = append(reactions, ThreadReactionInfo{
+				Reaction:  like.Reaction,
+				AuthorIds: make([]string, 0),
+			})
+		}
+		reactions[i].Count++
+		reactions[i].AuthorIds = append(reactions[i].AuthorIds, like.AuthorId)
+		if like.AuthorId == self {
+			reactions[i].Reacted = true
+		}
+	}
+
+	sort.SliceStable(reactions, func(i, j int) bool {
+		return reactions[i].Count > reactions[j].Count
+	})
+
+	return reactions, nil
+}
@@ -0,0 +1,43 @@
+package core
+
+import (
+	"github.com/textileio/textile-go/repo"
+)
+
+// ThreadReceipts lists the newest block read by each peer in a thread, newest first
+func (t *Textile) ThreadReceipts(threadId string) ([]ThreadReceiptInfo, error) {
+	if t.Thread(threadId) == nil {
+		return nil, ErrThreadNotFound
+	}
+
+	receipts := make([]ThreadReceiptInfo, 0)
+	for _, receipt := range t.datastore.ThreadReceipts().ListByThread(threadId) {
+		receipts = append(receipts, ThreadReceiptInfo{
+			PeerId:   receipt.PeerId,
+			Username: t.ContactUsername(receipt.PeerId),
+			BlockId:  receipt.BlockId,
+			Date:     receipt.Date,
+		})
+	}
+
+	return receipts, nil
+}
+
+// seenBy returns the peers, other than the author, who have read up to or past a block
+func (t *Textile) seenBy(block repo.Block) []string {
+	seen := make([]string, 0)
+	for _, receipt := range t.datastore.ThreadReceipts().ListByThread(block.ThreadId) {
+		if receipt.PeerId == block.AuthorId {
+			continue
+		}
+		if receipt.BlockId == block.Id {
+			seen = append(seen, receipt.PeerId)
+			continue
+		}
+		read := t.datastore.Blocks().Get(receipt.BlockId)
+		if read != nil && !read.Date.Before(block.Date) {
+			seen = append(seen, receipt.PeerId)
+		}
+	}
+	return seen
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"time"
+
+	"github.com/textileio/textile-go/repo"
+)
+
+type ThreadReplyInfo struct {
+	Id       string            `json:"id"`
+	Type     string            `json:"type"`
+	Date     time.Time         `json:"date"`
+	AuthorId string            `json:"author_id"`
+	Username string            `json:"username,omitempty"`
+	Body     string            `json:"body"`
+	Edited   bool              `json:"edited"`
+	EditDate *time.Time        `json:"edit_date,omitempty"`
+	Replies  []ThreadReplyInfo `json:"replies"`
+}
+
+// ThreadReplies returns the conversation subtree rooted at a message or comment
+func (t *Textile) ThreadReplies(blockId string) (*ThreadReplyInfo, error) {
+	block, err := t.Block(blockId)
+	if err != nil {
+		return nil, err
+	}
+	if !repliable(block.Type) {
+		return nil, ErrBlockWrongType
+	}
+
+	return t.threadReply(*block), nil
+}
+
+// threadReply builds a reply info with all of its replies, oldest first
+func (t *Textile) threadReply(block repo.Block) *ThreadReplyInfo {
+	body, edited := t.latestBody(block)
+
+	info := &ThreadReplyInfo{
+		Id:       block.Id,
+		Type:     block.Type.Description(),
+		Date:     block.Date,
+		AuthorId: block.AuthorId,
+		Username: t.ContactUsername(block.AuthorId),
+		Body:     body,
+		Edited:   edited != nil,
+		EditDate: edited,
+		Replies:  make([]ThreadReplyInfo, 0),
+	}
+
+	replies := t.replyBlocks(block.Id)
+	for i := len(replies) - 1; i >= 0; i-- {
+		info.Replies = append(info.Replies, *t.threadReply(replies[i]))
+	}
+
+	return info
+}
+
+// replyBlocks returns the messages and comments targeting a block, newest first
+func (t *Textile) replyBlocks(blockId string) []repo.Block {
+	return t.Blocks(&repo.BlockQuery{
+		Types:  []repo.BlockType{repo.MessageBlock, repo.CommentBlock},
+		Target: blockId,
+	})
+}
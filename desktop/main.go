@@ -137,6 +137,12 @@ func start(a *astilectron.Astilectron, w []*astilectron.Window, _ *astilectron.M
 				if !ok {
 					return
 				}
+				if signal, ok := update.(core.ThreadSignalInfo); ok {
+					sendData("thread.signal", map[string]interface{}{
+						"signal": signal,
+					})
+					break
+				}
 				sendData("thread.update", map[string]interface{}{
 					"update": update,
 				})
@@ -265,6 +271,19 @@ func handleMessage(_ *astilectron.Window, m bootstrap.MessageIn) (interface{}, e
 		return map[string]interface{}{
 			"html": html,
 		}, nil
+	case "thread.invite":
+		var threadId string
+		if err := json.Unmarshal(m.Payload, &threadId); err != nil {
+			return nil, err
+		}
+		uri, qr, err := getInviteQRCode(threadId)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"uri": uri,
+			"qr":  qr,
+		}, nil
 	default:
 		return map[string]interface{}{}, nil
 	}
@@ -287,14 +306,43 @@ func getQRCode() (string, string, error) {
 	return base64.StdEncoding.EncodeToString(png), pid.Pretty(), nil
 }
 
+func getInviteQRCode(threadId string) (string, string, error) {
+	thrd := node.Thread(threadId)
+	if thrd == nil {
+		return "", "", core.ErrThreadNotFound
+	}
+
+	// create an external invite link
+	hash, key, err := thrd.AddExternalInvite()
+	if err != nil {
+		return "", "", err
+	}
+	link, err := thrd.InviteLink(hash, key)
+	if err != nil {
+		return "", "", err
+	}
+
+	// create a qr code
+	uri := core.InviteURI(link)
+	png, err := qrcode.Encode(uri, qrcode.Medium, QRCodeSize)
+	if err != nil {
+		return "", "", err
+	}
+
+	return uri, base64.StdEncoding.EncodeToString(png), nil
+}
+
 func getThreadPhotos(id string) (string, error) {
 	thrd := node.Thread(id)
 	if thrd == nil {
 		return "", core.ErrThreadNotFound
 	}
 	var html string
-	query := fmt.Sprintf("threadId='%s' and type=%d", thrd.Id, repo.FilesBlock)
-	for range node.Blocks("", -1, query) {
+	query := &repo.BlockQuery{
+		ThreadIds: []string{thrd.Id},
+		Types:     []repo.BlockType{repo.FilesBlock},
+	}
+	for range node.Blocks(query) {
 		//photo := fmt.Sprintf("%s/ipfs/%s/photo?block=%s", gatewayAddr, block.DataId, block.Id)
 		//small := fmt.Sprintf("%s/ipfs/%s/small?block=%s", gatewayAddr, block.DataId, block.Id)
 		//meta := fmt.Sprintf("%s/ipfs/%s/meta?block=%s", gatewayAddr, block.DataId, block.Id)
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"gx/ipfs/QmPSQnBKM9g7BaUcZCvswUJVscQ1ipjmwxN5PXCjkp9EQ7/go-cid"
 	ipld "gx/ipfs/QmR7TcHkR9nxkUorfi8XMTAMLUK7GiP64TWWBzY3aacc1o/go-ipld-format"
 	"gx/ipfs/QmTRhk7cgjUf2gfQ3p2M9KPECNZEW9XUrmHcFCgog4cPgB/go-libp2p-peer"
 	"gx/ipfs/QmUJYo4etAQqFfSS2rarFAE97eNGB8ej64YkRT2SmsYD4r/go-ipfs/core/coreapi/interface"
@@ -123,7 +124,17 @@ func (g *Gateway) Addr() string {
 
 // gatewayHandler handles gateway http requests
 func (g *Gateway) gatewayHandler(c *gin.Context) {
-	contentPath := c.Param("root") + c.Param("path")
+	root := c.Param("root")
+	if _, err := cid.Decode(root); err != nil {
+		render404(c)
+		return
+	}
+	contentPath := root + c.Param("path")
+
+	if g.Node.TargetExpired(root) {
+		render404(c)
+		return
+	}
 
 	data := g.getDataAtPath(c, contentPath)
 
@@ -161,6 +161,15 @@ func AddObject(node *core.IpfsNode, reader io.Reader, pin bool) (*cid.Cid, error
 	return &id, nil
 }
 
+// HasBlock returns whether or not a block is in the local blockstore, without fetching it
+func HasBlock(node *core.IpfsNode, id string) (bool, error) {
+	dec, err := cid.Decode(id)
+	if err != nil {
+		return false, err
+	}
+	return node.Blockstore.Has(dec)
+}
+
 // NodeAtLink returns the node behind an ipld link
 func NodeAtLink(node *core.IpfsNode, link *ipld.Link) (ipld.Node, error) {
 	ctx, cancel := context.WithTimeout(node.Context(), catTimeout)
@@ -25,3 +25,28 @@ func (m *Mobile) AddThreadComment(blockId string, body string) (string, error) {
 
 	return hash.B58String(), nil
 }
+
+// AddThreadCommentReply adds a comment targeted at the given block, in reply to
+// a message or comment about it
+func (m *Mobile) AddThreadCommentReply(blockId string, replyTo string, body string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	block, err := m.node.Block(blockId)
+	if err != nil {
+		return "", err
+	}
+
+	thrd := m.node.Thread(block.ThreadId)
+	if thrd == nil {
+		return "", core.ErrThreadNotFound
+	}
+
+	hash, err := thrd.AddCommentReply(block.Id, replyTo, body)
+	if err != nil {
+		return "", err
+	}
+
+	return hash.B58String(), nil
+}
@@ -0,0 +1,41 @@
+package mobile
+
+import "github.com/textileio/textile-go/core"
+
+// AddThreadEdit replaces the body of a message, comment, or files block
+func (m *Mobile) AddThreadEdit(blockId string, body string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	block, err := m.node.Block(blockId)
+	if err != nil {
+		return "", err
+	}
+
+	thrd := m.node.Thread(block.ThreadId)
+	if thrd == nil {
+		return "", core.ErrThreadNotFound
+	}
+
+	hash, err := thrd.AddEdit(block.Id, body)
+	if err != nil {
+		return "", err
+	}
+
+	return hash.B58String(), nil
+}
+
+// ThreadEdits calls core ThreadEdits
+func (m *Mobile) ThreadEdits(blockId string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	edits, err := m.node.ThreadEdits(blockId)
+	if err != nil {
+		return "", err
+	}
+
+	return toJSON(edits)
+}
@@ -263,7 +263,15 @@ func (m *Mobile) ThreadFiles(offset string, limit int, threadId string) (string,
 		return "", core.ErrStopped
 	}
 
-	files, err := m.node.ThreadFiles(offset, limit, threadId)
+	query := &repo.BlockQuery{
+		Offset: offset,
+		Limit:  limit,
+	}
+	if threadId != "" {
+		query.ThreadIds = []string{threadId}
+	}
+
+	files, err := m.node.ThreadFiles(query)
 	if err != nil {
 		return "", err
 	}
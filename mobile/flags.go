@@ -0,0 +1,105 @@
+package mobile
+
+import "github.com/textileio/textile-go/core"
+
+// AddThreadFlag adds a flag targeted at the given block, with an optional reason code,
+// e.g., SPAM, ABUSE, INAPPROPRIATE, COPYRIGHT, or OTHER
+func (m *Mobile) AddThreadFlag(blockId string, reason string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	code, err := core.FlagReasonFromString(reason)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := m.node.Block(blockId)
+	if err != nil {
+		return "", err
+	}
+
+	thrd := m.node.Thread(block.ThreadId)
+	if thrd == nil {
+		return "", core.ErrThreadNotFound
+	}
+
+	hash, err := thrd.AddFlag(block.Id, code)
+	if err != nil {
+		return "", err
+	}
+
+	return hash.B58String(), nil
+}
+
+// RemoveThreadFlag undoes your flag of the given block
+func (m *Mobile) RemoveThreadFlag(blockId string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	block, err := m.node.Block(blockId)
+	if err != nil {
+		return "", err
+	}
+
+	thrd := m.node.Thread(block.ThreadId)
+	if thrd == nil {
+		return "", core.ErrThreadNotFound
+	}
+
+	hash, err := thrd.RemoveFlag(block.Id)
+	if err != nil {
+		return "", err
+	}
+
+	return hash.B58String(), nil
+}
+
+// ThreadFlags calls core ThreadFlags
+func (m *Mobile) ThreadFlags(blockId string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	flags, err := m.node.ThreadFlags(blockId)
+	if err != nil {
+		return "", err
+	}
+
+	return toJSON(flags)
+}
+
+// ThreadFlagged calls core ThreadFlagged, which lists flagged blocks awaiting review
+func (m *Mobile) ThreadFlagged(threadId string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	queue, err := m.node.ThreadFlagged(threadId)
+	if err != nil {
+		return "", err
+	}
+
+	return toJSON(queue)
+}
+
+// SetThreadFlagThreshold calls thread SetFlagThreshold, which sets the number of flags
+// from distinct peers that hide a block
+func (m *Mobile) SetThreadFlagThreshold(threadId string, threshold int) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	thrd := m.node.Thread(threadId)
+	if thrd == nil {
+		return "", core.ErrThreadNotFound
+	}
+
+	hash, err := thrd.SetFlagThreshold(threshold)
+	if err != nil {
+		return "", err
+	}
+
+	return hash.B58String(), nil
+}
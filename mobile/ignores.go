@@ -25,3 +25,27 @@ func (m *Mobile) AddThreadIgnore(blockId string) (string, error) {
 
 	return hash.B58String(), nil
 }
+
+// RestoreThreadBlock undoes your ignore of the given block and restores its target data
+func (m *Mobile) RestoreThreadBlock(blockId string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	block, err := m.node.Block(blockId)
+	if err != nil {
+		return "", err
+	}
+
+	thrd := m.node.Thread(block.ThreadId)
+	if thrd == nil {
+		return "", core.ErrThreadNotFound
+	}
+
+	hash, err := thrd.RestoreIgnored(block.Id)
+	if err != nil {
+		return "", err
+	}
+
+	return hash.B58String(), nil
+}
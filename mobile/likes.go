@@ -25,3 +25,89 @@ func (m *Mobile) AddThreadLike(blockId string) (string, error) {
 
 	return hash.B58String(), nil
 }
+
+// RemoveThreadLike undoes your like of the given block
+func (m *Mobile) RemoveThreadLike(blockId string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	block, err := m.node.Block(blockId)
+	if err != nil {
+		return "", err
+	}
+
+	thrd := m.node.Thread(block.ThreadId)
+	if thrd == nil {
+		return "", core.ErrThreadNotFound
+	}
+
+	hash, err := thrd.RemoveLike(block.Id)
+	if err != nil {
+		return "", err
+	}
+
+	return hash.B58String(), nil
+}
+
+// AddThreadReaction adds an emoji or short code reaction targeted at the given block
+func (m *Mobile) AddThreadReaction(blockId string, reaction string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	block, err := m.node.Block(blockId)
+	if err != nil {
+		return "", err
+	}
+
+	thrd := m.node.Thread(block.ThreadId)
+	if thrd == nil {
+		return "", core.ErrThreadNotFound
+	}
+
+	hash, err := thrd.AddReaction(block.Id, reaction)
+	if err != nil {
+		return "", err
+	}
+
+	return hash.B58String(), nil
+}
+
+// RemoveThreadReaction removes a reaction from the given block
+func (m *Mobile) RemoveThreadReaction(blockId string, reaction string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	block, err := m.node.Block(blockId)
+	if err != nil {
+		return "", err
+	}
+
+	thrd := m.node.Thread(block.ThreadId)
+	if thrd == nil {
+		return "", core.ErrThreadNotFound
+	}
+
+	hash, err := thrd.RemoveReaction(block.Id, reaction)
+	if err != nil {
+		return "", err
+	}
+
+	return hash.B58String(), nil
+}
+
+// ThreadReactions calls core ThreadReactions
+func (m *Mobile) ThreadReactions(blockId string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	reactions, err := m.node.ThreadReactions(blockId)
+	if err != nil {
+		return "", err
+	}
+
+	return toJSON(reactions)
+}
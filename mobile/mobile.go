@@ -194,7 +194,12 @@ func (m *Mobile) Start() error {
 						return
 					}
 					payload, err := toJSON(update)
-					if err == nil {
+					if err != nil {
+						break
+					}
+					if _, ok := update.(core.ThreadSignalInfo); ok {
+						m.messenger.Notify(&Event{Name: "onThreadSignal", Payload: payload})
+					} else {
 						m.messenger.Notify(&Event{Name: "onThreadUpdate", Payload: payload})
 					}
 				}
@@ -12,6 +12,7 @@ import (
 	"gx/ipfs/QmTRhk7cgjUf2gfQ3p2M9KPECNZEW9XUrmHcFCgog4cPgB/go-libp2p-peer"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/mr-tron/base58/base58"
 	"github.com/segmentio/ksuid"
 	"github.com/textileio/textile-go/core"
 	. "github.com/textileio/textile-go/mobile"
@@ -37,6 +38,7 @@ func (tc *TestCallback) Call(payload []byte, err error) {
 
 var repoPath1 = "testdata/.textile1"
 var repoPath2 = "testdata/.textile2"
+var archivePath = "testdata/thread.tar"
 
 var recovery string
 var seed string
@@ -45,10 +47,13 @@ var mobile1 *Mobile
 var mobile2 *Mobile
 
 var thrdId string
+var peerId string
 var dir []byte
 var filesBlock core.BlockInfo
+var targetBlock core.BlockInfo
 var files []core.ThreadFilesInfo
 var invite ExternalInvite
+var archiveId string
 
 func TestNewWallet(t *testing.T) {
 	var err error
@@ -173,6 +178,36 @@ func TestMobile_AddPeerToThread(t *testing.T) {
 		t.Errorf("add peer to thread failed: %s", err)
 		return
 	}
+	peerId = id.Pretty()
+}
+
+func TestMobile_SetThreadPeerRole(t *testing.T) {
+	if _, err := mobile1.SetThreadPeerRole(peerId, thrdId, "moderator"); err != nil {
+		t.Errorf("set thread peer role failed: %s", err)
+		return
+	}
+	if _, err := mobile1.SetThreadPeerRole(peerId, thrdId, "boss"); err == nil {
+		t.Error("set thread peer role with bad role should fail")
+	}
+}
+
+func TestMobile_RemovePeerFromThread(t *testing.T) {
+	if _, err := mobile1.RemovePeerFromThread(peerId, thrdId); err != nil {
+		t.Errorf("remove peer from thread failed: %s", err)
+		return
+	}
+	if _, err := mobile1.RemovePeerFromThread(peerId, thrdId); err != core.ErrPeerNotFound {
+		t.Error("remove peer from thread again should fail")
+	}
+}
+
+func TestMobile_UpdateThread(t *testing.T) {
+	if _, err := mobile1.UpdateThread(thrdId, "", "", "", 0); err != core.ErrMissingThreadName {
+		t.Error("update thread without a name should fail")
+	}
+	if _, err := mobile1.UpdateThread(thrdId, "renamed", "our stuff", "", 0); err != nil {
+		t.Errorf("update thread failed: %s", err)
+	}
 }
 
 func TestMobile_Threads(t *testing.T) {
@@ -188,6 +223,10 @@ func TestMobile_Threads(t *testing.T) {
 	}
 	if len(threads) != 1 {
 		t.Error("get threads bad result")
+		return
+	}
+	if threads[0].Name != "renamed" || threads[0].Description != "our stuff" {
+		t.Error("get threads bad meta")
 	}
 }
 
@@ -273,10 +312,11 @@ func TestMobile_AddThreadFilesByTarget(t *testing.T) {
 		t.Errorf("add thread files by target failed: %s", err)
 		return
 	}
-	info := &core.BlockInfo{}
+	info := core.BlockInfo{}
 	if err := json.Unmarshal([]byte(res), &info); err != nil {
 		t.Error(err)
 	}
+	targetBlock = info
 }
 
 func TestMobile_AddThreadComment(t *testing.T) {
@@ -291,6 +331,144 @@ func TestMobile_AddThreadLike(t *testing.T) {
 	}
 }
 
+func TestMobile_AddThreadReaction(t *testing.T) {
+	if _, err := mobile1.AddThreadReaction(filesBlock.Id, "🔥"); err != nil {
+		t.Errorf("add thread reaction failed: %s", err)
+		return
+	}
+	if _, err := mobile1.AddThreadReaction(filesBlock.Id, "🔥"); err == nil {
+		t.Error("add same thread reaction again should fail")
+	}
+	res, err := mobile1.ThreadReactions(filesBlock.Id)
+	if err != nil {
+		t.Errorf("get thread reactions failed: %s", err)
+		return
+	}
+	var reactions []core.ThreadReactionInfo
+	if err := json.Unmarshal([]byte(res), &reactions); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(reactions) != 2 {
+		t.Errorf("get thread reactions bad result")
+	}
+}
+
+func TestMobile_RemoveThreadReaction(t *testing.T) {
+	if _, err := mobile1.RemoveThreadReaction(filesBlock.Id, "🔥"); err != nil {
+		t.Errorf("remove thread reaction failed: %s", err)
+		return
+	}
+	res, err := mobile1.ThreadReactions(filesBlock.Id)
+	if err != nil {
+		t.Errorf("get thread reactions failed: %s", err)
+		return
+	}
+	var reactions []core.ThreadReactionInfo
+	if err := json.Unmarshal([]byte(res), &reactions); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(reactions) != 1 || reactions[0].Reaction != core.DefaultReaction {
+		t.Errorf("get thread reactions bad result")
+	}
+}
+
+func TestMobile_RemoveThreadLike(t *testing.T) {
+	if _, err := mobile1.RemoveThreadLike(filesBlock.Id); err != nil {
+		t.Errorf("remove thread like failed: %s", err)
+		return
+	}
+	if _, err := mobile1.RemoveThreadLike(filesBlock.Id); err != core.ErrReactionNotFound {
+		t.Error("remove thread like again should fail")
+	}
+	res, err := mobile1.ThreadReactions(filesBlock.Id)
+	if err != nil {
+		t.Errorf("get thread reactions failed: %s", err)
+		return
+	}
+	var reactions []core.ThreadReactionInfo
+	if err := json.Unmarshal([]byte(res), &reactions); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(reactions) != 0 {
+		t.Errorf("remove thread like bad result: %s", res)
+	}
+}
+
+func TestMobile_AddThreadReply(t *testing.T) {
+	// comment on the second files block, leaving the first block's comments alone
+	comment, err := mobile1.AddThreadComment(targetBlock.Id, "nice")
+	if err != nil {
+		t.Errorf("add thread comment failed: %s", err)
+		return
+	}
+	if _, err := mobile1.AddThreadReply(comment, "thanks"); err != nil {
+		t.Errorf("add thread reply failed: %s", err)
+		return
+	}
+	if _, err := mobile1.AddThreadReply(filesBlock.Id, "nope"); err == nil {
+		t.Error("reply to files block should fail")
+	}
+	if _, err := mobile1.AddThreadCommentReply(targetBlock.Id, comment, "agreed"); err != nil {
+		t.Errorf("add thread comment reply failed: %s", err)
+		return
+	}
+	res, err := mobile1.ThreadReplies(comment)
+	if err != nil {
+		t.Errorf("get thread replies failed: %s", err)
+		return
+	}
+	var info core.ThreadReplyInfo
+	if err := json.Unmarshal([]byte(res), &info); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(info.Replies) != 2 || info.Replies[0].Body != "thanks" || info.Replies[1].Body != "agreed" {
+		t.Errorf("get thread replies bad result")
+	}
+}
+
+func TestMobile_AddThreadEdit(t *testing.T) {
+	if _, err := mobile1.AddThreadEdit(filesBlock.Id, "edited"); err != nil {
+		t.Errorf("add thread edit failed: %s", err)
+		return
+	}
+	res, err := mobile1.ThreadEdits(filesBlock.Id)
+	if err != nil {
+		t.Errorf("get thread edits failed: %s", err)
+		return
+	}
+	var edits []core.ThreadEditInfo
+	if err := json.Unmarshal([]byte(res), &edits); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(edits) != 2 || edits[0].Body != "hello" || edits[1].Body != "edited" {
+		t.Errorf("get thread edits bad result")
+	}
+}
+
+func TestMobile_Search(t *testing.T) {
+	res, err := mobile1.Search("edited", thrdId, "", "FILES", 0, 0, 10)
+	if err != nil {
+		t.Errorf("search failed: %s", err)
+		return
+	}
+	var results []core.SearchResultInfo
+	if err := json.Unmarshal([]byte(res), &results); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(results) != 1 || results[0].Block.Id != filesBlock.Id {
+		t.Errorf("search bad result: %s", res)
+	}
+	if _, err := mobile1.Search("edited", thrdId, "", "BOGUS", 0, 0, 10); err == nil {
+		t.Error("search with bad block type should fail")
+	}
+}
+
 func TestMobile_ThreadFiles(t *testing.T) {
 	res, err := mobile1.ThreadFiles("", -1, thrdId)
 	if err != nil {
@@ -312,6 +490,117 @@ func TestMobile_ThreadFiles(t *testing.T) {
 	}
 }
 
+func TestMobile_VerifyThread(t *testing.T) {
+	res, err := mobile1.VerifyThread(thrdId, false)
+	if err != nil {
+		t.Errorf("verify thread failed: %s", err)
+		return
+	}
+	var result core.ThreadVerifyResult
+	if err := json.Unmarshal([]byte(res), &result); err != nil {
+		t.Error(err)
+		return
+	}
+	if !result.Ok() || result.BlockCount == 0 {
+		t.Errorf("verify thread bad result: %s", res)
+	}
+}
+
+func TestMobile_AddThreadCheckpoint(t *testing.T) {
+	res, err := mobile1.AddThreadCheckpoint(thrdId)
+	if err != nil {
+		t.Errorf("add thread checkpoint failed: %s", err)
+		return
+	}
+	if res == "" {
+		t.Error("add thread checkpoint bad result")
+	}
+}
+
+func TestMobile_LoadThreadHistory(t *testing.T) {
+	res, err := mobile1.LoadThreadHistory(thrdId)
+	if err != nil {
+		t.Errorf("load thread history failed: %s", err)
+		return
+	}
+	var info core.ThreadInfo
+	if err := json.Unmarshal([]byte(res), &info); err != nil {
+		t.Error(err)
+		return
+	}
+	if info.State != "LOADED" {
+		t.Errorf("load thread history bad result: %s", res)
+	}
+}
+
+func TestMobile_MarkThreadRead(t *testing.T) {
+	if _, err := mobile1.MarkThreadRead(thrdId, filesBlock.Id); err != nil {
+		t.Errorf("mark thread read failed: %s", err)
+		return
+	}
+	res, err := mobile1.ThreadReceipts(thrdId)
+	if err != nil {
+		t.Errorf("get thread receipts failed: %s", err)
+		return
+	}
+	var receipts []core.ThreadReceiptInfo
+	if err := json.Unmarshal([]byte(res), &receipts); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(receipts) != 1 || receipts[0].BlockId != filesBlock.Id {
+		t.Errorf("get thread receipts bad result: %s", res)
+	}
+}
+
+func TestMobile_SetThreadReceiptsOff(t *testing.T) {
+	if err := mobile1.SetThreadReceiptsOff(thrdId, true); err != nil {
+		t.Errorf("set thread receipts off failed: %s", err)
+		return
+	}
+	if _, err := mobile1.MarkThreadRead(thrdId, filesBlock.Id); err != core.ErrReceiptsOff {
+		t.Error("mark thread read should fail with receipts off")
+	}
+	if err := mobile1.SetThreadReceiptsOff(thrdId, false); err != nil {
+		t.Errorf("set thread receipts on failed: %s", err)
+	}
+}
+
+func TestMobile_SendThreadSignal(t *testing.T) {
+	<-mobile1.OnlineCh()
+	if err := mobile1.SendThreadSignal(thrdId, "typing", ""); err != nil {
+		t.Errorf("send thread signal failed: %s", err)
+	}
+	if err := mobile1.SendThreadSignal(thrdId, "bogus", ""); err != core.ErrInvalidSignalType {
+		t.Error("send thread signal with bad type should fail")
+	}
+}
+
+func TestMobile_RequestThreadJoin(t *testing.T) {
+	if err := mobile1.RequestThreadJoin(thrdId, "QmWAtE7YoBq1MvAC5NLCFeSGvnGMoZ6Z7EHMwsqWMShU9S", ""); err != core.ErrThreadJoined {
+		t.Error("request to join a joined thread should fail")
+	}
+}
+
+func TestMobile_ThreadJoinRequests(t *testing.T) {
+	res, err := mobile1.ThreadJoinRequests(thrdId, true)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var list []core.ThreadJoinRequestInfo
+	if err := json.Unmarshal([]byte(res), &list); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(list) != 0 {
+		t.Errorf("bad join requests result: %s", res)
+	}
+	if _, err := mobile1.ApproveThreadJoinRequest(thrdId, "bogus"); err != core.ErrJoinRequestNotFound {
+		t.Error("approve unknown join request should fail")
+	}
+}
+
 func TestMobile_ThreadFilesBadThread(t *testing.T) {
 	if _, err := mobile1.ThreadFiles("", -1, "empty"); err == nil {
 		t.Error("get thread files from bad thread should fail")
@@ -329,6 +618,124 @@ func TestMobile_FileData(t *testing.T) {
 	}
 }
 
+func TestMobile_AddThreadFlag(t *testing.T) {
+	comment, err := mobile1.AddThreadComment(filesBlock.Id, "buy now")
+	if err != nil {
+		t.Errorf("add thread comment failed: %s", err)
+		return
+	}
+	if _, err := mobile1.AddThreadFlag(comment, "bogus"); err != core.ErrInvalidFlagReason {
+		t.Error("add thread flag with bad reason should fail")
+	}
+	if _, err := mobile1.AddThreadFlag(comment, "spam"); err != nil {
+		t.Errorf("add thread flag failed: %s", err)
+		return
+	}
+	if _, err := mobile1.AddThreadFlag(comment, "spam"); err != core.ErrFlagExists {
+		t.Error("add thread flag again should fail")
+	}
+	res, err := mobile1.ThreadFlags(comment)
+	if err != nil {
+		t.Errorf("get thread flags failed: %s", err)
+		return
+	}
+	var flags []core.ThreadFlagInfo
+	if err := json.Unmarshal([]byte(res), &flags); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(flags) != 1 || flags[0].Reason != "SPAM" {
+		t.Errorf("get thread flags bad result: %s", res)
+	}
+}
+
+func TestMobile_ThreadFlagged(t *testing.T) {
+	res, err := mobile1.ThreadFlagged(thrdId)
+	if err != nil {
+		t.Errorf("get flagged blocks failed: %s", err)
+		return
+	}
+	var queue []core.ThreadFlaggedInfo
+	if err := json.Unmarshal([]byte(res), &queue); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(queue) != 1 || len(queue[0].Flags) != 1 {
+		t.Errorf("get flagged blocks bad result: %s", res)
+		return
+	}
+
+	// the initiator is a moderator, so one flag hides the block
+	if !queue[0].Block.Hidden {
+		t.Error("block flagged by a moderator should be hidden")
+	}
+}
+
+func TestMobile_SetThreadFlagThreshold(t *testing.T) {
+	if _, err := mobile1.SetThreadFlagThreshold(thrdId, -1); err != core.ErrInvalidFlagThreshold {
+		t.Error("set negative flag threshold should fail")
+	}
+	if _, err := mobile1.SetThreadFlagThreshold(thrdId, 3); err != nil {
+		t.Errorf("set flag threshold failed: %s", err)
+		return
+	}
+	res, err := mobile1.Threads()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var threads []core.ThreadInfo
+	if err := json.Unmarshal([]byte(res), &threads); err != nil {
+		t.Error(err)
+		return
+	}
+	for _, thrd := range threads {
+		if thrd.Id == thrdId && thrd.FlagThreshold != 3 {
+			t.Error("set flag threshold bad result")
+		}
+	}
+}
+
+func TestMobile_RemoveThreadFlag(t *testing.T) {
+	res, err := mobile1.ThreadFlagged(thrdId)
+	if err != nil {
+		t.Errorf("get flagged blocks failed: %s", err)
+		return
+	}
+	var queue []core.ThreadFlaggedInfo
+	if err := json.Unmarshal([]byte(res), &queue); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(queue) != 1 {
+		t.Errorf("get flagged blocks bad result: %s", res)
+		return
+	}
+	blockId := queue[0].Block.Id
+
+	if _, err := mobile1.RemoveThreadFlag(blockId); err != nil {
+		t.Errorf("remove thread flag failed: %s", err)
+		return
+	}
+	if _, err := mobile1.RemoveThreadFlag(blockId); err != core.ErrFlagNotFound {
+		t.Error("remove thread flag again should fail")
+	}
+
+	// the only flag was undone, so the block no longer needs review
+	res, err = mobile1.ThreadFlagged(thrdId)
+	if err != nil {
+		t.Errorf("get flagged blocks failed: %s", err)
+		return
+	}
+	if err := json.Unmarshal([]byte(res), &queue); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(queue) != 0 {
+		t.Errorf("remove thread flag bad result: %s", res)
+	}
+}
+
 func TestMobile_AddThreadIgnore(t *testing.T) {
 	if _, err := mobile1.AddThreadIgnore(filesBlock.Id); err != nil {
 		t.Errorf("add thread ignore failed: %s", err)
@@ -348,6 +755,29 @@ func TestMobile_AddThreadIgnore(t *testing.T) {
 	}
 }
 
+func TestMobile_RestoreThreadBlock(t *testing.T) {
+	if _, err := mobile1.RestoreThreadBlock(filesBlock.Id); err != nil {
+		t.Errorf("restore thread block failed: %s", err)
+		return
+	}
+	if _, err := mobile1.RestoreThreadBlock(filesBlock.Id); err != core.ErrIgnoreNotFound {
+		t.Error("restore thread block again should fail")
+	}
+	res, err := mobile1.ThreadFiles("", -1, thrdId)
+	if err != nil {
+		t.Errorf("get thread files failed: %s", err)
+		return
+	}
+	var files []core.ThreadFilesInfo
+	if err := json.Unmarshal([]byte(res), &files); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(files) != 2 {
+		t.Errorf("thread restore bad result")
+	}
+}
+
 func TestMobile_PhotoDataForMinWidth(t *testing.T) {
 	large, err := mobile1.FileData(files[0].Files[0].Links["large"].Hash)
 	if err != nil {
@@ -509,8 +939,79 @@ func TestMobile_AddExternalThreadInvite(t *testing.T) {
 		t.Error(err)
 		return
 	}
-	if invite.Key == "" {
+	if invite.Key == "" || invite.Link == "" {
 		t.Errorf("bad invite result: %s", res)
+		return
+	}
+	link, err := core.DecodeInviteLink(invite.URI)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if link.Id != invite.Id || base58.FastBase58Encoding(link.Key) != invite.Key {
+		t.Errorf("bad invite link: %s", invite.Link)
+	}
+}
+
+func TestMobile_ThreadExternalInvites(t *testing.T) {
+	res, err := mobile1.AddLimitedExternalThreadInvite(thrdId, 3600, 1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var limited ExternalInvite
+	if err := json.Unmarshal([]byte(res), &limited); err != nil {
+		t.Error(err)
+		return
+	}
+
+	res, err = mobile1.ThreadExternalInvites(thrdId)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var list []core.ThreadExternalInviteInfo
+	if err := json.Unmarshal([]byte(res), &list); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(list) != 2 || list[0].Id != limited.Id || list[0].MaxUses != 1 || list[0].Expires == nil {
+		t.Errorf("bad external invites result: %s", res)
+	}
+}
+
+func TestMobile_RevokeExternalThreadInvite(t *testing.T) {
+	res, err := mobile1.AddExternalThreadInvite(thrdId)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var revoked ExternalInvite
+	if err := json.Unmarshal([]byte(res), &revoked); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := mobile1.RevokeExternalThreadInvite(thrdId, revoked.Id); err != nil {
+		t.Error(err)
+		return
+	}
+	res, err = mobile1.ThreadExternalInvites(thrdId)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var list []core.ThreadExternalInviteInfo
+	if err := json.Unmarshal([]byte(res), &list); err != nil {
+		t.Error(err)
+		return
+	}
+	for _, item := range list {
+		if item.Id == revoked.Id {
+			t.Error("revoked invite should not be listed")
+		}
+	}
+	if _, err := mobile1.RevokeExternalThreadInvite(thrdId, revoked.Id); err != core.ErrInviteRevoked {
+		t.Error("revoking an invite twice should fail")
 	}
 }
 
@@ -526,6 +1027,44 @@ func TestMobile_AcceptExternalThreadInvite(t *testing.T) {
 	}
 }
 
+func TestMobile_ExportThread(t *testing.T) {
+	res, err := mobile2.AddThread(ksuid.New().String(), "archived", true)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var thrd *core.ThreadInfo
+	if err := json.Unmarshal([]byte(res), &thrd); err != nil {
+		t.Error(err)
+		return
+	}
+	archiveId = thrd.Id
+
+	if err := mobile2.ExportThread(archiveId, archivePath); err != nil {
+		t.Errorf("export thread failed: %s", err)
+	}
+}
+
+func TestMobile_ImportThread(t *testing.T) {
+	defer os.Remove(archivePath)
+	res, err := mobile1.ImportThread(archivePath)
+	if err != nil {
+		t.Errorf("import thread failed: %s", err)
+		return
+	}
+	var thrd *core.ThreadInfo
+	if err := json.Unmarshal([]byte(res), &thrd); err != nil {
+		t.Error(err)
+		return
+	}
+	if thrd.Id != archiveId || thrd.Name != "archived" || thrd.BlockCount != 1 {
+		t.Errorf("import thread bad result: %s", res)
+	}
+	if _, err := mobile1.ImportThread(archivePath); err != core.ErrThreadLoaded {
+		t.Error("import thread again should fail")
+	}
+}
+
 func TestMobile_Notifications(t *testing.T) {
 	res, err := mobile1.Notifications("", -1)
 	if err != nil {
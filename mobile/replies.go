@@ -0,0 +1,41 @@
+package mobile
+
+import "github.com/textileio/textile-go/core"
+
+// AddThreadReply adds a message in reply to the given message or comment block
+func (m *Mobile) AddThreadReply(blockId string, body string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	block, err := m.node.Block(blockId)
+	if err != nil {
+		return "", err
+	}
+
+	thrd := m.node.Thread(block.ThreadId)
+	if thrd == nil {
+		return "", core.ErrThreadNotFound
+	}
+
+	hash, err := thrd.AddReply(block.Id, body)
+	if err != nil {
+		return "", err
+	}
+
+	return hash.B58String(), nil
+}
+
+// ThreadReplies calls core ThreadReplies
+func (m *Mobile) ThreadReplies(blockId string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	info, err := m.node.ThreadReplies(blockId)
+	if err != nil {
+		return "", err
+	}
+
+	return toJSON(info)
+}
@@ -0,0 +1,47 @@
+package mobile
+
+import (
+	"strings"
+	"time"
+
+	"github.com/textileio/textile-go/core"
+	"github.com/textileio/textile-go/repo"
+)
+
+// Search calls core Search, where types is an or'd list of block types (e.g., MESSAGE|COMMENT),
+// and since and until are unix seconds, zero for no date limit
+func (m *Mobile) Search(query string, threadId string, authorId string, types string, since int64, until int64, limit int) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	squery := &repo.SearchQuery{
+		Text:     query,
+		ThreadId: threadId,
+		AuthorId: authorId,
+	}
+	if types != "" {
+		for _, desc := range strings.Split(types, "|") {
+			btype, err := repo.BlockTypeFromString(desc)
+			if err != nil {
+				return "", err
+			}
+			squery.Types = append(squery.Types, btype)
+		}
+	}
+	if since > 0 {
+		date := time.Unix(since, 0)
+		squery.Since = &date
+	}
+	if until > 0 {
+		date := time.Unix(until, 0)
+		squery.Until = &date
+	}
+
+	results, err := m.node.Search(squery, limit)
+	if err != nil {
+		return "", err
+	}
+
+	return toJSON(results)
+}
@@ -2,6 +2,7 @@ package mobile
 
 import (
 	"crypto/rand"
+	"os"
 
 	mh "gx/ipfs/QmPnFwZ2JXKnXgMw8CdBPxn7FWh6LLdjUjxV1fKHuJnkr8/go-multihash"
 	libp2pc "gx/ipfs/QmPvyPwuCgJ7pDmrKDxRtsScJgBaM5h4EpRL2qQJsmXf4n/go-libp2p-crypto"
@@ -13,11 +14,14 @@ import (
 	"github.com/textileio/textile-go/schema/textile"
 )
 
-// ExternalInvite is a wrapper around an invite id and key
+// ExternalInvite is a wrapper around an invite id and key, along with
+// a single invite link and its textile:// URI
 type ExternalInvite struct {
 	Id      string `json:"id"`
 	Key     string `json:"key"`
 	Inviter string `json:"inviter"`
+	Link    string `json:"link"`
+	URI     string `json:"uri"`
 }
 
 // Threads lists all threads
@@ -40,6 +44,29 @@ func (m *Mobile) Threads() (string, error) {
 
 // AddThread adds a new thread with the given name
 func (m *Mobile) AddThread(key string, name string, shared bool) (string, error) {
+	// tmp use the built-in schemas for all mobile threads
+	// until we're ready to let the app define its own schemas.
+	if shared {
+		return m.addThread(key, name, textile.Media, repo.OpenThread)
+	}
+	return m.addThread(key, name, textile.CameraRoll, repo.PrivateThread)
+}
+
+// AddThreadWithType adds a new thread with the given name and type,
+// one of private, readonly, public, or open
+func (m *Mobile) AddThreadWithType(key string, name string, threadType string) (string, error) {
+	ttype, err := repo.ThreadTypeFromString(threadType)
+	if err != nil {
+		return "", err
+	}
+	if ttype == repo.PrivateThread {
+		return m.addThread(key, name, textile.CameraRoll, ttype)
+	}
+	return m.addThread(key, name, textile.Media, ttype)
+}
+
+// addThread adds a new thread with the given name, schema, and type
+func (m *Mobile) addThread(key string, name string, sch string, ttype repo.ThreadType) (string, error) {
 	if !m.node.Started() {
 		return "", core.ErrStopped
 	}
@@ -49,17 +76,6 @@ func (m *Mobile) AddThread(key string, name string, shared bool) (string, error)
 		return "", err
 	}
 
-	// tmp use the built-in schemas for all mobile threads
-	// until we're ready to let the app define its own schemas.
-	var sch string
-	var ttype repo.ThreadType
-	if shared {
-		sch = textile.Media
-		ttype = repo.OpenThread
-	} else {
-		sch = textile.CameraRoll
-		ttype = repo.PrivateThread
-	}
 	schema, err := m.addSchema(sch)
 	if err != nil {
 		return "", err
@@ -129,6 +145,12 @@ func (m *Mobile) AddThreadInvite(threadId string, inviteeId string) (string, err
 
 // AddExternalThreadInvite generates a new external invite link to a thread
 func (m *Mobile) AddExternalThreadInvite(threadId string) (string, error) {
+	return m.AddLimitedExternalThreadInvite(threadId, 0, 0)
+}
+
+// AddLimitedExternalThreadInvite generates a new external invite link to a thread,
+// which expires after ttl seconds and can be used at most maxUses times (0 for no limit)
+func (m *Mobile) AddLimitedExternalThreadInvite(threadId string, ttl int64, maxUses int) (string, error) {
 	if !m.node.Started() {
 		return "", core.ErrStopped
 	}
@@ -138,7 +160,12 @@ func (m *Mobile) AddExternalThreadInvite(threadId string) (string, error) {
 		return "", core.ErrThreadNotFound
 	}
 
-	hash, key, err := thrd.AddExternalInvite()
+	hash, key, err := thrd.AddLimitedExternalInvite(ttl, maxUses)
+	if err != nil {
+		return "", err
+	}
+
+	link, err := thrd.InviteLink(hash, key)
 	if err != nil {
 		return "", err
 	}
@@ -148,20 +175,60 @@ func (m *Mobile) AddExternalThreadInvite(threadId string) (string, error) {
 		Id:      hash.B58String(),
 		Key:     base58.FastBase58Encoding(key),
 		Inviter: username,
+		Link:    link,
+		URI:     core.InviteURI(link),
 	}
 
 	return toJSON(invite)
 }
 
-// AcceptExternalThreadInvite notifies the thread of a join
+// ThreadExternalInvites lists the outstanding external invites to a thread
+func (m *Mobile) ThreadExternalInvites(threadId string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	invites, err := m.node.ThreadExternalInvites(threadId)
+	if err != nil {
+		return "", err
+	}
+
+	return toJSON(invites)
+}
+
+// RevokeExternalThreadInvite revokes an external invite created by the local peer
+func (m *Mobile) RevokeExternalThreadInvite(threadId string, inviteId string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	thrd := m.node.Thread(threadId)
+	if thrd == nil {
+		return "", core.ErrThreadNotFound
+	}
+
+	hash, err := thrd.RevokeExternalInvite(inviteId)
+	if err != nil {
+		return "", err
+	}
+
+	return hash.B58String(), nil
+}
+
+// AcceptExternalThreadInvite notifies the thread of a join.
+// Pass an invite link or URI as id with an empty key to accept with a link.
 func (m *Mobile) AcceptExternalThreadInvite(id string, key string) (string, error) {
 	if !m.node.Online() {
 		return "", core.ErrOffline
 	}
 
-	keyb, err := base58.Decode(key)
-	if err != nil {
-		return "", err
+	var keyb []byte
+	if key != "" {
+		var err error
+		keyb, err = base58.Decode(key)
+		if err != nil {
+			return "", err
+		}
 	}
 
 	hash, err := m.node.AcceptExternalThreadInvite(id, keyb)
@@ -199,3 +266,283 @@ func (m *Mobile) AddPeerToThread(id string, threadId string) error {
 
 	return thrd.AddPeer(id)
 }
+
+// RemovePeerFromThread calls thread AddKick
+func (m *Mobile) RemovePeerFromThread(id string, threadId string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	thrd := m.node.Thread(threadId)
+	if thrd == nil {
+		return "", core.ErrThreadNotFound
+	}
+
+	hash, err := thrd.AddKick(id)
+	if err != nil {
+		return "", err
+	}
+
+	return hash.B58String(), nil
+}
+
+// SetThreadPeerRole calls thread AddRole with one of default, reader, writer, moderator, or admin
+func (m *Mobile) SetThreadPeerRole(id string, threadId string, role string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	thrd := m.node.Thread(threadId)
+	if thrd == nil {
+		return "", core.ErrThreadNotFound
+	}
+
+	trole, err := repo.ThreadRoleFromString(role)
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := thrd.AddRole(id, trole)
+	if err != nil {
+		return "", err
+	}
+
+	return hash.B58String(), nil
+}
+
+// UpdateThread calls thread UpdateMeta, replacing the thread name, description, cover, and ttl in seconds
+func (m *Mobile) UpdateThread(threadId string, name string, description string, cover string, ttl int64) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	thrd := m.node.Thread(threadId)
+	if thrd == nil {
+		return "", core.ErrThreadNotFound
+	}
+
+	hash, err := thrd.UpdateMeta(name, description, cover, ttl)
+	if err != nil {
+		return "", err
+	}
+
+	return hash.B58String(), nil
+}
+
+// ExportThread writes a thread archive to the given path
+func (m *Mobile) ExportThread(threadId string, path string) error {
+	if !m.node.Started() {
+		return core.ErrStopped
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return m.node.ExportThread(threadId, file)
+}
+
+// ImportThread adds a thread from an archive at the given path
+func (m *Mobile) ImportThread(path string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	thrd, err := m.node.ImportThread(file)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := thrd.Info()
+	if err != nil {
+		return "", err
+	}
+
+	return toJSON(info)
+}
+
+// VerifyThread calls thread Verify, optionally repairing missing or unindexed history
+func (m *Mobile) VerifyThread(threadId string, repair bool) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	thrd := m.node.Thread(threadId)
+	if thrd == nil {
+		return "", core.ErrThreadNotFound
+	}
+
+	res, err := thrd.Verify(repair)
+	if err != nil {
+		return "", err
+	}
+
+	return toJSON(res)
+}
+
+// AddThreadCheckpoint adds a checkpoint block to a thread, which lets new peers skip its full history
+func (m *Mobile) AddThreadCheckpoint(threadId string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	thrd := m.node.Thread(threadId)
+	if thrd == nil {
+		return "", core.ErrThreadNotFound
+	}
+
+	hash, err := thrd.AddCheckpoint()
+	if err != nil {
+		return "", err
+	}
+
+	return hash.B58String(), nil
+}
+
+// LoadThreadHistory calls thread LoadHistory, which syncs history skipped by a checkpoint join
+func (m *Mobile) LoadThreadHistory(threadId string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	thrd := m.node.Thread(threadId)
+	if thrd == nil {
+		return "", core.ErrThreadNotFound
+	}
+
+	if err := thrd.LoadHistory(); err != nil {
+		return "", err
+	}
+
+	info, err := thrd.Info()
+	if err != nil {
+		return "", err
+	}
+
+	return toJSON(info)
+}
+
+// MarkThreadRead calls thread MarkRead, which tells thread peers the newest block read
+func (m *Mobile) MarkThreadRead(threadId string, blockId string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	thrd := m.node.Thread(threadId)
+	if thrd == nil {
+		return "", core.ErrThreadNotFound
+	}
+
+	receipt, err := thrd.MarkRead(blockId)
+	if err != nil {
+		return "", err
+	}
+
+	return toJSON(receipt)
+}
+
+// ThreadReceipts lists the newest block read by each peer in a thread
+func (m *Mobile) ThreadReceipts(threadId string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	receipts, err := m.node.ThreadReceipts(threadId)
+	if err != nil {
+		return "", err
+	}
+
+	return toJSON(receipts)
+}
+
+// SetThreadReceiptsOff opts a thread in or out of sending read receipts
+func (m *Mobile) SetThreadReceiptsOff(threadId string, off bool) error {
+	if !m.node.Started() {
+		return core.ErrStopped
+	}
+
+	thrd := m.node.Thread(threadId)
+	if thrd == nil {
+		return core.ErrThreadNotFound
+	}
+
+	return thrd.SetReceiptsOff(off)
+}
+
+// SendThreadSignal sends a transient signal, e.g., typing or presence, to connected thread peers
+func (m *Mobile) SendThreadSignal(threadId string, stype string, data string) error {
+	if !m.node.Started() {
+		return core.ErrStopped
+	}
+
+	thrd := m.node.Thread(threadId)
+	if thrd == nil {
+		return core.ErrThreadNotFound
+	}
+
+	signalType, err := core.SignalTypeFromString(stype)
+	if err != nil {
+		return err
+	}
+
+	return thrd.SendSignal(signalType, data)
+}
+
+// RequestThreadJoin asks a thread admin for an invite to a thread
+func (m *Mobile) RequestThreadJoin(threadId string, peerId string, note string) error {
+	if !m.node.Started() {
+		return core.ErrStopped
+	}
+
+	return m.node.RequestThreadJoin(threadId, peerId, note)
+}
+
+// ThreadJoinRequests lists pending join requests to a thread, or all requests with all
+func (m *Mobile) ThreadJoinRequests(threadId string, all bool) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	reqs, err := m.node.ThreadJoinRequests(threadId, all)
+	if err != nil {
+		return "", err
+	}
+
+	return toJSON(reqs)
+}
+
+// ApproveThreadJoinRequest approves a pending join request with a direct invite
+func (m *Mobile) ApproveThreadJoinRequest(threadId string, id string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	info, err := m.node.ApproveThreadJoinRequest(threadId, id)
+	if err != nil {
+		return "", err
+	}
+
+	return toJSON(info)
+}
+
+// DenyThreadJoinRequest denies a pending join request
+func (m *Mobile) DenyThreadJoinRequest(threadId string, id string, note string) (string, error) {
+	if !m.node.Started() {
+		return "", core.ErrStopped
+	}
+
+	info, err := m.node.DenyThreadJoinRequest(threadId, id, note)
+	if err != nil {
+		return "", err
+	}
+
+	return toJSON(info)
+}
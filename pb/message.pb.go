@@ -25,6 +25,9 @@ const (
 	Message_PING                     Message_Type = 0
 	Message_PONG                     Message_Type = 1
 	Message_THREAD_ENVELOPE          Message_Type = 10
+	Message_THREAD_SIGNAL            Message_Type = 11
+	Message_THREAD_JOIN_REQUEST      Message_Type = 12
+	Message_THREAD_JOIN_DENIAL       Message_Type = 13
 	Message_CAFE_CHALLENGE           Message_Type = 50
 	Message_CAFE_NONCE               Message_Type = 51
 	Message_CAFE_REGISTRATION        Message_Type = 52
@@ -48,6 +51,9 @@ var Message_Type_name = map[int32]string{
 	0:   "PING",
 	1:   "PONG",
 	10:  "THREAD_ENVELOPE",
+	11:  "THREAD_SIGNAL",
+	12:  "THREAD_JOIN_REQUEST",
+	13:  "THREAD_JOIN_DENIAL",
 	50:  "CAFE_CHALLENGE",
 	51:  "CAFE_NONCE",
 	52:  "CAFE_REGISTRATION",
@@ -70,6 +76,9 @@ var Message_Type_value = map[string]int32{
 	"PING":                     0,
 	"PONG":                     1,
 	"THREAD_ENVELOPE":          10,
+	"THREAD_SIGNAL":            11,
+	"THREAD_JOIN_REQUEST":      12,
+	"THREAD_JOIN_DENIAL":       13,
 	"CAFE_CHALLENGE":           50,
 	"CAFE_NONCE":               51,
 	"CAFE_REGISTRATION":        52,
@@ -93,7 +102,7 @@ func (x Message_Type) String() string {
 	return proto.EnumName(Message_Type_name, int32(x))
 }
 func (Message_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_message_67c8121172310a71, []int{0, 0}
+	return fileDescriptor_message_df074157044dbb8f, []int{0, 0}
 }
 
 type Message struct {
@@ -110,7 +119,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_67c8121172310a71, []int{0}
+	return fileDescriptor_message_df074157044dbb8f, []int{0}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Message.Unmarshal(m, b)
@@ -170,7 +179,7 @@ func (m *Envelope) Reset()         { *m = Envelope{} }
 func (m *Envelope) String() string { return proto.CompactTextString(m) }
 func (*Envelope) ProtoMessage()    {}
 func (*Envelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_67c8121172310a71, []int{1}
+	return fileDescriptor_message_df074157044dbb8f, []int{1}
 }
 func (m *Envelope) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Envelope.Unmarshal(m, b)
@@ -216,7 +225,7 @@ func (m *Error) Reset()         { *m = Error{} }
 func (m *Error) String() string { return proto.CompactTextString(m) }
 func (*Error) ProtoMessage()    {}
 func (*Error) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_67c8121172310a71, []int{2}
+	return fileDescriptor_message_df074157044dbb8f, []int{2}
 }
 func (m *Error) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Error.Unmarshal(m, b)
@@ -257,39 +266,40 @@ func init() {
 	proto.RegisterEnum("Message_Type", Message_Type_name, Message_Type_value)
 }
 
-func init() { proto.RegisterFile("message.proto", fileDescriptor_message_67c8121172310a71) }
+func init() { proto.RegisterFile("message.proto", fileDescriptor_message_df074157044dbb8f) }
 
-var fileDescriptor_message_67c8121172310a71 = []byte{
-	// 481 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0xdd, 0x6e, 0xda, 0x40,
-	0x10, 0x85, 0xeb, 0xe0, 0x14, 0x32, 0x04, 0xb2, 0x99, 0xd0, 0xd6, 0xad, 0xa2, 0x8a, 0x72, 0xc5,
-	0x95, 0x23, 0x91, 0xa6, 0xff, 0x3f, 0xd9, 0x98, 0x09, 0xb8, 0x31, 0x76, 0xb4, 0xeb, 0x22, 0xb5,
-	0x37, 0x16, 0x94, 0x2d, 0x8a, 0x44, 0xb1, 0x8b, 0x49, 0x25, 0x9e, 0xa4, 0x2f, 0xd2, 0x47, 0xeb,
-	0x03, 0x54, 0xac, 0xb1, 0xc3, 0x45, 0xef, 0x66, 0xbe, 0x33, 0x73, 0x66, 0x57, 0x3a, 0x50, 0xfb,
-	0xa1, 0xd2, 0x74, 0x34, 0x55, 0x76, 0xb2, 0x88, 0x97, 0xf1, 0x93, 0xc7, 0xd3, 0x38, 0x9e, 0xce,
-	0xd4, 0x89, 0xee, 0xc6, 0xb7, 0xdf, 0x4f, 0x46, 0xf3, 0x55, 0x26, 0xb5, 0xfe, 0x98, 0x50, 0x1e,
-	0x64, 0xc3, 0xf8, 0x0c, 0xcc, 0xe5, 0x2a, 0x51, 0x96, 0xd1, 0x34, 0xda, 0xf5, 0x4e, 0xcd, 0xde,
-	0x70, 0x3b, 0x5c, 0x25, 0x4a, 0x68, 0x09, 0x6d, 0x28, 0x27, 0xa3, 0xd5, 0x2c, 0x1e, 0x4d, 0xac,
-	0x9d, 0xa6, 0xd1, 0xae, 0x76, 0x1a, 0x76, 0xe6, 0x6d, 0xe7, 0xde, 0x36, 0x9f, 0xaf, 0x44, 0x3e,
-	0x84, 0xc7, 0xb0, 0xb7, 0x50, 0x3f, 0x6f, 0x55, 0xba, 0x74, 0x27, 0x56, 0xa9, 0x69, 0xb4, 0x77,
-	0xc5, 0x1d, 0xc0, 0xa7, 0x00, 0x37, 0xa9, 0x50, 0x69, 0x12, 0xcf, 0x53, 0x65, 0x99, 0x4d, 0xa3,
-	0x5d, 0x11, 0x5b, 0xa4, 0xf5, 0xbb, 0x04, 0xe6, 0xfa, 0x38, 0x56, 0xc0, 0xbc, 0x76, 0xfd, 0x1e,
-	0xbb, 0xa7, 0xab, 0xc0, 0xef, 0x31, 0x03, 0x8f, 0xe0, 0x20, 0xec, 0x0b, 0xe2, 0xdd, 0x88, 0xfc,
-	0x21, 0x79, 0xc1, 0x35, 0x31, 0x40, 0x84, 0xba, 0xc3, 0x2f, 0x29, 0x72, 0xfa, 0xdc, 0xf3, 0xc8,
-	0xef, 0x11, 0xeb, 0x60, 0x1d, 0x40, 0x33, 0x3f, 0xf0, 0x1d, 0x62, 0xa7, 0xf8, 0x00, 0x0e, 0x75,
-	0x2f, 0xa8, 0xe7, 0xca, 0x50, 0xf0, 0xd0, 0x0d, 0x7c, 0xf6, 0x1c, 0x19, 0xec, 0x6b, 0x2c, 0x49,
-	0xca, 0x35, 0x39, 0x43, 0x0b, 0x1a, 0x9b, 0xc1, 0x4b, 0x41, 0xb2, 0x5f, 0x28, 0x2f, 0x0a, 0x4b,
-	0x19, 0x06, 0x82, 0xd8, 0x4b, 0x3c, 0x80, 0xaa, 0xee, 0x83, 0x8b, 0x4f, 0xe4, 0x84, 0xec, 0x15,
-	0x36, 0x80, 0x6d, 0x81, 0xc8, 0x73, 0x65, 0xc8, 0x5e, 0x17, 0x97, 0xf5, 0x5a, 0x94, 0xbd, 0x9e,
-	0xbd, 0x29, 0xb6, 0x35, 0xee, 0xb2, 0xb7, 0xc5, 0xe1, 0x2e, 0x79, 0xee, 0x90, 0x44, 0x34, 0x20,
-	0x29, 0x79, 0x8f, 0xd8, 0x3b, 0x7c, 0x04, 0x47, 0x9b, 0xff, 0x91, 0x73, 0x95, 0x73, 0xc9, 0xde,
-	0xe3, 0x21, 0xd4, 0xb4, 0x50, 0xa0, 0x0f, 0xdb, 0x2e, 0x14, 0x6e, 0x29, 0x1f, 0xf1, 0x18, 0xac,
-	0xff, 0x29, 0x11, 0x77, 0xae, 0xd8, 0x39, 0x3e, 0x04, 0xd4, 0xea, 0x97, 0xe0, 0x73, 0xd4, 0xe7,
-	0x43, 0x8a, 0x06, 0xdc, 0xf5, 0x18, 0x47, 0x80, 0x5d, 0x12, 0x22, 0x10, 0xec, 0x6f, 0xa9, 0x75,
-	0x0e, 0x15, 0x9a, 0xff, 0x52, 0xb3, 0x38, 0x51, 0xd8, 0x82, 0xf2, 0x26, 0x6e, 0x3a, 0x39, 0xd5,
-	0x4e, 0x25, 0x4f, 0x8e, 0xc8, 0x05, 0x64, 0x50, 0x4a, 0x6f, 0xa6, 0x3a, 0x33, 0xfb, 0x62, 0x5d,
-	0xb6, 0xce, 0x60, 0x97, 0x16, 0x8b, 0x78, 0x81, 0x08, 0xe6, 0xb7, 0x78, 0x92, 0xed, 0xd6, 0x84,
-	0xae, 0xd1, 0xba, 0xb3, 0x5c, 0xaf, 0xec, 0x15, 0x46, 0x17, 0xe6, 0xd7, 0x9d, 0x64, 0x3c, 0xbe,
-	0xaf, 0xd3, 0x76, 0xfa, 0x2f, 0x00, 0x00, 0xff, 0xff, 0xfb, 0x5c, 0x63, 0xc6, 0xe8, 0x02, 0x00,
-	0x00,
+var fileDescriptor_message_df074157044dbb8f = []byte{
+	// 512 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0x4d, 0x6f, 0xda, 0x4e,
+	0x10, 0xc6, 0xff, 0x0e, 0xce, 0x1f, 0x32, 0xbc, 0x64, 0x33, 0xa1, 0xad, 0x5b, 0x45, 0x15, 0xe5,
+	0xc4, 0xc9, 0x91, 0x48, 0xd3, 0xf7, 0x97, 0x6c, 0xcc, 0xc4, 0x38, 0x31, 0x76, 0xba, 0xeb, 0x20,
+	0xb5, 0x17, 0x0b, 0x8a, 0x8b, 0x22, 0x51, 0xec, 0x62, 0x52, 0x89, 0xcf, 0xda, 0xaf, 0xd1, 0x43,
+	0x8f, 0x95, 0x17, 0xe3, 0x70, 0xe8, 0x6d, 0xe7, 0xf7, 0xcc, 0x3c, 0xa3, 0x1d, 0x3d, 0x50, 0xff,
+	0x1e, 0xa5, 0xe9, 0x68, 0x1a, 0x99, 0xc9, 0x22, 0x5e, 0xc6, 0x4f, 0x1e, 0x4f, 0xe3, 0x78, 0x3a,
+	0x8b, 0x8e, 0x55, 0x35, 0xbe, 0xfb, 0x76, 0x3c, 0x9a, 0xaf, 0xd6, 0x52, 0xfb, 0x8f, 0x0e, 0xe5,
+	0xc1, 0xba, 0x19, 0x9f, 0x81, 0xbe, 0x5c, 0x25, 0x91, 0xa1, 0xb5, 0xb4, 0x4e, 0xa3, 0x5b, 0x37,
+	0x73, 0x6e, 0x06, 0xab, 0x24, 0x12, 0x4a, 0x42, 0x13, 0xca, 0xc9, 0x68, 0x35, 0x8b, 0x47, 0x13,
+	0x63, 0xa7, 0xa5, 0x75, 0xaa, 0xdd, 0xa6, 0xb9, 0xf6, 0x36, 0x37, 0xde, 0x26, 0x9f, 0xaf, 0xc4,
+	0xa6, 0x09, 0x8f, 0x60, 0x6f, 0x11, 0xfd, 0xb8, 0x8b, 0xd2, 0xa5, 0x33, 0x31, 0x4a, 0x2d, 0xad,
+	0xb3, 0x2b, 0xee, 0x01, 0x3e, 0x05, 0xb8, 0x4d, 0x45, 0x94, 0x26, 0xf1, 0x3c, 0x8d, 0x0c, 0xbd,
+	0xa5, 0x75, 0x2a, 0x62, 0x8b, 0xb4, 0x7f, 0x95, 0x40, 0xcf, 0x96, 0x63, 0x05, 0xf4, 0x6b, 0xc7,
+	0xb3, 0xd9, 0x7f, 0xea, 0xe5, 0x7b, 0x36, 0xd3, 0xf0, 0x10, 0xf6, 0x83, 0xbe, 0x20, 0xde, 0x0b,
+	0xc9, 0x1b, 0x92, 0xeb, 0x5f, 0x13, 0x03, 0x3c, 0x80, 0x7a, 0x0e, 0xa5, 0x63, 0x7b, 0xdc, 0x65,
+	0x55, 0x7c, 0x04, 0x87, 0x39, 0xba, 0xf4, 0x1d, 0x2f, 0x14, 0xf4, 0xe9, 0x86, 0x64, 0xc0, 0x6a,
+	0xf8, 0x10, 0x70, 0x5b, 0xe8, 0x91, 0xe7, 0x70, 0x97, 0xd5, 0x11, 0xa1, 0x61, 0xf1, 0x0b, 0x0a,
+	0xad, 0x3e, 0x77, 0x5d, 0xf2, 0x6c, 0x62, 0x5d, 0x6c, 0x00, 0x28, 0xe6, 0xf9, 0x9e, 0x45, 0xec,
+	0x04, 0x1f, 0xc0, 0x81, 0xaa, 0x05, 0xd9, 0x8e, 0x0c, 0x04, 0x0f, 0x1c, 0xdf, 0x63, 0xcf, 0x91,
+	0x41, 0x4d, 0x61, 0x49, 0x52, 0x66, 0xe4, 0x14, 0x0d, 0x68, 0xe6, 0x8d, 0x17, 0x82, 0x64, 0xbf,
+	0x50, 0x5e, 0x14, 0x96, 0x32, 0xf0, 0x05, 0xb1, 0x97, 0xb8, 0x0f, 0x55, 0x55, 0xfb, 0xe7, 0x97,
+	0x64, 0x05, 0xec, 0x15, 0x36, 0x81, 0x6d, 0x81, 0xd0, 0x75, 0x64, 0xc0, 0x5e, 0x17, 0x9b, 0xd5,
+	0x58, 0xb8, 0xfe, 0x00, 0x7b, 0x53, 0x4c, 0x2b, 0xdc, 0x63, 0x6f, 0x8b, 0xc5, 0x3d, 0x72, 0x9d,
+	0x21, 0x89, 0x70, 0x40, 0x52, 0x72, 0x9b, 0xd8, 0xbb, 0xec, 0x20, 0xf9, 0xff, 0xc8, 0xba, 0xda,
+	0x70, 0xc9, 0xde, 0x67, 0xc7, 0x53, 0x42, 0x81, 0x3e, 0x6c, 0xbb, 0x50, 0xb0, 0xa5, 0x7c, 0xc4,
+	0x23, 0x30, 0xfe, 0xa5, 0x84, 0xdc, 0xba, 0x62, 0x67, 0xd9, 0x6d, 0x95, 0xfa, 0xd9, 0xbf, 0x09,
+	0xfb, 0x7c, 0x48, 0xe1, 0x80, 0x3b, 0x2e, 0xe3, 0x08, 0xb0, 0x4b, 0x42, 0xf8, 0x82, 0xfd, 0x2e,
+	0xb5, 0xcf, 0xa0, 0x42, 0xf3, 0x9f, 0xd1, 0x2c, 0x4e, 0x22, 0x6c, 0x43, 0x39, 0x8f, 0xac, 0x4a,
+	0x5f, 0xb5, 0x5b, 0xd9, 0xa4, 0x4f, 0x6c, 0x04, 0x64, 0x50, 0x4a, 0x6f, 0xa7, 0x2a, 0x77, 0x35,
+	0x91, 0x3d, 0xdb, 0xa7, 0xb0, 0x4b, 0x8b, 0x45, 0xbc, 0x40, 0x04, 0xfd, 0x6b, 0x3c, 0x59, 0xcf,
+	0xd6, 0x85, 0x7a, 0xa3, 0x71, 0x6f, 0x99, 0x8d, 0xec, 0x15, 0x46, 0xe7, 0xfa, 0x97, 0x9d, 0x64,
+	0x3c, 0xfe, 0x5f, 0x25, 0xf6, 0xe4, 0xef, 0x00, 0x5d, 0x5e, 0xe5, 0x53, 0x2c, 0x03, 0x00, 0x00,
 }
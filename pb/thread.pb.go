@@ -20,20 +20,56 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type ThreadSignal_Type int32
+
+const (
+	ThreadSignal_TYPING   ThreadSignal_Type = 0
+	ThreadSignal_PRESENCE ThreadSignal_Type = 1
+	ThreadSignal_CURSOR   ThreadSignal_Type = 2
+)
+
+var ThreadSignal_Type_name = map[int32]string{
+	0: "TYPING",
+	1: "PRESENCE",
+	2: "CURSOR",
+}
+var ThreadSignal_Type_value = map[string]int32{
+	"TYPING":   0,
+	"PRESENCE": 1,
+	"CURSOR":   2,
+}
+
+func (x ThreadSignal_Type) String() string {
+	return proto.EnumName(ThreadSignal_Type_name, int32(x))
+}
+func (ThreadSignal_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{2, 0}
+}
+
 type ThreadBlock_Type int32
 
 const (
-	ThreadBlock_MERGE    ThreadBlock_Type = 0
-	ThreadBlock_IGNORE   ThreadBlock_Type = 1
-	ThreadBlock_FLAG     ThreadBlock_Type = 2
-	ThreadBlock_JOIN     ThreadBlock_Type = 3
-	ThreadBlock_ANNOUNCE ThreadBlock_Type = 4
-	ThreadBlock_LEAVE    ThreadBlock_Type = 5
-	ThreadBlock_MESSAGE  ThreadBlock_Type = 6
-	ThreadBlock_FILES    ThreadBlock_Type = 7
-	ThreadBlock_COMMENT  ThreadBlock_Type = 8
-	ThreadBlock_LIKE     ThreadBlock_Type = 9
-	ThreadBlock_INVITE   ThreadBlock_Type = 50
+	ThreadBlock_MERGE           ThreadBlock_Type = 0
+	ThreadBlock_IGNORE          ThreadBlock_Type = 1
+	ThreadBlock_FLAG            ThreadBlock_Type = 2
+	ThreadBlock_JOIN            ThreadBlock_Type = 3
+	ThreadBlock_ANNOUNCE        ThreadBlock_Type = 4
+	ThreadBlock_LEAVE           ThreadBlock_Type = 5
+	ThreadBlock_MESSAGE         ThreadBlock_Type = 6
+	ThreadBlock_FILES           ThreadBlock_Type = 7
+	ThreadBlock_COMMENT         ThreadBlock_Type = 8
+	ThreadBlock_LIKE            ThreadBlock_Type = 9
+	ThreadBlock_KEY             ThreadBlock_Type = 10
+	ThreadBlock_KICK            ThreadBlock_Type = 11
+	ThreadBlock_ROLE            ThreadBlock_Type = 12
+	ThreadBlock_EDIT            ThreadBlock_Type = 13
+	ThreadBlock_META            ThreadBlock_Type = 14
+	ThreadBlock_CHECKPOINT      ThreadBlock_Type = 15
+	ThreadBlock_READ            ThreadBlock_Type = 16
+	ThreadBlock_EXTERNAL_INVITE ThreadBlock_Type = 17
+	ThreadBlock_UNDO            ThreadBlock_Type = 18
+	ThreadBlock_JOIN_DECISION   ThreadBlock_Type = 19
+	ThreadBlock_INVITE          ThreadBlock_Type = 50
 )
 
 var ThreadBlock_Type_name = map[int32]string{
@@ -47,27 +83,114 @@ var ThreadBlock_Type_name = map[int32]string{
 	7:  "FILES",
 	8:  "COMMENT",
 	9:  "LIKE",
+	10: "KEY",
+	11: "KICK",
+	12: "ROLE",
+	13: "EDIT",
+	14: "META",
+	15: "CHECKPOINT",
+	16: "READ",
+	17: "EXTERNAL_INVITE",
+	18: "UNDO",
+	19: "JOIN_DECISION",
 	50: "INVITE",
 }
 var ThreadBlock_Type_value = map[string]int32{
-	"MERGE":    0,
-	"IGNORE":   1,
-	"FLAG":     2,
-	"JOIN":     3,
-	"ANNOUNCE": 4,
-	"LEAVE":    5,
-	"MESSAGE":  6,
-	"FILES":    7,
-	"COMMENT":  8,
-	"LIKE":     9,
-	"INVITE":   50,
+	"MERGE":           0,
+	"IGNORE":          1,
+	"FLAG":            2,
+	"JOIN":            3,
+	"ANNOUNCE":        4,
+	"LEAVE":           5,
+	"MESSAGE":         6,
+	"FILES":           7,
+	"COMMENT":         8,
+	"LIKE":            9,
+	"KEY":             10,
+	"KICK":            11,
+	"ROLE":            12,
+	"EDIT":            13,
+	"META":            14,
+	"CHECKPOINT":      15,
+	"READ":            16,
+	"EXTERNAL_INVITE": 17,
+	"UNDO":            18,
+	"JOIN_DECISION":   19,
+	"INVITE":          50,
 }
 
 func (x ThreadBlock_Type) String() string {
 	return proto.EnumName(ThreadBlock_Type_name, int32(x))
 }
 func (ThreadBlock_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_thread_b1d52b2481e68edd, []int{1, 0}
+	return fileDescriptor_thread_474fafe899102781, []int{5, 0}
+}
+
+type ThreadFlag_Reason int32
+
+const (
+	ThreadFlag_UNSPECIFIED   ThreadFlag_Reason = 0
+	ThreadFlag_SPAM          ThreadFlag_Reason = 1
+	ThreadFlag_ABUSE         ThreadFlag_Reason = 2
+	ThreadFlag_INAPPROPRIATE ThreadFlag_Reason = 3
+	ThreadFlag_COPYRIGHT     ThreadFlag_Reason = 4
+	ThreadFlag_OTHER         ThreadFlag_Reason = 5
+)
+
+var ThreadFlag_Reason_name = map[int32]string{
+	0: "UNSPECIFIED",
+	1: "SPAM",
+	2: "ABUSE",
+	3: "INAPPROPRIATE",
+	4: "COPYRIGHT",
+	5: "OTHER",
+}
+var ThreadFlag_Reason_value = map[string]int32{
+	"UNSPECIFIED":   0,
+	"SPAM":          1,
+	"ABUSE":         2,
+	"INAPPROPRIATE": 3,
+	"COPYRIGHT":     4,
+	"OTHER":         5,
+}
+
+func (x ThreadFlag_Reason) String() string {
+	return proto.EnumName(ThreadFlag_Reason_name, int32(x))
+}
+func (ThreadFlag_Reason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{13, 0}
+}
+
+type ThreadRole_Role int32
+
+const (
+	ThreadRole_DEFAULT   ThreadRole_Role = 0
+	ThreadRole_READER    ThreadRole_Role = 1
+	ThreadRole_WRITER    ThreadRole_Role = 2
+	ThreadRole_MODERATOR ThreadRole_Role = 3
+	ThreadRole_ADMIN     ThreadRole_Role = 4
+)
+
+var ThreadRole_Role_name = map[int32]string{
+	0: "DEFAULT",
+	1: "READER",
+	2: "WRITER",
+	3: "MODERATOR",
+	4: "ADMIN",
+}
+var ThreadRole_Role_value = map[string]int32{
+	"DEFAULT":   0,
+	"READER":    1,
+	"WRITER":    2,
+	"MODERATOR": 3,
+	"ADMIN":     4,
+}
+
+func (x ThreadRole_Role) String() string {
+	return proto.EnumName(ThreadRole_Role_name, int32(x))
+}
+func (ThreadRole_Role) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{28, 0}
 }
 
 // for wire transport
@@ -84,7 +207,7 @@ func (m *ThreadEnvelope) Reset()         { *m = ThreadEnvelope{} }
 func (m *ThreadEnvelope) String() string { return proto.CompactTextString(m) }
 func (*ThreadEnvelope) ProtoMessage()    {}
 func (*ThreadEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_thread_b1d52b2481e68edd, []int{0}
+	return fileDescriptor_thread_474fafe899102781, []int{0}
 }
 func (m *ThreadEnvelope) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ThreadEnvelope.Unmarshal(m, b)
@@ -125,6 +248,231 @@ func (m *ThreadEnvelope) GetCiphertext() []byte {
 	return nil
 }
 
+type ThreadSignalEnvelope struct {
+	Thread               string   `protobuf:"bytes,1,opt,name=thread,proto3" json:"thread,omitempty"`
+	Ciphertext           []byte   `protobuf:"bytes,2,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ThreadSignalEnvelope) Reset()         { *m = ThreadSignalEnvelope{} }
+func (m *ThreadSignalEnvelope) String() string { return proto.CompactTextString(m) }
+func (*ThreadSignalEnvelope) ProtoMessage()    {}
+func (*ThreadSignalEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{1}
+}
+func (m *ThreadSignalEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThreadSignalEnvelope.Unmarshal(m, b)
+}
+func (m *ThreadSignalEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThreadSignalEnvelope.Marshal(b, m, deterministic)
+}
+func (dst *ThreadSignalEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThreadSignalEnvelope.Merge(dst, src)
+}
+func (m *ThreadSignalEnvelope) XXX_Size() int {
+	return xxx_messageInfo_ThreadSignalEnvelope.Size(m)
+}
+func (m *ThreadSignalEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThreadSignalEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThreadSignalEnvelope proto.InternalMessageInfo
+
+func (m *ThreadSignalEnvelope) GetThread() string {
+	if m != nil {
+		return m.Thread
+	}
+	return ""
+}
+
+func (m *ThreadSignalEnvelope) GetCiphertext() []byte {
+	if m != nil {
+		return m.Ciphertext
+	}
+	return nil
+}
+
+type ThreadSignal struct {
+	Type                 ThreadSignal_Type    `protobuf:"varint,1,opt,name=type,proto3,enum=ThreadSignal_Type" json:"type,omitempty"`
+	Data                 string               `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Date                 *timestamp.Timestamp `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ThreadSignal) Reset()         { *m = ThreadSignal{} }
+func (m *ThreadSignal) String() string { return proto.CompactTextString(m) }
+func (*ThreadSignal) ProtoMessage()    {}
+func (*ThreadSignal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{2}
+}
+func (m *ThreadSignal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThreadSignal.Unmarshal(m, b)
+}
+func (m *ThreadSignal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThreadSignal.Marshal(b, m, deterministic)
+}
+func (dst *ThreadSignal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThreadSignal.Merge(dst, src)
+}
+func (m *ThreadSignal) XXX_Size() int {
+	return xxx_messageInfo_ThreadSignal.Size(m)
+}
+func (m *ThreadSignal) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThreadSignal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThreadSignal proto.InternalMessageInfo
+
+func (m *ThreadSignal) GetType() ThreadSignal_Type {
+	if m != nil {
+		return m.Type
+	}
+	return ThreadSignal_TYPING
+}
+
+func (m *ThreadSignal) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+func (m *ThreadSignal) GetDate() *timestamp.Timestamp {
+	if m != nil {
+		return m.Date
+	}
+	return nil
+}
+
+// sent directly to a thread admin, who may approve it with an invite
+type ThreadJoinRequest struct {
+	Thread               string               `protobuf:"bytes,1,opt,name=thread,proto3" json:"thread,omitempty"`
+	Username             string               `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Address              string               `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Inboxes              []string             `protobuf:"bytes,4,rep,name=inboxes,proto3" json:"inboxes,omitempty"`
+	Note                 string               `protobuf:"bytes,5,opt,name=note,proto3" json:"note,omitempty"`
+	Date                 *timestamp.Timestamp `protobuf:"bytes,6,opt,name=date,proto3" json:"date,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ThreadJoinRequest) Reset()         { *m = ThreadJoinRequest{} }
+func (m *ThreadJoinRequest) String() string { return proto.CompactTextString(m) }
+func (*ThreadJoinRequest) ProtoMessage()    {}
+func (*ThreadJoinRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{3}
+}
+func (m *ThreadJoinRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThreadJoinRequest.Unmarshal(m, b)
+}
+func (m *ThreadJoinRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThreadJoinRequest.Marshal(b, m, deterministic)
+}
+func (dst *ThreadJoinRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThreadJoinRequest.Merge(dst, src)
+}
+func (m *ThreadJoinRequest) XXX_Size() int {
+	return xxx_messageInfo_ThreadJoinRequest.Size(m)
+}
+func (m *ThreadJoinRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThreadJoinRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThreadJoinRequest proto.InternalMessageInfo
+
+func (m *ThreadJoinRequest) GetThread() string {
+	if m != nil {
+		return m.Thread
+	}
+	return ""
+}
+
+func (m *ThreadJoinRequest) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *ThreadJoinRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ThreadJoinRequest) GetInboxes() []string {
+	if m != nil {
+		return m.Inboxes
+	}
+	return nil
+}
+
+func (m *ThreadJoinRequest) GetNote() string {
+	if m != nil {
+		return m.Note
+	}
+	return ""
+}
+
+func (m *ThreadJoinRequest) GetDate() *timestamp.Timestamp {
+	if m != nil {
+		return m.Date
+	}
+	return nil
+}
+
+type ThreadJoinDenial struct {
+	Thread               string   `protobuf:"bytes,1,opt,name=thread,proto3" json:"thread,omitempty"`
+	Note                 string   `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ThreadJoinDenial) Reset()         { *m = ThreadJoinDenial{} }
+func (m *ThreadJoinDenial) String() string { return proto.CompactTextString(m) }
+func (*ThreadJoinDenial) ProtoMessage()    {}
+func (*ThreadJoinDenial) Descriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{4}
+}
+func (m *ThreadJoinDenial) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThreadJoinDenial.Unmarshal(m, b)
+}
+func (m *ThreadJoinDenial) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThreadJoinDenial.Marshal(b, m, deterministic)
+}
+func (dst *ThreadJoinDenial) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThreadJoinDenial.Merge(dst, src)
+}
+func (m *ThreadJoinDenial) XXX_Size() int {
+	return xxx_messageInfo_ThreadJoinDenial.Size(m)
+}
+func (m *ThreadJoinDenial) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThreadJoinDenial.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThreadJoinDenial proto.InternalMessageInfo
+
+func (m *ThreadJoinDenial) GetThread() string {
+	if m != nil {
+		return m.Thread
+	}
+	return ""
+}
+
+func (m *ThreadJoinDenial) GetNote() string {
+	if m != nil {
+		return m.Note
+	}
+	return ""
+}
+
 type ThreadBlock struct {
 	Header               *ThreadBlockHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Type                 ThreadBlock_Type   `protobuf:"varint,2,opt,name=type,proto3,enum=ThreadBlock_Type" json:"type,omitempty"`
@@ -138,7 +486,7 @@ func (m *ThreadBlock) Reset()         { *m = ThreadBlock{} }
 func (m *ThreadBlock) String() string { return proto.CompactTextString(m) }
 func (*ThreadBlock) ProtoMessage()    {}
 func (*ThreadBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_thread_b1d52b2481e68edd, []int{1}
+	return fileDescriptor_thread_474fafe899102781, []int{5}
 }
 func (m *ThreadBlock) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ThreadBlock.Unmarshal(m, b)
@@ -184,6 +532,9 @@ type ThreadBlockHeader struct {
 	Parents              []string             `protobuf:"bytes,2,rep,name=parents,proto3" json:"parents,omitempty"`
 	Author               string               `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
 	Address              string               `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	Sig                  []byte               `protobuf:"bytes,5,opt,name=sig,proto3" json:"sig,omitempty"`
+	Clock                int64                `protobuf:"varint,6,opt,name=clock,proto3" json:"clock,omitempty"`
+	PeerSig              []byte               `protobuf:"bytes,7,opt,name=peer_sig,json=peerSig,proto3" json:"peer_sig,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -193,7 +544,7 @@ func (m *ThreadBlockHeader) Reset()         { *m = ThreadBlockHeader{} }
 func (m *ThreadBlockHeader) String() string { return proto.CompactTextString(m) }
 func (*ThreadBlockHeader) ProtoMessage()    {}
 func (*ThreadBlockHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_thread_b1d52b2481e68edd, []int{2}
+	return fileDescriptor_thread_474fafe899102781, []int{6}
 }
 func (m *ThreadBlockHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ThreadBlockHeader.Unmarshal(m, b)
@@ -241,21 +592,46 @@ func (m *ThreadBlockHeader) GetAddress() string {
 	return ""
 }
 
+func (m *ThreadBlockHeader) GetSig() []byte {
+	if m != nil {
+		return m.Sig
+	}
+	return nil
+}
+
+func (m *ThreadBlockHeader) GetClock() int64 {
+	if m != nil {
+		return m.Clock
+	}
+	return 0
+}
+
+func (m *ThreadBlockHeader) GetPeerSig() []byte {
+	if m != nil {
+		return m.PeerSig
+	}
+	return nil
+}
+
 type ThreadInvite struct {
-	Sk                   []byte   `protobuf:"bytes,1,opt,name=sk,proto3" json:"sk,omitempty"`
-	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Schema               string   `protobuf:"bytes,3,opt,name=schema,proto3" json:"schema,omitempty"`
-	Initiator            string   `protobuf:"bytes,4,opt,name=initiator,proto3" json:"initiator,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Sk                   []byte               `protobuf:"bytes,1,opt,name=sk,proto3" json:"sk,omitempty"`
+	Name                 string               `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Schema               string               `protobuf:"bytes,3,opt,name=schema,proto3" json:"schema,omitempty"`
+	Initiator            string               `protobuf:"bytes,4,opt,name=initiator,proto3" json:"initiator,omitempty"`
+	Keys                 []*ThreadInviteKey   `protobuf:"bytes,5,rep,name=keys,proto3" json:"keys,omitempty"`
+	Expires              *timestamp.Timestamp `protobuf:"bytes,6,opt,name=expires,proto3" json:"expires,omitempty"`
+	MaxUses              int32                `protobuf:"varint,7,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	Record               *ThreadInviteRecord  `protobuf:"bytes,8,opt,name=record,proto3" json:"record,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *ThreadInvite) Reset()         { *m = ThreadInvite{} }
 func (m *ThreadInvite) String() string { return proto.CompactTextString(m) }
 func (*ThreadInvite) ProtoMessage()    {}
 func (*ThreadInvite) Descriptor() ([]byte, []int) {
-	return fileDescriptor_thread_b1d52b2481e68edd, []int{3}
+	return fileDescriptor_thread_474fafe899102781, []int{7}
 }
 func (m *ThreadInvite) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ThreadInvite.Unmarshal(m, b)
@@ -303,97 +679,429 @@ func (m *ThreadInvite) GetInitiator() string {
 	return ""
 }
 
-type ThreadIgnore struct {
-	Target               string   `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *ThreadInvite) GetKeys() []*ThreadInviteKey {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
 }
 
-func (m *ThreadIgnore) Reset()         { *m = ThreadIgnore{} }
-func (m *ThreadIgnore) String() string { return proto.CompactTextString(m) }
-func (*ThreadIgnore) ProtoMessage()    {}
-func (*ThreadIgnore) Descriptor() ([]byte, []int) {
-	return fileDescriptor_thread_b1d52b2481e68edd, []int{4}
-}
-func (m *ThreadIgnore) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ThreadIgnore.Unmarshal(m, b)
-}
-func (m *ThreadIgnore) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ThreadIgnore.Marshal(b, m, deterministic)
-}
-func (dst *ThreadIgnore) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ThreadIgnore.Merge(dst, src)
-}
-func (m *ThreadIgnore) XXX_Size() int {
-	return xxx_messageInfo_ThreadIgnore.Size(m)
-}
-func (m *ThreadIgnore) XXX_DiscardUnknown() {
-	xxx_messageInfo_ThreadIgnore.DiscardUnknown(m)
+func (m *ThreadInvite) GetExpires() *timestamp.Timestamp {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
 }
 
-var xxx_messageInfo_ThreadIgnore proto.InternalMessageInfo
+func (m *ThreadInvite) GetMaxUses() int32 {
+	if m != nil {
+		return m.MaxUses
+	}
+	return 0
+}
 
-func (m *ThreadIgnore) GetTarget() string {
+func (m *ThreadInvite) GetRecord() *ThreadInviteRecord {
 	if m != nil {
-		return m.Target
+		return m.Record
 	}
-	return ""
+	return nil
 }
 
-type ThreadFlag struct {
-	Target               string   `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type ThreadInviteRecord struct {
+	Thread               string               `protobuf:"bytes,1,opt,name=thread,proto3" json:"thread,omitempty"`
+	Inviter              string               `protobuf:"bytes,2,opt,name=inviter,proto3" json:"inviter,omitempty"`
+	Invitee              string               `protobuf:"bytes,3,opt,name=invitee,proto3" json:"invitee,omitempty"`
+	Nonce                string               `protobuf:"bytes,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Expires              *timestamp.Timestamp `protobuf:"bytes,5,opt,name=expires,proto3" json:"expires,omitempty"`
+	MaxUses              int32                `protobuf:"varint,6,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	Sig                  []byte               `protobuf:"bytes,7,opt,name=sig,proto3" json:"sig,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *ThreadFlag) Reset()         { *m = ThreadFlag{} }
-func (m *ThreadFlag) String() string { return proto.CompactTextString(m) }
-func (*ThreadFlag) ProtoMessage()    {}
-func (*ThreadFlag) Descriptor() ([]byte, []int) {
-	return fileDescriptor_thread_b1d52b2481e68edd, []int{5}
+func (m *ThreadInviteRecord) Reset()         { *m = ThreadInviteRecord{} }
+func (m *ThreadInviteRecord) String() string { return proto.CompactTextString(m) }
+func (*ThreadInviteRecord) ProtoMessage()    {}
+func (*ThreadInviteRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{8}
 }
-func (m *ThreadFlag) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ThreadFlag.Unmarshal(m, b)
+func (m *ThreadInviteRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThreadInviteRecord.Unmarshal(m, b)
 }
-func (m *ThreadFlag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ThreadFlag.Marshal(b, m, deterministic)
+func (m *ThreadInviteRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThreadInviteRecord.Marshal(b, m, deterministic)
 }
-func (dst *ThreadFlag) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ThreadFlag.Merge(dst, src)
+func (dst *ThreadInviteRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThreadInviteRecord.Merge(dst, src)
 }
-func (m *ThreadFlag) XXX_Size() int {
-	return xxx_messageInfo_ThreadFlag.Size(m)
+func (m *ThreadInviteRecord) XXX_Size() int {
+	return xxx_messageInfo_ThreadInviteRecord.Size(m)
 }
-func (m *ThreadFlag) XXX_DiscardUnknown() {
-	xxx_messageInfo_ThreadFlag.DiscardUnknown(m)
+func (m *ThreadInviteRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThreadInviteRecord.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ThreadFlag proto.InternalMessageInfo
+var xxx_messageInfo_ThreadInviteRecord proto.InternalMessageInfo
 
-func (m *ThreadFlag) GetTarget() string {
+func (m *ThreadInviteRecord) GetThread() string {
 	if m != nil {
-		return m.Target
+		return m.Thread
 	}
 	return ""
 }
 
-type ThreadJoin struct {
-	Inviter              string   `protobuf:"bytes,1,opt,name=inviter,proto3" json:"inviter,omitempty"`
-	Username             string   `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	Inboxes              []string `protobuf:"bytes,3,rep,name=inboxes,proto3" json:"inboxes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *ThreadInviteRecord) GetInviter() string {
+	if m != nil {
+		return m.Inviter
+	}
+	return ""
 }
 
-func (m *ThreadJoin) Reset()         { *m = ThreadJoin{} }
-func (m *ThreadJoin) String() string { return proto.CompactTextString(m) }
-func (*ThreadJoin) ProtoMessage()    {}
-func (*ThreadJoin) Descriptor() ([]byte, []int) {
-	return fileDescriptor_thread_b1d52b2481e68edd, []int{6}
-}
+func (m *ThreadInviteRecord) GetInvitee() string {
+	if m != nil {
+		return m.Invitee
+	}
+	return ""
+}
+
+func (m *ThreadInviteRecord) GetNonce() string {
+	if m != nil {
+		return m.Nonce
+	}
+	return ""
+}
+
+func (m *ThreadInviteRecord) GetExpires() *timestamp.Timestamp {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
+}
+
+func (m *ThreadInviteRecord) GetMaxUses() int32 {
+	if m != nil {
+		return m.MaxUses
+	}
+	return 0
+}
+
+func (m *ThreadInviteRecord) GetSig() []byte {
+	if m != nil {
+		return m.Sig
+	}
+	return nil
+}
+
+type ThreadInviteKey struct {
+	Sk                   []byte               `protobuf:"bytes,1,opt,name=sk,proto3" json:"sk,omitempty"`
+	Date                 *timestamp.Timestamp `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Block                string               `protobuf:"bytes,3,opt,name=block,proto3" json:"block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ThreadInviteKey) Reset()         { *m = ThreadInviteKey{} }
+func (m *ThreadInviteKey) String() string { return proto.CompactTextString(m) }
+func (*ThreadInviteKey) ProtoMessage()    {}
+func (*ThreadInviteKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{9}
+}
+func (m *ThreadInviteKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThreadInviteKey.Unmarshal(m, b)
+}
+func (m *ThreadInviteKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThreadInviteKey.Marshal(b, m, deterministic)
+}
+func (dst *ThreadInviteKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThreadInviteKey.Merge(dst, src)
+}
+func (m *ThreadInviteKey) XXX_Size() int {
+	return xxx_messageInfo_ThreadInviteKey.Size(m)
+}
+func (m *ThreadInviteKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThreadInviteKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThreadInviteKey proto.InternalMessageInfo
+
+func (m *ThreadInviteKey) GetSk() []byte {
+	if m != nil {
+		return m.Sk
+	}
+	return nil
+}
+
+func (m *ThreadInviteKey) GetDate() *timestamp.Timestamp {
+	if m != nil {
+		return m.Date
+	}
+	return nil
+}
+
+func (m *ThreadInviteKey) GetBlock() string {
+	if m != nil {
+		return m.Block
+	}
+	return ""
+}
+
+type ThreadInviteLink struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Key                  []byte   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Name                 string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Inviter              string   `protobuf:"bytes,4,opt,name=inviter,proto3" json:"inviter,omitempty"`
+	Inboxes              []string `protobuf:"bytes,5,rep,name=inboxes,proto3" json:"inboxes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ThreadInviteLink) Reset()         { *m = ThreadInviteLink{} }
+func (m *ThreadInviteLink) String() string { return proto.CompactTextString(m) }
+func (*ThreadInviteLink) ProtoMessage()    {}
+func (*ThreadInviteLink) Descriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{10}
+}
+func (m *ThreadInviteLink) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThreadInviteLink.Unmarshal(m, b)
+}
+func (m *ThreadInviteLink) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThreadInviteLink.Marshal(b, m, deterministic)
+}
+func (dst *ThreadInviteLink) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThreadInviteLink.Merge(dst, src)
+}
+func (m *ThreadInviteLink) XXX_Size() int {
+	return xxx_messageInfo_ThreadInviteLink.Size(m)
+}
+func (m *ThreadInviteLink) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThreadInviteLink.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThreadInviteLink proto.InternalMessageInfo
+
+func (m *ThreadInviteLink) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ThreadInviteLink) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *ThreadInviteLink) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ThreadInviteLink) GetInviter() string {
+	if m != nil {
+		return m.Inviter
+	}
+	return ""
+}
+
+func (m *ThreadInviteLink) GetInboxes() []string {
+	if m != nil {
+		return m.Inboxes
+	}
+	return nil
+}
+
+type ThreadJoinDecision struct {
+	Target               string   `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	Approved             bool     `protobuf:"varint,2,opt,name=approved,proto3" json:"approved,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ThreadJoinDecision) Reset()         { *m = ThreadJoinDecision{} }
+func (m *ThreadJoinDecision) String() string { return proto.CompactTextString(m) }
+func (*ThreadJoinDecision) ProtoMessage()    {}
+func (*ThreadJoinDecision) Descriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{11}
+}
+func (m *ThreadJoinDecision) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThreadJoinDecision.Unmarshal(m, b)
+}
+func (m *ThreadJoinDecision) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThreadJoinDecision.Marshal(b, m, deterministic)
+}
+func (dst *ThreadJoinDecision) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThreadJoinDecision.Merge(dst, src)
+}
+func (m *ThreadJoinDecision) XXX_Size() int {
+	return xxx_messageInfo_ThreadJoinDecision.Size(m)
+}
+func (m *ThreadJoinDecision) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThreadJoinDecision.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThreadJoinDecision proto.InternalMessageInfo
+
+func (m *ThreadJoinDecision) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *ThreadJoinDecision) GetApproved() bool {
+	if m != nil {
+		return m.Approved
+	}
+	return false
+}
+
+type ThreadIgnore struct {
+	Target               string   `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ThreadIgnore) Reset()         { *m = ThreadIgnore{} }
+func (m *ThreadIgnore) String() string { return proto.CompactTextString(m) }
+func (*ThreadIgnore) ProtoMessage()    {}
+func (*ThreadIgnore) Descriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{12}
+}
+func (m *ThreadIgnore) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThreadIgnore.Unmarshal(m, b)
+}
+func (m *ThreadIgnore) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThreadIgnore.Marshal(b, m, deterministic)
+}
+func (dst *ThreadIgnore) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThreadIgnore.Merge(dst, src)
+}
+func (m *ThreadIgnore) XXX_Size() int {
+	return xxx_messageInfo_ThreadIgnore.Size(m)
+}
+func (m *ThreadIgnore) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThreadIgnore.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThreadIgnore proto.InternalMessageInfo
+
+func (m *ThreadIgnore) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+type ThreadFlag struct {
+	Target               string            `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	Reason               ThreadFlag_Reason `protobuf:"varint,2,opt,name=reason,proto3,enum=ThreadFlag_Reason" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ThreadFlag) Reset()         { *m = ThreadFlag{} }
+func (m *ThreadFlag) String() string { return proto.CompactTextString(m) }
+func (*ThreadFlag) ProtoMessage()    {}
+func (*ThreadFlag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{13}
+}
+func (m *ThreadFlag) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThreadFlag.Unmarshal(m, b)
+}
+func (m *ThreadFlag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThreadFlag.Marshal(b, m, deterministic)
+}
+func (dst *ThreadFlag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThreadFlag.Merge(dst, src)
+}
+func (m *ThreadFlag) XXX_Size() int {
+	return xxx_messageInfo_ThreadFlag.Size(m)
+}
+func (m *ThreadFlag) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThreadFlag.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThreadFlag proto.InternalMessageInfo
+
+func (m *ThreadFlag) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *ThreadFlag) GetReason() ThreadFlag_Reason {
+	if m != nil {
+		return m.Reason
+	}
+	return ThreadFlag_UNSPECIFIED
+}
+
+type ThreadUndo struct {
+	Target               string   `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ThreadUndo) Reset()         { *m = ThreadUndo{} }
+func (m *ThreadUndo) String() string { return proto.CompactTextString(m) }
+func (*ThreadUndo) ProtoMessage()    {}
+func (*ThreadUndo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{14}
+}
+func (m *ThreadUndo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThreadUndo.Unmarshal(m, b)
+}
+func (m *ThreadUndo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThreadUndo.Marshal(b, m, deterministic)
+}
+func (dst *ThreadUndo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThreadUndo.Merge(dst, src)
+}
+func (m *ThreadUndo) XXX_Size() int {
+	return xxx_messageInfo_ThreadUndo.Size(m)
+}
+func (m *ThreadUndo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThreadUndo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThreadUndo proto.InternalMessageInfo
+
+func (m *ThreadUndo) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+type ThreadJoin struct {
+	Inviter              string              `protobuf:"bytes,1,opt,name=inviter,proto3" json:"inviter,omitempty"`
+	Username             string              `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Inboxes              []string            `protobuf:"bytes,3,rep,name=inboxes,proto3" json:"inboxes,omitempty"`
+	Invite               string              `protobuf:"bytes,4,opt,name=invite,proto3" json:"invite,omitempty"`
+	Record               *ThreadInviteRecord `protobuf:"bytes,5,opt,name=record,proto3" json:"record,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ThreadJoin) Reset()         { *m = ThreadJoin{} }
+func (m *ThreadJoin) String() string { return proto.CompactTextString(m) }
+func (*ThreadJoin) ProtoMessage()    {}
+func (*ThreadJoin) Descriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{15}
+}
 func (m *ThreadJoin) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ThreadJoin.Unmarshal(m, b)
 }
@@ -433,6 +1141,20 @@ func (m *ThreadJoin) GetInboxes() []string {
 	return nil
 }
 
+func (m *ThreadJoin) GetInvite() string {
+	if m != nil {
+		return m.Invite
+	}
+	return ""
+}
+
+func (m *ThreadJoin) GetRecord() *ThreadInviteRecord {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
 type ThreadAnnounce struct {
 	Username             string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
 	Inboxes              []string `protobuf:"bytes,2,rep,name=inboxes,proto3" json:"inboxes,omitempty"`
@@ -445,7 +1167,7 @@ func (m *ThreadAnnounce) Reset()         { *m = ThreadAnnounce{} }
 func (m *ThreadAnnounce) String() string { return proto.CompactTextString(m) }
 func (*ThreadAnnounce) ProtoMessage()    {}
 func (*ThreadAnnounce) Descriptor() ([]byte, []int) {
-	return fileDescriptor_thread_b1d52b2481e68edd, []int{7}
+	return fileDescriptor_thread_474fafe899102781, []int{16}
 }
 func (m *ThreadAnnounce) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ThreadAnnounce.Unmarshal(m, b)
@@ -481,6 +1203,8 @@ func (m *ThreadAnnounce) GetInboxes() []string {
 
 type ThreadMessage struct {
 	Body                 string   `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
+	ReplyTo              string   `protobuf:"bytes,2,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`
+	Ttl                  int64    `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -490,7 +1214,7 @@ func (m *ThreadMessage) Reset()         { *m = ThreadMessage{} }
 func (m *ThreadMessage) String() string { return proto.CompactTextString(m) }
 func (*ThreadMessage) ProtoMessage()    {}
 func (*ThreadMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_thread_b1d52b2481e68edd, []int{8}
+	return fileDescriptor_thread_474fafe899102781, []int{17}
 }
 func (m *ThreadMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ThreadMessage.Unmarshal(m, b)
@@ -517,10 +1241,25 @@ func (m *ThreadMessage) GetBody() string {
 	return ""
 }
 
+func (m *ThreadMessage) GetReplyTo() string {
+	if m != nil {
+		return m.ReplyTo
+	}
+	return ""
+}
+
+func (m *ThreadMessage) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
 type ThreadFiles struct {
 	Target               string            `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
 	Body                 string            `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
 	Keys                 map[string]string `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Ttl                  int64             `protobuf:"varint,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -530,7 +1269,7 @@ func (m *ThreadFiles) Reset()         { *m = ThreadFiles{} }
 func (m *ThreadFiles) String() string { return proto.CompactTextString(m) }
 func (*ThreadFiles) ProtoMessage()    {}
 func (*ThreadFiles) Descriptor() ([]byte, []int) {
-	return fileDescriptor_thread_b1d52b2481e68edd, []int{9}
+	return fileDescriptor_thread_474fafe899102781, []int{18}
 }
 func (m *ThreadFiles) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ThreadFiles.Unmarshal(m, b)
@@ -571,9 +1310,17 @@ func (m *ThreadFiles) GetKeys() map[string]string {
 	return nil
 }
 
+func (m *ThreadFiles) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
 type ThreadComment struct {
 	Target               string   `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
 	Body                 string   `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	ReplyTo              string   `protobuf:"bytes,3,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -583,7 +1330,7 @@ func (m *ThreadComment) Reset()         { *m = ThreadComment{} }
 func (m *ThreadComment) String() string { return proto.CompactTextString(m) }
 func (*ThreadComment) ProtoMessage()    {}
 func (*ThreadComment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_thread_b1d52b2481e68edd, []int{10}
+	return fileDescriptor_thread_474fafe899102781, []int{19}
 }
 func (m *ThreadComment) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ThreadComment.Unmarshal(m, b)
@@ -617,8 +1364,16 @@ func (m *ThreadComment) GetBody() string {
 	return ""
 }
 
+func (m *ThreadComment) GetReplyTo() string {
+	if m != nil {
+		return m.ReplyTo
+	}
+	return ""
+}
+
 type ThreadLike struct {
 	Target               string   `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	Reaction             string   `protobuf:"bytes,2,opt,name=reaction,proto3" json:"reaction,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -628,7 +1383,7 @@ func (m *ThreadLike) Reset()         { *m = ThreadLike{} }
 func (m *ThreadLike) String() string { return proto.CompactTextString(m) }
 func (*ThreadLike) ProtoMessage()    {}
 func (*ThreadLike) Descriptor() ([]byte, []int) {
-	return fileDescriptor_thread_b1d52b2481e68edd, []int{11}
+	return fileDescriptor_thread_474fafe899102781, []int{20}
 }
 func (m *ThreadLike) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ThreadLike.Unmarshal(m, b)
@@ -655,13 +1410,491 @@ func (m *ThreadLike) GetTarget() string {
 	return ""
 }
 
+func (m *ThreadLike) GetReaction() string {
+	if m != nil {
+		return m.Reaction
+	}
+	return ""
+}
+
+type ThreadEdit struct {
+	Target               string   `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	Body                 string   `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ThreadEdit) Reset()         { *m = ThreadEdit{} }
+func (m *ThreadEdit) String() string { return proto.CompactTextString(m) }
+func (*ThreadEdit) ProtoMessage()    {}
+func (*ThreadEdit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{21}
+}
+func (m *ThreadEdit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThreadEdit.Unmarshal(m, b)
+}
+func (m *ThreadEdit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThreadEdit.Marshal(b, m, deterministic)
+}
+func (dst *ThreadEdit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThreadEdit.Merge(dst, src)
+}
+func (m *ThreadEdit) XXX_Size() int {
+	return xxx_messageInfo_ThreadEdit.Size(m)
+}
+func (m *ThreadEdit) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThreadEdit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThreadEdit proto.InternalMessageInfo
+
+func (m *ThreadEdit) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *ThreadEdit) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
+type ThreadMeta struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description          string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Cover                string   `protobuf:"bytes,3,opt,name=cover,proto3" json:"cover,omitempty"`
+	Ttl                  int64    `protobuf:"varint,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	FlagThreshold        int32    `protobuf:"varint,5,opt,name=flag_threshold,json=flagThreshold,proto3" json:"flag_threshold,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ThreadMeta) Reset()         { *m = ThreadMeta{} }
+func (m *ThreadMeta) String() string { return proto.CompactTextString(m) }
+func (*ThreadMeta) ProtoMessage()    {}
+func (*ThreadMeta) Descriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{22}
+}
+func (m *ThreadMeta) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThreadMeta.Unmarshal(m, b)
+}
+func (m *ThreadMeta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThreadMeta.Marshal(b, m, deterministic)
+}
+func (dst *ThreadMeta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThreadMeta.Merge(dst, src)
+}
+func (m *ThreadMeta) XXX_Size() int {
+	return xxx_messageInfo_ThreadMeta.Size(m)
+}
+func (m *ThreadMeta) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThreadMeta.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThreadMeta proto.InternalMessageInfo
+
+func (m *ThreadMeta) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ThreadMeta) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *ThreadMeta) GetCover() string {
+	if m != nil {
+		return m.Cover
+	}
+	return ""
+}
+
+func (m *ThreadMeta) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *ThreadMeta) GetFlagThreshold() int32 {
+	if m != nil {
+		return m.FlagThreshold
+	}
+	return 0
+}
+
+type ThreadCheckpoint struct {
+	Members              []*ThreadCheckpoint_Member `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	Heads                []string                   `protobuf:"bytes,2,rep,name=heads,proto3" json:"heads,omitempty"`
+	State                []string                   `protobuf:"bytes,3,rep,name=state,proto3" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *ThreadCheckpoint) Reset()         { *m = ThreadCheckpoint{} }
+func (m *ThreadCheckpoint) String() string { return proto.CompactTextString(m) }
+func (*ThreadCheckpoint) ProtoMessage()    {}
+func (*ThreadCheckpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{23}
+}
+func (m *ThreadCheckpoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThreadCheckpoint.Unmarshal(m, b)
+}
+func (m *ThreadCheckpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThreadCheckpoint.Marshal(b, m, deterministic)
+}
+func (dst *ThreadCheckpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThreadCheckpoint.Merge(dst, src)
+}
+func (m *ThreadCheckpoint) XXX_Size() int {
+	return xxx_messageInfo_ThreadCheckpoint.Size(m)
+}
+func (m *ThreadCheckpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThreadCheckpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThreadCheckpoint proto.InternalMessageInfo
+
+func (m *ThreadCheckpoint) GetMembers() []*ThreadCheckpoint_Member {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+func (m *ThreadCheckpoint) GetHeads() []string {
+	if m != nil {
+		return m.Heads
+	}
+	return nil
+}
+
+func (m *ThreadCheckpoint) GetState() []string {
+	if m != nil {
+		return m.State
+	}
+	return nil
+}
+
+type ThreadCheckpoint_Member struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Username             string   `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Inboxes              []string `protobuf:"bytes,4,rep,name=inboxes,proto3" json:"inboxes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ThreadCheckpoint_Member) Reset()         { *m = ThreadCheckpoint_Member{} }
+func (m *ThreadCheckpoint_Member) String() string { return proto.CompactTextString(m) }
+func (*ThreadCheckpoint_Member) ProtoMessage()    {}
+func (*ThreadCheckpoint_Member) Descriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{23, 0}
+}
+func (m *ThreadCheckpoint_Member) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThreadCheckpoint_Member.Unmarshal(m, b)
+}
+func (m *ThreadCheckpoint_Member) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThreadCheckpoint_Member.Marshal(b, m, deterministic)
+}
+func (dst *ThreadCheckpoint_Member) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThreadCheckpoint_Member.Merge(dst, src)
+}
+func (m *ThreadCheckpoint_Member) XXX_Size() int {
+	return xxx_messageInfo_ThreadCheckpoint_Member.Size(m)
+}
+func (m *ThreadCheckpoint_Member) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThreadCheckpoint_Member.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThreadCheckpoint_Member proto.InternalMessageInfo
+
+func (m *ThreadCheckpoint_Member) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ThreadCheckpoint_Member) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ThreadCheckpoint_Member) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *ThreadCheckpoint_Member) GetInboxes() []string {
+	if m != nil {
+		return m.Inboxes
+	}
+	return nil
+}
+
+type ThreadExternalInvite struct {
+	Invite               string               `protobuf:"bytes,1,opt,name=invite,proto3" json:"invite,omitempty"`
+	Expires              *timestamp.Timestamp `protobuf:"bytes,2,opt,name=expires,proto3" json:"expires,omitempty"`
+	MaxUses              int32                `protobuf:"varint,3,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	Revoked              bool                 `protobuf:"varint,4,opt,name=revoked,proto3" json:"revoked,omitempty"`
+	Record               *ThreadInviteRecord  `protobuf:"bytes,5,opt,name=record,proto3" json:"record,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ThreadExternalInvite) Reset()         { *m = ThreadExternalInvite{} }
+func (m *ThreadExternalInvite) String() string { return proto.CompactTextString(m) }
+func (*ThreadExternalInvite) ProtoMessage()    {}
+func (*ThreadExternalInvite) Descriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{24}
+}
+func (m *ThreadExternalInvite) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThreadExternalInvite.Unmarshal(m, b)
+}
+func (m *ThreadExternalInvite) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThreadExternalInvite.Marshal(b, m, deterministic)
+}
+func (dst *ThreadExternalInvite) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThreadExternalInvite.Merge(dst, src)
+}
+func (m *ThreadExternalInvite) XXX_Size() int {
+	return xxx_messageInfo_ThreadExternalInvite.Size(m)
+}
+func (m *ThreadExternalInvite) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThreadExternalInvite.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThreadExternalInvite proto.InternalMessageInfo
+
+func (m *ThreadExternalInvite) GetInvite() string {
+	if m != nil {
+		return m.Invite
+	}
+	return ""
+}
+
+func (m *ThreadExternalInvite) GetExpires() *timestamp.Timestamp {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
+}
+
+func (m *ThreadExternalInvite) GetMaxUses() int32 {
+	if m != nil {
+		return m.MaxUses
+	}
+	return 0
+}
+
+func (m *ThreadExternalInvite) GetRevoked() bool {
+	if m != nil {
+		return m.Revoked
+	}
+	return false
+}
+
+func (m *ThreadExternalInvite) GetRecord() *ThreadInviteRecord {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
+type ThreadRead struct {
+	Target               string   `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ThreadRead) Reset()         { *m = ThreadRead{} }
+func (m *ThreadRead) String() string { return proto.CompactTextString(m) }
+func (*ThreadRead) ProtoMessage()    {}
+func (*ThreadRead) Descriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{25}
+}
+func (m *ThreadRead) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThreadRead.Unmarshal(m, b)
+}
+func (m *ThreadRead) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThreadRead.Marshal(b, m, deterministic)
+}
+func (dst *ThreadRead) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThreadRead.Merge(dst, src)
+}
+func (m *ThreadRead) XXX_Size() int {
+	return xxx_messageInfo_ThreadRead.Size(m)
+}
+func (m *ThreadRead) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThreadRead.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThreadRead proto.InternalMessageInfo
+
+func (m *ThreadRead) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+type ThreadKey struct {
+	Keys                 map[string][]byte `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ThreadKey) Reset()         { *m = ThreadKey{} }
+func (m *ThreadKey) String() string { return proto.CompactTextString(m) }
+func (*ThreadKey) ProtoMessage()    {}
+func (*ThreadKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{26}
+}
+func (m *ThreadKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThreadKey.Unmarshal(m, b)
+}
+func (m *ThreadKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThreadKey.Marshal(b, m, deterministic)
+}
+func (dst *ThreadKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThreadKey.Merge(dst, src)
+}
+func (m *ThreadKey) XXX_Size() int {
+	return xxx_messageInfo_ThreadKey.Size(m)
+}
+func (m *ThreadKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThreadKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThreadKey proto.InternalMessageInfo
+
+func (m *ThreadKey) GetKeys() map[string][]byte {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+type ThreadKick struct {
+	Target               string   `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ThreadKick) Reset()         { *m = ThreadKick{} }
+func (m *ThreadKick) String() string { return proto.CompactTextString(m) }
+func (*ThreadKick) ProtoMessage()    {}
+func (*ThreadKick) Descriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{27}
+}
+func (m *ThreadKick) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThreadKick.Unmarshal(m, b)
+}
+func (m *ThreadKick) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThreadKick.Marshal(b, m, deterministic)
+}
+func (dst *ThreadKick) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThreadKick.Merge(dst, src)
+}
+func (m *ThreadKick) XXX_Size() int {
+	return xxx_messageInfo_ThreadKick.Size(m)
+}
+func (m *ThreadKick) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThreadKick.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThreadKick proto.InternalMessageInfo
+
+func (m *ThreadKick) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+type ThreadRole struct {
+	Target               string          `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	Role                 ThreadRole_Role `protobuf:"varint,2,opt,name=role,proto3,enum=ThreadRole_Role" json:"role,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ThreadRole) Reset()         { *m = ThreadRole{} }
+func (m *ThreadRole) String() string { return proto.CompactTextString(m) }
+func (*ThreadRole) ProtoMessage()    {}
+func (*ThreadRole) Descriptor() ([]byte, []int) {
+	return fileDescriptor_thread_474fafe899102781, []int{28}
+}
+func (m *ThreadRole) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ThreadRole.Unmarshal(m, b)
+}
+func (m *ThreadRole) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ThreadRole.Marshal(b, m, deterministic)
+}
+func (dst *ThreadRole) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThreadRole.Merge(dst, src)
+}
+func (m *ThreadRole) XXX_Size() int {
+	return xxx_messageInfo_ThreadRole.Size(m)
+}
+func (m *ThreadRole) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThreadRole.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThreadRole proto.InternalMessageInfo
+
+func (m *ThreadRole) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *ThreadRole) GetRole() ThreadRole_Role {
+	if m != nil {
+		return m.Role
+	}
+	return ThreadRole_DEFAULT
+}
+
 func init() {
 	proto.RegisterType((*ThreadEnvelope)(nil), "ThreadEnvelope")
+	proto.RegisterType((*ThreadSignalEnvelope)(nil), "ThreadSignalEnvelope")
+	proto.RegisterType((*ThreadSignal)(nil), "ThreadSignal")
+	proto.RegisterType((*ThreadJoinRequest)(nil), "ThreadJoinRequest")
+	proto.RegisterType((*ThreadJoinDenial)(nil), "ThreadJoinDenial")
 	proto.RegisterType((*ThreadBlock)(nil), "ThreadBlock")
 	proto.RegisterType((*ThreadBlockHeader)(nil), "ThreadBlockHeader")
 	proto.RegisterType((*ThreadInvite)(nil), "ThreadInvite")
+	proto.RegisterType((*ThreadInviteRecord)(nil), "ThreadInviteRecord")
+	proto.RegisterType((*ThreadInviteKey)(nil), "ThreadInviteKey")
+	proto.RegisterType((*ThreadInviteLink)(nil), "ThreadInviteLink")
+	proto.RegisterType((*ThreadJoinDecision)(nil), "ThreadJoinDecision")
 	proto.RegisterType((*ThreadIgnore)(nil), "ThreadIgnore")
 	proto.RegisterType((*ThreadFlag)(nil), "ThreadFlag")
+	proto.RegisterType((*ThreadUndo)(nil), "ThreadUndo")
 	proto.RegisterType((*ThreadJoin)(nil), "ThreadJoin")
 	proto.RegisterType((*ThreadAnnounce)(nil), "ThreadAnnounce")
 	proto.RegisterType((*ThreadMessage)(nil), "ThreadMessage")
@@ -669,52 +1902,123 @@ func init() {
 	proto.RegisterMapType((map[string]string)(nil), "ThreadFiles.KeysEntry")
 	proto.RegisterType((*ThreadComment)(nil), "ThreadComment")
 	proto.RegisterType((*ThreadLike)(nil), "ThreadLike")
+	proto.RegisterType((*ThreadEdit)(nil), "ThreadEdit")
+	proto.RegisterType((*ThreadMeta)(nil), "ThreadMeta")
+	proto.RegisterType((*ThreadCheckpoint)(nil), "ThreadCheckpoint")
+	proto.RegisterType((*ThreadCheckpoint_Member)(nil), "ThreadCheckpoint.Member")
+	proto.RegisterType((*ThreadExternalInvite)(nil), "ThreadExternalInvite")
+	proto.RegisterType((*ThreadRead)(nil), "ThreadRead")
+	proto.RegisterType((*ThreadKey)(nil), "ThreadKey")
+	proto.RegisterMapType((map[string][]byte)(nil), "ThreadKey.KeysEntry")
+	proto.RegisterType((*ThreadKick)(nil), "ThreadKick")
+	proto.RegisterType((*ThreadRole)(nil), "ThreadRole")
+	proto.RegisterEnum("ThreadSignal_Type", ThreadSignal_Type_name, ThreadSignal_Type_value)
 	proto.RegisterEnum("ThreadBlock_Type", ThreadBlock_Type_name, ThreadBlock_Type_value)
-}
-
-func init() { proto.RegisterFile("thread.proto", fileDescriptor_thread_b1d52b2481e68edd) }
-
-var fileDescriptor_thread_b1d52b2481e68edd = []byte{
-	// 649 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x4d, 0x6f, 0xda, 0x4c,
-	0x10, 0x7e, 0x6d, 0x1c, 0x02, 0x03, 0x6f, 0xe4, 0xac, 0xa2, 0x88, 0x17, 0xbd, 0x6a, 0x23, 0xf7,
-	0x43, 0x51, 0x0e, 0x8e, 0x44, 0x0f, 0xad, 0xda, 0x13, 0x89, 0x0c, 0x25, 0xe1, 0x43, 0x72, 0x68,
-	0x0e, 0x55, 0x2e, 0x0b, 0x4c, 0xb1, 0x05, 0xec, 0x5a, 0xde, 0x25, 0x8a, 0x7f, 0x40, 0xcf, 0xbd,
-	0xf6, 0xd4, 0xdf, 0x5a, 0xed, 0xda, 0x4b, 0x48, 0x23, 0x0e, 0xbd, 0xcd, 0x33, 0xf3, 0xcc, 0x33,
-	0x1f, 0x3b, 0x0b, 0x75, 0x19, 0xa5, 0x48, 0x67, 0x7e, 0x92, 0x72, 0xc9, 0x9b, 0xff, 0xcd, 0x39,
-	0x9f, 0x2f, 0xf1, 0x5c, 0xa3, 0xc9, 0xfa, 0xdb, 0x39, 0x65, 0x59, 0x11, 0x7a, 0xf9, 0x67, 0x48,
-	0xc6, 0x2b, 0x14, 0x92, 0xae, 0x92, 0x9c, 0xe0, 0xdd, 0xc1, 0xc1, 0x58, 0x6b, 0x05, 0xec, 0x1e,
-	0x97, 0x3c, 0x41, 0x72, 0x0c, 0xe5, 0x5c, 0xbd, 0x61, 0x9d, 0x58, 0xa7, 0xd5, 0xb0, 0x40, 0x84,
-	0x80, 0x13, 0x51, 0x11, 0x35, 0x6c, 0xed, 0xd5, 0x36, 0x79, 0x01, 0x30, 0x8d, 0x93, 0x08, 0x53,
-	0x89, 0x0f, 0xb2, 0x51, 0x3a, 0xb1, 0x4e, 0xeb, 0xe1, 0x96, 0xc7, 0xfb, 0x69, 0x43, 0x2d, 0x97,
-	0xbf, 0x58, 0xf2, 0xe9, 0x82, 0x9c, 0x41, 0x39, 0x42, 0x3a, 0xc3, 0x54, 0x6b, 0xd7, 0x5a, 0xc4,
-	0xdf, 0x8a, 0x7e, 0xd6, 0x91, 0xb0, 0x60, 0x90, 0x37, 0xe0, 0xc8, 0x2c, 0x41, 0x5d, 0xef, 0xa0,
-	0x75, 0xb8, 0xcd, 0xf4, 0xc7, 0x59, 0x82, 0xa1, 0x0e, 0x13, 0x1f, 0xf6, 0x13, 0x9a, 0x2d, 0x39,
-	0x9d, 0xe9, 0xfa, 0xb5, 0xd6, 0x91, 0x9f, 0xcf, 0xec, 0x9b, 0x99, 0xfd, 0x36, 0xcb, 0x42, 0x43,
-	0xf2, 0xbe, 0x5b, 0xe0, 0xa8, 0x74, 0x52, 0x85, 0xbd, 0x41, 0x10, 0x76, 0x03, 0xf7, 0x1f, 0x02,
-	0x50, 0xee, 0x75, 0x87, 0xa3, 0x30, 0x70, 0x2d, 0x52, 0x01, 0xa7, 0xd3, 0x6f, 0x77, 0x5d, 0x5b,
-	0x59, 0x57, 0xa3, 0xde, 0xd0, 0x2d, 0x91, 0x3a, 0x54, 0xda, 0xc3, 0xe1, 0xe8, 0xcb, 0xf0, 0x32,
-	0x70, 0x1d, 0x95, 0xd8, 0x0f, 0xda, 0xb7, 0x81, 0xbb, 0x47, 0x6a, 0xb0, 0x3f, 0x08, 0x6e, 0x6e,
-	0xda, 0xdd, 0xc0, 0x2d, 0x2b, 0x7f, 0xa7, 0xd7, 0x0f, 0x6e, 0xdc, 0x7d, 0xe5, 0xbf, 0x1c, 0x0d,
-	0x06, 0xc1, 0x70, 0xec, 0x56, 0x94, 0x4e, 0xbf, 0x77, 0x1d, 0xb8, 0x55, 0x5d, 0x67, 0x78, 0xdb,
-	0x1b, 0x07, 0x6e, 0xcb, 0xfb, 0x61, 0xc1, 0xe1, 0xb3, 0xe1, 0x89, 0x0f, 0xce, 0x8c, 0x4a, 0x2c,
-	0xd6, 0xd3, 0x7c, 0x36, 0xca, 0xd8, 0x3c, 0x5f, 0xa8, 0x79, 0xa4, 0xa1, 0xa6, 0x4f, 0x91, 0x49,
-	0xd1, 0xb0, 0x4f, 0x4a, 0xa7, 0xd5, 0xd0, 0x40, 0xf5, 0x8c, 0x74, 0x2d, 0x23, 0x9e, 0xea, 0xb5,
-	0x54, 0xc3, 0x02, 0xa9, 0x0c, 0x3a, 0x9b, 0xa5, 0x28, 0x44, 0xc3, 0xd1, 0x01, 0x03, 0xbd, 0x08,
-	0xea, 0x79, 0x43, 0x3d, 0x76, 0x1f, 0x4b, 0x24, 0x07, 0x60, 0x8b, 0x85, 0xee, 0xa4, 0x1e, 0xda,
-	0x62, 0xa1, 0x0e, 0x80, 0xd1, 0x15, 0x9a, 0x03, 0x50, 0xb6, 0xaa, 0x22, 0xa6, 0x11, 0xae, 0xa8,
-	0xa9, 0x92, 0x23, 0xf2, 0x3f, 0x54, 0x63, 0x16, 0xcb, 0x98, 0x4a, 0x9e, 0x16, 0x75, 0x1e, 0x1d,
-	0xde, 0xdb, 0x4d, 0xa5, 0x39, 0xe3, 0x69, 0x7e, 0x72, 0x34, 0x9d, 0xa3, 0xdc, 0x9c, 0x9c, 0x46,
-	0xde, 0x6b, 0x80, 0x9c, 0xd7, 0x59, 0xd2, 0xf9, 0x4e, 0xd6, 0x9d, 0x61, 0x5d, 0xf1, 0x98, 0xa9,
-	0xf9, 0x62, 0xdd, 0x7f, 0x5a, 0xd0, 0x0c, 0x24, 0x4d, 0xa8, 0xac, 0x05, 0xa6, 0x5b, 0x33, 0x6c,
-	0x70, 0x9e, 0x35, 0xe1, 0x0f, 0x28, 0x1a, 0xa5, 0x7c, 0x8f, 0x05, 0xf4, 0x3a, 0xe6, 0x83, 0xb4,
-	0x19, 0xe3, 0x6b, 0x36, 0xc5, 0x27, 0x3a, 0xd6, 0x6e, 0x1d, 0xfb, 0xa9, 0xce, 0x2b, 0xf8, 0x37,
-	0xd7, 0x19, 0xa0, 0x10, 0x74, 0x8e, 0x6a, 0x9d, 0x13, 0x3e, 0xcb, 0x0a, 0x09, 0x6d, 0x7b, 0xbf,
-	0x2c, 0xf3, 0x5f, 0x3a, 0xf1, 0x12, 0xc5, 0xae, 0x91, 0x37, 0xb9, 0xf6, 0x63, 0x2e, 0x39, 0x03,
-	0x67, 0x81, 0x59, 0xde, 0x7f, 0xad, 0x75, 0xec, 0x6f, 0xe9, 0xf8, 0xd7, 0x98, 0x89, 0x80, 0xc9,
-	0x34, 0x0b, 0x35, 0xa7, 0xf9, 0x1e, 0xaa, 0x1b, 0x17, 0x71, 0xa1, 0xb4, 0x40, 0xd3, 0x87, 0x32,
-	0xc9, 0x11, 0xec, 0xdd, 0xd3, 0xe5, 0xda, 0xac, 0x29, 0x07, 0x1f, 0xed, 0x0f, 0x96, 0xf7, 0xc9,
-	0x4c, 0x71, 0xc9, 0x57, 0x2b, 0x64, 0xf2, 0x6f, 0x3a, 0x7c, 0x7c, 0xce, 0x7e, 0xbc, 0xd8, 0xf9,
-	0xe8, 0x17, 0xce, 0x57, 0x3b, 0x99, 0x4c, 0xca, 0xfa, 0xe4, 0xdf, 0xfd, 0x0e, 0x00, 0x00, 0xff,
-	0xff, 0xe5, 0xeb, 0xa5, 0xc8, 0xea, 0x04, 0x00, 0x00,
+	proto.RegisterEnum("ThreadFlag_Reason", ThreadFlag_Reason_name, ThreadFlag_Reason_value)
+	proto.RegisterEnum("ThreadRole_Role", ThreadRole_Role_name, ThreadRole_Role_value)
+}
+
+func init() { proto.RegisterFile("thread.proto", fileDescriptor_thread_474fafe899102781) }
+
+var fileDescriptor_thread_474fafe899102781 = []byte{
+	// 1581 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0xcf, 0x6e, 0xdb, 0x46,
+	0x13, 0x0f, 0x49, 0xfd, 0x5d, 0xc9, 0xf6, 0x7a, 0x63, 0x04, 0x8a, 0xf1, 0xe1, 0xfb, 0x0c, 0x22,
+	0x09, 0x8c, 0x7c, 0x05, 0x03, 0xb8, 0x05, 0x1a, 0xf4, 0x50, 0x54, 0x91, 0xd6, 0x36, 0x23, 0x89,
+	0x12, 0x56, 0x54, 0xda, 0xb4, 0x05, 0x0c, 0x5a, 0xda, 0x48, 0x84, 0x28, 0x92, 0x25, 0x69, 0xc3,
+	0x02, 0x7a, 0xea, 0xb9, 0x97, 0x1e, 0xfa, 0x00, 0xbd, 0xf5, 0x52, 0xa0, 0x6f, 0xd0, 0x5b, 0x1f,
+	0xa0, 0xb7, 0x02, 0x7d, 0x98, 0x62, 0x77, 0xb9, 0x14, 0x65, 0x47, 0x89, 0x9d, 0x8b, 0xb0, 0xb3,
+	0x33, 0x9c, 0xbf, 0xbf, 0x99, 0x59, 0x81, 0x7a, 0x32, 0x8b, 0xa8, 0x33, 0x31, 0xc2, 0x28, 0x48,
+	0x82, 0xfd, 0x87, 0xd3, 0x20, 0x98, 0x7a, 0xf4, 0x19, 0xa7, 0xce, 0x2f, 0xde, 0x3c, 0x73, 0xfc,
+	0x65, 0xca, 0xfa, 0xdf, 0x75, 0x56, 0xe2, 0x2e, 0x68, 0x9c, 0x38, 0x8b, 0x50, 0x08, 0xe8, 0xdf,
+	0x82, 0x6d, 0x9b, 0xeb, 0xc2, 0xfe, 0x25, 0xf5, 0x82, 0x90, 0xa2, 0x07, 0xa0, 0x24, 0xb4, 0x37,
+	0x94, 0x03, 0xe5, 0xb0, 0x4a, 0x52, 0x0a, 0x21, 0x50, 0x98, 0x39, 0xf1, 0xac, 0xa1, 0xf2, 0x5b,
+	0x7e, 0x46, 0xff, 0x05, 0x60, 0xec, 0x86, 0x33, 0x1a, 0x25, 0xf4, 0x2a, 0x69, 0x68, 0x07, 0xca,
+	0x61, 0x9d, 0xe4, 0x6e, 0x74, 0x0b, 0xec, 0x09, 0xed, 0x43, 0x77, 0xea, 0x3b, 0xde, 0x7b, 0x6d,
+	0xac, 0xeb, 0x53, 0x6f, 0xe8, 0xfb, 0x55, 0x01, 0xf5, 0xbc, 0x42, 0xf4, 0x04, 0x14, 0x92, 0x65,
+	0x48, 0xb9, 0x9a, 0xed, 0x23, 0x64, 0xe4, 0x99, 0x86, 0xbd, 0x0c, 0x29, 0xe1, 0x7c, 0xe6, 0xfc,
+	0xc4, 0x49, 0x1c, 0xe9, 0x3c, 0x3b, 0x23, 0x83, 0xdf, 0x51, 0xee, 0x76, 0xed, 0x68, 0xdf, 0x10,
+	0xa9, 0x32, 0x64, 0xaa, 0x0c, 0x5b, 0xa6, 0x8a, 0xcb, 0x53, 0xfd, 0x23, 0x50, 0x60, 0x1a, 0x11,
+	0x00, 0x25, 0xfb, 0xf5, 0xc0, 0xb4, 0x4e, 0xe0, 0x3d, 0x54, 0x07, 0x95, 0x01, 0xc1, 0x43, 0x6c,
+	0xb5, 0x30, 0x54, 0x18, 0xa7, 0x35, 0x22, 0xc3, 0x3e, 0x81, 0xaa, 0xfe, 0x87, 0x02, 0x76, 0x85,
+	0x37, 0x2f, 0x03, 0xd7, 0x27, 0xf4, 0xbb, 0x0b, 0x1a, 0x27, 0x1b, 0x03, 0xdf, 0x07, 0x95, 0x8b,
+	0x98, 0x46, 0xbe, 0xb3, 0xa0, 0xa9, 0x8f, 0x19, 0x8d, 0x1a, 0xa0, 0xec, 0x4c, 0x26, 0x11, 0x8d,
+	0x63, 0xee, 0x6a, 0x95, 0x48, 0x92, 0x71, 0x5c, 0xff, 0x3c, 0xb8, 0xa2, 0x71, 0xa3, 0x70, 0xa0,
+	0x31, 0x4e, 0x4a, 0xb2, 0x78, 0xfd, 0x20, 0xa1, 0x8d, 0xa2, 0x88, 0x97, 0x9d, 0xb3, 0x78, 0x4b,
+	0xb7, 0x8c, 0xf7, 0x73, 0x00, 0x57, 0x01, 0xb4, 0xa9, 0xef, 0x3a, 0xde, 0xbb, 0xc0, 0xc1, 0xed,
+	0xa9, 0x2b, 0x7b, 0xfa, 0xcf, 0x1a, 0xa8, 0x09, 0x05, 0x2f, 0xbc, 0x60, 0x3c, 0x47, 0x4f, 0x41,
+	0x69, 0x46, 0x9d, 0x09, 0x8d, 0xf8, 0xb7, 0xb5, 0xac, 0x5a, 0x9c, 0x7b, 0xca, 0x39, 0x24, 0x95,
+	0x40, 0x8f, 0xd3, 0xba, 0xaa, 0xbc, 0xae, 0xbb, 0x79, 0xc9, 0x7c, 0x59, 0x0d, 0x50, 0x0e, 0x9d,
+	0xa5, 0x17, 0x38, 0x93, 0xb4, 0x8a, 0x7b, 0x37, 0xa2, 0x6a, 0xfa, 0x4b, 0x22, 0x85, 0xf4, 0x1f,
+	0xd4, 0xb4, 0x86, 0x55, 0x50, 0xec, 0x61, 0x72, 0x82, 0xe1, 0x3d, 0x56, 0x34, 0xf3, 0xc4, 0xea,
+	0x13, 0x56, 0xc0, 0x0a, 0x28, 0x1c, 0x77, 0x9b, 0x27, 0x50, 0x65, 0xa7, 0x97, 0x7d, 0xd3, 0x82,
+	0x1a, 0x2b, 0x71, 0xd3, 0xb2, 0xfa, 0x23, 0x56, 0xe2, 0x02, 0xfb, 0xb0, 0x8b, 0x9b, 0xaf, 0x30,
+	0x2c, 0xa2, 0x1a, 0x28, 0xf7, 0xf0, 0x70, 0xd8, 0x3c, 0xc1, 0xb0, 0xc4, 0xee, 0x8f, 0xcd, 0x2e,
+	0x1e, 0xc2, 0x32, 0xbb, 0x6f, 0xf5, 0x7b, 0x3d, 0x6c, 0xd9, 0xb0, 0xc2, 0xf4, 0x74, 0xcd, 0x0e,
+	0x86, 0x55, 0x54, 0x06, 0x5a, 0x07, 0xbf, 0x86, 0x80, 0x5d, 0x75, 0xcc, 0x56, 0x07, 0xd6, 0xd8,
+	0x89, 0xf4, 0xbb, 0x18, 0xd6, 0xd9, 0x09, 0xb7, 0x4d, 0x1b, 0x6e, 0xb1, 0x53, 0x0f, 0xdb, 0x4d,
+	0xb8, 0x8d, 0xb6, 0x01, 0x68, 0x9d, 0xe2, 0x56, 0x67, 0xd0, 0x37, 0x2d, 0x1b, 0xee, 0x70, 0x69,
+	0xdc, 0x6c, 0x43, 0x88, 0xee, 0x83, 0x1d, 0xfc, 0x95, 0x8d, 0x89, 0xd5, 0xec, 0x9e, 0x99, 0xd6,
+	0x2b, 0xd3, 0xc6, 0x70, 0x97, 0xb1, 0x47, 0x56, 0xbb, 0x0f, 0x11, 0xda, 0x05, 0x5b, 0xcc, 0xf7,
+	0xb3, 0x36, 0x6e, 0x99, 0x43, 0xb3, 0x6f, 0xc1, 0xfb, 0x3c, 0x48, 0x21, 0x78, 0xa4, 0xff, 0x95,
+	0x21, 0x33, 0x97, 0xf9, 0x0c, 0x1d, 0xca, 0xed, 0xd0, 0xc1, 0xb0, 0x17, 0x3a, 0x11, 0xf5, 0x93,
+	0xb8, 0xa1, 0x0a, 0xec, 0xa5, 0x24, 0xc3, 0x88, 0x73, 0x91, 0xcc, 0x82, 0x28, 0x85, 0x6b, 0x4a,
+	0xe5, 0x71, 0x5c, 0x58, 0xc7, 0x31, 0x04, 0x5a, 0xec, 0x4e, 0x39, 0x58, 0xeb, 0x84, 0x1d, 0xd1,
+	0x1e, 0x28, 0x8e, 0x99, 0x73, 0x1c, 0xac, 0x1a, 0x11, 0x04, 0x7a, 0x08, 0x2a, 0x21, 0xa5, 0xd1,
+	0x19, 0x13, 0x2e, 0x73, 0xe1, 0x32, 0xa3, 0x87, 0xee, 0x54, 0xff, 0x51, 0x95, 0x93, 0xc1, 0xf4,
+	0x2f, 0xdd, 0x84, 0xa2, 0x6d, 0xa0, 0xc6, 0x73, 0x1e, 0x4d, 0x9d, 0xa8, 0xf1, 0x9c, 0x23, 0x74,
+	0xd5, 0x5d, 0xfc, 0xcc, 0x3c, 0x8d, 0xc7, 0x33, 0xba, 0x70, 0xa4, 0xa7, 0x82, 0x42, 0xff, 0x01,
+	0x55, 0xd7, 0x77, 0x13, 0xd7, 0x49, 0x82, 0x28, 0xf5, 0x75, 0x75, 0x81, 0x1e, 0x81, 0xc2, 0x9c,
+	0x2e, 0xe3, 0x46, 0xf1, 0x40, 0x3b, 0xac, 0x1d, 0x41, 0x23, 0x6f, 0xb6, 0x43, 0x97, 0x84, 0x73,
+	0xd1, 0x27, 0xa0, 0x4c, 0xaf, 0x42, 0x37, 0xa2, 0xf1, 0x2d, 0x1a, 0x4e, 0x8a, 0xb2, 0x08, 0x17,
+	0xce, 0xd5, 0xd9, 0x45, 0x4c, 0x63, 0x1e, 0x61, 0x91, 0x94, 0x17, 0xce, 0xd5, 0x28, 0xa6, 0x31,
+	0xfa, 0x3f, 0x28, 0x45, 0x74, 0x1c, 0x44, 0x93, 0x46, 0x85, 0xeb, 0xbb, 0xbf, 0x66, 0x98, 0x70,
+	0x16, 0x49, 0x45, 0xf4, 0xbf, 0x15, 0x80, 0x6e, 0xb2, 0x37, 0xb6, 0x2f, 0x1f, 0x24, 0x4c, 0x2e,
+	0x4a, 0xf3, 0x23, 0xc9, 0x15, 0x87, 0xca, 0xe1, 0x93, 0x92, 0xac, 0x44, 0x7e, 0xe0, 0x8f, 0x69,
+	0x9a, 0x20, 0x41, 0xe4, 0xc3, 0x2e, 0x7e, 0x58, 0xd8, 0xa5, 0xf5, 0xb0, 0x53, 0x6c, 0x94, 0x33,
+	0x6c, 0xe8, 0x53, 0xb0, 0x73, 0x2d, 0xe5, 0x37, 0x8a, 0x2d, 0xc1, 0xac, 0xde, 0x12, 0xcc, 0x7b,
+	0xa0, 0x78, 0xce, 0xe1, 0x26, 0x62, 0x14, 0x84, 0xfe, 0x3d, 0x80, 0x79, 0x43, 0x5d, 0xd7, 0x9f,
+	0x33, 0x4b, 0xae, 0xcc, 0x9e, 0xea, 0x4e, 0x98, 0x7b, 0x73, 0xba, 0x4c, 0x57, 0x15, 0x3b, 0x66,
+	0x40, 0xd3, 0x72, 0x40, 0xcb, 0xe5, 0xb7, 0xf0, 0x96, 0xfc, 0x8a, 0x11, 0x5e, 0x5c, 0x1b, 0xe1,
+	0xfa, 0x29, 0x40, 0xf9, 0xf1, 0x3b, 0x76, 0x63, 0x37, 0xf0, 0x79, 0x05, 0x9d, 0x68, 0x4a, 0x93,
+	0xac, 0x82, 0x9c, 0x62, 0x0b, 0xc4, 0x09, 0xc3, 0x28, 0xb8, 0xa4, 0x13, 0xee, 0x4c, 0x85, 0x64,
+	0xb4, 0xfe, 0x24, 0x6b, 0x8d, 0xa9, 0x1f, 0x44, 0x74, 0x93, 0x0e, 0xfd, 0x37, 0x05, 0x00, 0x21,
+	0x78, 0xec, 0x39, 0xd3, 0x8d, 0xa6, 0x9e, 0x32, 0x20, 0x3a, 0x71, 0xe0, 0xa7, 0xd3, 0x19, 0x19,
+	0xab, 0x8f, 0x0c, 0xc2, 0x39, 0x24, 0x95, 0xd0, 0xbf, 0x01, 0x25, 0x71, 0x83, 0x76, 0x40, 0x6d,
+	0x64, 0x0d, 0x07, 0xb8, 0x65, 0x1e, 0x9b, 0xb8, 0x0d, 0xef, 0xb1, 0x79, 0x35, 0x1c, 0x34, 0x7b,
+	0x50, 0x61, 0xb3, 0xb3, 0xf9, 0x62, 0x34, 0xc4, 0x50, 0x65, 0xa3, 0xcb, 0xb4, 0x9a, 0x83, 0x01,
+	0xe9, 0x0f, 0x88, 0xd9, 0xb4, 0x31, 0xd4, 0xd0, 0x16, 0xa8, 0xb6, 0xfa, 0x83, 0xd7, 0xc4, 0x3c,
+	0x39, 0xb5, 0xc5, 0x00, 0xee, 0xdb, 0xa7, 0x98, 0xc0, 0xa2, 0xfe, 0x48, 0xba, 0x3b, 0xf2, 0x27,
+	0xc1, 0xc6, 0xa8, 0x7e, 0xc9, 0xa2, 0x62, 0x89, 0xcc, 0x97, 0x42, 0x59, 0x2f, 0xc5, 0x7b, 0x76,
+	0xb0, 0x2c, 0x93, 0xb6, 0xbe, 0x69, 0x1f, 0x80, 0x92, 0x50, 0x90, 0x56, 0x36, 0xa5, 0x72, 0xed,
+	0x5a, 0x7c, 0x7f, 0xbb, 0x1e, 0xcb, 0x57, 0x58, 0xd3, 0xf7, 0x83, 0x0b, 0xd6, 0x47, 0x79, 0x67,
+	0x94, 0xcd, 0xce, 0xa8, 0xeb, 0x98, 0x19, 0x80, 0x2d, 0xa1, 0xa7, 0x47, 0xe3, 0xd8, 0x99, 0xf2,
+	0x77, 0xcf, 0x79, 0x30, 0x59, 0xa6, 0x2a, 0xf8, 0x99, 0x35, 0x5b, 0x44, 0x43, 0x6f, 0x79, 0x96,
+	0x04, 0xb2, 0xdb, 0x39, 0x6d, 0x07, 0x0c, 0xcd, 0x49, 0xe2, 0x71, 0xe8, 0x6a, 0x84, 0x1d, 0xf5,
+	0xdf, 0x15, 0xb9, 0xc4, 0x8f, 0x5d, 0x4f, 0x84, 0xfb, 0x56, 0x50, 0x48, 0x43, 0x6a, 0xce, 0xd0,
+	0xd3, 0x74, 0x50, 0x6a, 0x7c, 0x50, 0x3e, 0x30, 0x72, 0x7a, 0x8c, 0x0e, 0x5d, 0xc6, 0xd8, 0x4f,
+	0x22, 0x39, 0x2e, 0x53, 0xcb, 0x85, 0xcc, 0xf2, 0xfe, 0xa7, 0xa0, 0x9a, 0x09, 0xc9, 0x36, 0x13,
+	0x36, 0xd9, 0x91, 0xb5, 0xec, 0xa5, 0xe3, 0x5d, 0xc8, 0x52, 0x09, 0xe2, 0x33, 0xf5, 0xb9, 0xa2,
+	0xbf, 0x92, 0x49, 0x68, 0x05, 0x8b, 0x05, 0xf5, 0x93, 0x3b, 0xf9, 0x9c, 0x4f, 0x8e, 0xb6, 0x96,
+	0x1c, 0xfd, 0x0b, 0x89, 0xa3, 0xae, 0x3b, 0xa7, 0xef, 0x6a, 0xc4, 0x88, 0x3a, 0xe3, 0xc4, 0x4d,
+	0xfb, 0xa3, 0x4a, 0x32, 0x5a, 0x7f, 0x2e, 0x35, 0xe0, 0x89, 0x7b, 0x27, 0xb7, 0xf4, 0x9f, 0x32,
+	0x10, 0xf7, 0x68, 0xe2, 0x64, 0x33, 0x46, 0xc9, 0xcd, 0x98, 0x03, 0x50, 0x9b, 0xd0, 0x78, 0x1c,
+	0xb9, 0x61, 0xce, 0x76, 0xfe, 0x8a, 0x2f, 0xd5, 0xe0, 0x92, 0xca, 0xbd, 0x2c, 0x88, 0x9b, 0x99,
+	0x47, 0x8f, 0xc1, 0xf6, 0x1b, 0xcf, 0x99, 0x9e, 0xb1, 0xe5, 0x10, 0xcf, 0x02, 0x4f, 0x40, 0xb8,
+	0x48, 0xb6, 0xd8, 0xad, 0x2d, 0x2f, 0xf5, 0x7f, 0x14, 0x39, 0x1f, 0x5b, 0x33, 0x3a, 0x9e, 0x87,
+	0x81, 0xeb, 0x27, 0xe8, 0x08, 0x94, 0x17, 0x74, 0x71, 0x4e, 0xa3, 0xb8, 0xa1, 0xf0, 0xb2, 0x37,
+	0x8c, 0xeb, 0x32, 0x46, 0x8f, 0x0b, 0x10, 0x29, 0xc8, 0xfc, 0x62, 0xcf, 0x3e, 0x89, 0x66, 0x41,
+	0xb0, 0xdb, 0x38, 0x11, 0xef, 0x73, 0x7e, 0xcb, 0x89, 0xfd, 0x19, 0x28, 0x89, 0xcf, 0x6f, 0x4c,
+	0xe2, 0xdc, 0xf3, 0x42, 0x5d, 0x7f, 0x5e, 0xe4, 0x7b, 0x49, 0xdb, 0xdc, 0x4b, 0xeb, 0x4f, 0x68,
+	0xfd, 0x4f, 0x45, 0xfe, 0x79, 0xc1, 0x57, 0x09, 0x93, 0xf6, 0xd2, 0x97, 0xc5, 0xaa, 0xe3, 0x95,
+	0xb5, 0x8e, 0xcf, 0xad, 0x3e, 0xf5, 0xc3, 0x56, 0x9f, 0xb6, 0xbe, 0xfa, 0x1a, 0xa0, 0x1c, 0xd1,
+	0xcb, 0x60, 0x4e, 0x27, 0xbc, 0x3a, 0x15, 0x22, 0xc9, 0xbb, 0x0d, 0x97, 0x6c, 0x4c, 0x12, 0xb6,
+	0xea, 0x37, 0x8d, 0x49, 0x1f, 0x54, 0x85, 0x14, 0xdb, 0xa7, 0x87, 0x69, 0xe7, 0x8a, 0x12, 0xee,
+	0x19, 0x19, 0xe7, 0x7a, 0xdf, 0xde, 0xa9, 0x4b, 0xeb, 0xf9, 0x2e, 0xcd, 0xbc, 0xea, 0xb8, 0xe3,
+	0xf9, 0x46, 0xaf, 0x56, 0xb8, 0x27, 0x81, 0xb7, 0xb9, 0xe9, 0x1e, 0x81, 0x42, 0x14, 0x78, 0xf2,
+	0xef, 0x02, 0x34, 0x56, 0x9f, 0x18, 0xec, 0x87, 0x70, 0xae, 0x8e, 0x41, 0x81, 0x6b, 0xa9, 0x81,
+	0x72, 0x1b, 0x1f, 0x37, 0x47, 0x5d, 0x5b, 0x3c, 0xff, 0xd9, 0xab, 0x1a, 0x13, 0xf1, 0xff, 0xed,
+	0x4b, 0x62, 0xda, 0x98, 0x40, 0x95, 0xad, 0x9d, 0x5e, 0xbf, 0x8d, 0x49, 0xd3, 0xee, 0x13, 0xa8,
+	0xf1, 0x1d, 0xd5, 0xee, 0x99, 0x16, 0x2c, 0xbc, 0x28, 0x7c, 0xad, 0x86, 0xe7, 0xe7, 0x25, 0x5e,
+	0xd4, 0x8f, 0xff, 0x1d, 0x00, 0x7c, 0xb5, 0x9e, 0x42, 0x8b, 0x0f, 0x00, 0x00,
 }
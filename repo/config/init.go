@@ -55,6 +55,7 @@ type Logs struct {
 // Thread settings
 type Threads struct {
 	Defaults ThreadDefaults // default settings
+	Sync     ThreadSync     // history sync settings
 }
 
 // ThreadDefaults settings
@@ -62,6 +63,11 @@ type ThreadDefaults struct {
 	ID string // default thread ID for reads/writes
 }
 
+// ThreadSync settings
+type ThreadSync struct {
+	Concurrency int // maximum number of parallel block fetches per thread, zero uses the default
+}
+
 // Cafe settings
 type Cafe struct {
 	Host   CafeHost
@@ -129,6 +135,9 @@ func Init(version string) (*Config, error) {
 			Defaults: ThreadDefaults{
 				ID: "",
 			},
+			Sync: ThreadSync{
+				Concurrency: 8,
+			},
 		},
 		Cafe: Cafe{
 			Host: CafeHost{
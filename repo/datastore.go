@@ -15,9 +15,15 @@ type Datastore interface {
 	Files() FileStore
 	Threads() ThreadStore
 	ThreadInvites() ThreadInviteStore
+	ThreadKeys() ThreadKeyStore
 	ThreadPeers() ThreadPeerStore
+	ThreadSyncs() ThreadSyncStore
+	ThreadReceipts() ThreadReceiptStore
+	ThreadExternalInvites() ThreadExternalInviteStore
+	ThreadJoinRequests() ThreadJoinRequestStore
 	ThreadMessages() ThreadMessageStore
 	Blocks() BlockStore
+	Search() SearchStore
 	Notifications() NotificationStore
 	CafeSessions() CafeSessionStore
 	CafeRequests() CafeRequestStore
@@ -69,6 +75,7 @@ type FileStore interface {
 	Get(hash string) *File
 	GetByPrimary(mill string, checksum string) *File
 	GetBySource(mill string, source string, opts string) *File
+	ListByTarget(target string) []File
 	AddTarget(hash string, target string) error
 	RemoveTarget(hash string, target string) error
 	Count() int
@@ -83,6 +90,9 @@ type ThreadStore interface {
 	List() []Thread
 	Count() int
 	UpdateHead(id string, head string) error
+	UpdateMeta(id string, name string, description string, cover string, ttl int64) error
+	UpdateReceiptsOff(id string, off bool) error
+	UpdateFlagThreshold(id string, threshold int) error
 	Delete(id string) error
 }
 
@@ -94,6 +104,14 @@ type ThreadInviteStore interface {
 	Delete(id string) error
 }
 
+type ThreadKeyStore interface {
+	Queryable
+	Add(key *ThreadKey) error
+	Get(id string) *ThreadKey
+	ListByThread(threadId string) []ThreadKey
+	DeleteByThread(threadId string) error
+}
+
 type ThreadPeerStore interface {
 	Queryable
 	Add(peer *ThreadPeer) error
@@ -102,16 +120,55 @@ type ThreadPeerStore interface {
 	ListByThread(threadId string) []ThreadPeer
 	ListUnwelcomedByThread(threadId string) []ThreadPeer
 	WelcomeByThread(thread string) error
+	UpdateRole(id string, thread string, role ThreadRole) error
 	Count(distinct bool) int
 	Delete(id string, thread string) error
 	DeleteById(id string) error
 	DeleteByThread(thread string) error
 }
 
+type ThreadSyncStore interface {
+	Queryable
+	Add(ts *ThreadSync) error
+	ListByThread(threadId string, limit int) []ThreadSync
+	CountByThread(threadId string) int
+	AddAttempt(id string, threadId string) error
+	Delete(id string, threadId string) error
+	DeleteByThread(threadId string) error
+}
+
+type ThreadReceiptStore interface {
+	Queryable
+	AddOrUpdate(receipt *ThreadReceipt) error
+	Get(threadId string, peerId string) *ThreadReceipt
+	ListByThread(threadId string) []ThreadReceipt
+	DeleteByThread(threadId string) error
+}
+
+type ThreadExternalInviteStore interface {
+	Queryable
+	AddOrUpdate(invite *ThreadExternalInvite) error
+	Get(id string) *ThreadExternalInvite
+	ListByThread(threadId string) []ThreadExternalInvite
+	DeleteByThread(threadId string) error
+}
+
+type ThreadJoinRequestStore interface {
+	Queryable
+	Add(req *ThreadJoinRequest) error
+	Get(id string) *ThreadJoinRequest
+	GetPending(threadId string, peerId string) *ThreadJoinRequest
+	GetSent(threadId string, peerId string) *ThreadJoinRequest
+	ListByThread(threadId string) []ThreadJoinRequest
+	Decide(id string, status JoinRequestStatus, deciderId string, date time.Time) error
+	DeleteByThread(threadId string) error
+}
+
 type ThreadMessageStore interface {
 	Queryable
 	Add(msg *ThreadMessage) error
 	List(offset string, limit int) []ThreadMessage
+	ListByPeer(peerId string) []ThreadMessage
 	Delete(id string) error
 }
 
@@ -120,11 +177,24 @@ type BlockStore interface {
 	Add(block *Block) error
 	Get(id string) *Block
 	List(offset string, limit int, query string) []Block
+	ListCausal(offset string, limit int, query string) []Block
+	ListByQuery(query *BlockQuery) []Block
 	Count(query string) int
+	CountByQuery(query *BlockQuery) int
+	UpdateHidden(id string, hidden bool) error
+	Sweep(id string, expires time.Time) error
 	Delete(id string) error
 	DeleteByThread(threadId string) error
 }
 
+type SearchStore interface {
+	Queryable
+	Add(doc *SearchDoc) error
+	Search(query *SearchQuery) ([]SearchResult, error)
+	Delete(blockId string) error
+	DeleteByThread(threadId string) error
+}
+
 type NotificationStore interface {
 	Queryable
 	Add(notification *Notification) error
@@ -2,6 +2,7 @@ package db
 
 import (
 	"database/sql"
+	"fmt"
 	"strconv"
 	"strings"
 	"sync"
@@ -25,7 +26,11 @@ func (c *BlockDB) Add(block *repo.Block) error {
 	if err != nil {
 		return err
 	}
-	stm := `insert into blocks(id, threadId, authorId, type, date, parents, target, body) values(?,?,?,?,?,?,?,?)`
+	var expires int
+	if block.Expires != nil {
+		expires = int(block.Expires.UnixNano())
+	}
+	stm := `insert into blocks(id, threadId, authorId, type, date, parents, target, body, verified, clock, expires, swept, hidden, address) values(?,?,?,?,?,?,?,?,?,?,?,?,?,?)`
 	stmt, err := tx.Prepare(stm)
 	if err != nil {
 		log.Errorf("error in tx prepare: %s", err)
@@ -41,6 +46,12 @@ func (c *BlockDB) Add(block *repo.Block) error {
 		strings.Join(block.Parents, ","),
 		block.Target,
 		block.Body,
+		block.Verified,
+		int(block.Clock),
+		expires,
+		block.Swept,
+		block.Hidden,
+		block.Address,
 	)
 	if err != nil {
 		tx.Rollback()
@@ -53,7 +64,7 @@ func (c *BlockDB) Add(block *repo.Block) error {
 func (c *BlockDB) Get(id string) *repo.Block {
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	ret := c.handleQuery("select * from blocks where id='" + id + "';")
+	ret := c.handleQuery("select * from blocks where id=?;", id)
 	if len(ret) == 0 {
 		return nil
 	}
@@ -78,6 +89,67 @@ func (c *BlockDB) List(offset string, limit int, query string) []repo.Block {
 	return c.handleQuery(stm)
 }
 
+func (c *BlockDB) ListCausal(offset string, limit int, query string) []repo.Block {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	var stm, q string
+	if offset != "" {
+		if query != "" {
+			q = query + " and "
+		}
+		clock := "(select clock from blocks where id='" + offset + "')"
+		date := "(select date from blocks where id='" + offset + "')"
+		q += "(clock<" + clock + " or (clock=" + clock + " and (date<" + date + " or (date=" + date + " and id<'" + offset + "'))))"
+		stm = "select * from blocks where " + q + " order by clock desc, date desc, id desc limit " + strconv.Itoa(limit) + ";"
+	} else {
+		if query != "" {
+			q = "where " + query + " "
+		}
+		stm = "select * from blocks " + q + "order by clock desc, date desc, id desc limit " + strconv.Itoa(limit) + ";"
+	}
+	return c.handleQuery(stm)
+}
+
+// ListByQuery lists blocks matching a typed query, which is compiled into a parameterized statement
+func (c *BlockDB) ListByQuery(query *repo.BlockQuery) []repo.Block {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	conds, args := blockQueryConds(query)
+
+	dir, cmp := "desc", "<"
+	if query.Ascending {
+		dir, cmp = "asc", ">"
+	}
+	var order string
+	if query.Order == repo.CausalOrder {
+		if query.Offset != "" {
+			clock := "(select clock from blocks where id=?)"
+			date := "(select date from blocks where id=?)"
+			conds = append(conds, "(clock"+cmp+clock+" or (clock="+clock+" and (date"+cmp+date+" or (date="+date+" and id"+cmp+"?))))")
+			for i := 0; i < 5; i++ {
+				args = append(args, query.Offset)
+			}
+		}
+		order = "clock " + dir + ", date " + dir + ", id " + dir
+	} else {
+		if query.Offset != "" {
+			conds = append(conds, "date"+cmp+"(select date from blocks where id=?)")
+			args = append(args, query.Offset)
+		}
+		order = "date " + dir
+	}
+
+	stm := "select * from blocks"
+	if len(conds) > 0 {
+		stm += " where " + strings.Join(conds, " and ")
+	}
+	stm += " order by " + order
+	if query.Limit > 0 {
+		stm += " limit " + strconv.Itoa(query.Limit)
+	}
+	return c.handleQuery(stm+";", args...)
+}
+
 func (c *BlockDB) Count(query string) int {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -91,6 +163,37 @@ func (c *BlockDB) Count(query string) int {
 	return count
 }
 
+// CountByQuery counts blocks matching a typed query, ignoring its offset and limit
+func (c *BlockDB) CountByQuery(query *repo.BlockQuery) int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	conds, args := blockQueryConds(query)
+	stm := "select Count(*) from blocks"
+	if len(conds) > 0 {
+		stm += " where " + strings.Join(conds, " and ")
+	}
+	row := c.db.QueryRow(stm+";", args...)
+	var count int
+	row.Scan(&count)
+	return count
+}
+
+func (c *BlockDB) UpdateHidden(id string, hidden bool) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err := c.db.Exec("update blocks set hidden=? where id=?", hidden, id)
+	return err
+}
+
+// Sweep marks a block as expired at a date and clears its body,
+// keeping the row as a tombstone so the block is not fetched again
+func (c *BlockDB) Sweep(id string, expires time.Time) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err := c.db.Exec("update blocks set swept=1, body='', expires=? where id=?", int(expires.UnixNano()), id)
+	return err
+}
+
 func (c *BlockDB) Delete(id string) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -105,17 +208,17 @@ func (c *BlockDB) DeleteByThread(threadId string) error {
 	return err
 }
 
-func (c *BlockDB) handleQuery(stm string) []repo.Block {
+func (c *BlockDB) handleQuery(stm string, args ...interface{}) []repo.Block {
 	var ret []repo.Block
-	rows, err := c.db.Query(stm)
+	rows, err := c.db.Query(stm, args...)
 	if err != nil {
 		log.Errorf("error in db query: %s", err)
 		return nil
 	}
 	for rows.Next() {
-		var id, threadId, authorId, parents, target, body string
-		var dateInt, typeInt int
-		if err := rows.Scan(&id, &threadId, &authorId, &typeInt, &dateInt, &parents, &target, &body); err != nil {
+		var id, threadId, authorId, parents, target, body, address string
+		var dateInt, typeInt, verifiedInt, clockInt, expiresInt, sweptInt, hiddenInt int
+		if err := rows.Scan(&id, &threadId, &authorId, &typeInt, &dateInt, &parents, &target, &body, &verifiedInt, &clockInt, &expiresInt, &sweptInt, &hiddenInt, &address); err != nil {
 			log.Errorf("error in db scan: %s", err)
 			continue
 		}
@@ -125,16 +228,104 @@ func (c *BlockDB) handleQuery(stm string) []repo.Block {
 				plist = append(plist, p)
 			}
 		}
+		var expires *time.Time
+		if expiresInt > 0 {
+			date := time.Unix(0, int64(expiresInt))
+			expires = &date
+		}
 		ret = append(ret, repo.Block{
 			Id:       id,
 			ThreadId: threadId,
 			AuthorId: authorId,
+			Address:  address,
 			Type:     repo.BlockType(typeInt),
 			Date:     time.Unix(0, int64(dateInt)),
 			Parents:  plist,
 			Target:   target,
 			Body:     body,
+			Verified: verifiedInt == 1,
+			Clock:    int64(clockInt),
+			Expires:  expires,
+			Hidden:   hiddenInt == 1,
+			Swept:    sweptInt == 1,
 		})
 	}
 	return ret
 }
+
+// undoneCond returns a condition that is true unless the aliased block is an ignore,
+// like, or flag that was undone by a verified undo from its author
+func undoneCond(alias string) string {
+	return fmt.Sprintf("not (%s.type in (%d,%d,%d) and exists (select 1 from blocks u where u.target='undo-'||%s.id and u.authorId=%s.authorId and u.verified=1))",
+		alias, repo.IgnoreBlock, repo.LikeBlock, repo.FlagBlock, alias, alias, alias)
+}
+
+// blockQueryConds returns the where conditions and args of a typed query, excluding its offset
+func blockQueryConds(query *repo.BlockQuery) ([]string, []interface{}) {
+	var conds []string
+	var args []interface{}
+
+	in := func(col string, vals []interface{}) {
+		if len(vals) == 0 {
+			return
+		}
+		marks := strings.TrimSuffix(strings.Repeat("?,", len(vals)), ",")
+		conds = append(conds, col+" in ("+marks+")")
+		args = append(args, vals...)
+	}
+
+	var threadIds, types, authorIds []interface{}
+	for _, id := range query.ThreadIds {
+		threadIds = append(threadIds, id)
+	}
+	for _, t := range query.Types {
+		types = append(types, int(t))
+	}
+	for _, id := range query.AuthorIds {
+		authorIds = append(authorIds, id)
+	}
+	in("threadId", threadIds)
+	in("type", types)
+	in("authorId", authorIds)
+
+	if query.Since != nil {
+		conds = append(conds, "date>=?")
+		args = append(args, int(query.Since.UnixNano()))
+	}
+	if query.Until != nil {
+		conds = append(conds, "date<=?")
+		args = append(args, int(query.Until.UnixNano()))
+	}
+	if query.Target != "" {
+		conds = append(conds, "target=?")
+		args = append(args, query.Target)
+	}
+	if query.Body != "" {
+		conds = append(conds, "body=?")
+		args = append(args, query.Body)
+	}
+	if query.Parent != "" {
+		conds = append(conds, "instr(','||parents||',', ?)>0")
+		args = append(args, ","+query.Parent+",")
+	}
+	if query.Verified {
+		conds = append(conds, "verified=1")
+	}
+	if query.Due != nil {
+		conds = append(conds, "expires>0 and expires<=? and swept=0")
+		args = append(args, int(query.Due.UnixNano()))
+	}
+	if !query.Ignored {
+		conds = append(conds, undoneCond("blocks"))
+		// an ignore of a disallowed block targets itself and can't be undone
+		conds = append(conds, "not exists (select 1 from blocks i where i.target='ignore-'||blocks.id and (i.id=blocks.id or "+undoneCond("i")+"))")
+	}
+	if !query.Hidden {
+		conds = append(conds, "hidden=0")
+	}
+	if !query.Expired {
+		conds = append(conds, "(expires=0 or expires>?)")
+		args = append(args, int(time.Now().UnixNano()))
+	}
+	return conds, args
+}
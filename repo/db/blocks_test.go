@@ -31,6 +31,7 @@ func TestBlockDB_Add(t *testing.T) {
 		Parents:  []string{"Qm123"},
 		Target:   "Qm456",
 		Body:     "body",
+		Verified: true,
 	})
 	if err != nil {
 		t.Error(err)
@@ -51,6 +52,41 @@ func TestBlockDB_Get(t *testing.T) {
 	block := blockStore.Get("abcde")
 	if block == nil {
 		t.Error("could not get block")
+		return
+	}
+	if !block.Verified {
+		t.Error("block should be verified")
+	}
+	if block.Expires != nil {
+		t.Error("block should not expire")
+	}
+}
+
+func TestBlockDB_Expires(t *testing.T) {
+	expires := time.Now().Add(time.Hour)
+	err := blockStore.Add(&repo.Block{
+		Id:       "expiring",
+		ThreadId: "thread_id",
+		AuthorId: "author_id",
+		Type:     repo.MessageBlock,
+		Date:     time.Now(),
+		Body:     "body",
+		Expires:  &expires,
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	block := blockStore.Get("expiring")
+	if block == nil || block.Expires == nil {
+		t.Error("could not get block expiry")
+		return
+	}
+	if !block.Expires.Equal(time.Unix(0, expires.UnixNano())) {
+		t.Error("block expiry does not match")
+	}
+	if err := blockStore.Delete("expiring"); err != nil {
+		t.Error(err)
 	}
 }
 
@@ -103,6 +139,225 @@ func TestBlockDB_List(t *testing.T) {
 	}
 }
 
+func TestBlockDB_ListCausal(t *testing.T) {
+	setupBlockDB()
+	now := time.Now()
+	// a skewed author clock dates the child before its parent
+	blocks := []repo.Block{
+		{Id: "parent", Date: now, Clock: 1},
+		{Id: "child", Date: now.Add(-time.Hour), Clock: 2, Parents: []string{"parent"}},
+		{Id: "sibling1", Date: now.Add(-time.Hour), Clock: 3, Parents: []string{"child"}},
+		{Id: "sibling2", Date: now.Add(-time.Hour), Clock: 3, Parents: []string{"child"}},
+	}
+	for _, block := range blocks {
+		block.ThreadId = "thread_id"
+		block.AuthorId = "author_id"
+		block.Type = repo.MessageBlock
+		if err := blockStore.Add(&block); err != nil {
+			t.Error(err)
+		}
+	}
+	all := blockStore.ListCausal("", -1, "")
+	if len(all) != 4 {
+		t.Error("returned incorrect number of blocks")
+		return
+	}
+	expected := []string{"sibling2", "sibling1", "child", "parent"}
+	for i, id := range expected {
+		if all[i].Id != id {
+			t.Errorf("expected %s at %d got %s", id, i, all[i].Id)
+		}
+	}
+	offset := blockStore.ListCausal("sibling1", -1, "threadId='thread_id'")
+	if len(offset) != 2 || offset[0].Id != "child" {
+		t.Error("returned incorrect blocks after offset")
+	}
+}
+
+func TestBlockDB_ListByQuery(t *testing.T) {
+	setupBlockDB()
+	now := time.Now()
+	expired := now.Add(-time.Minute)
+	blocks := []repo.Block{
+		{Id: "msg1", ThreadId: "thread1", AuthorId: "author1", Type: repo.MessageBlock, Date: now.Add(-time.Hour), Clock: 1},
+		{Id: "msg2", ThreadId: "thread1", AuthorId: "author2", Type: repo.MessageBlock, Date: now.Add(-time.Minute), Clock: 2},
+		{Id: "files", ThreadId: "thread2", AuthorId: "author1", Type: repo.FilesBlock, Date: now.Add(-time.Second * 30), Clock: 1, Target: "Qmtarget"},
+		{Id: "expired", ThreadId: "thread1", AuthorId: "author1", Type: repo.MessageBlock, Date: now.Add(-time.Second * 10), Clock: 3, Expires: &expired},
+		{Id: "ignore", ThreadId: "thread1", AuthorId: "author1", Type: repo.IgnoreBlock, Date: now, Clock: 4, Target: "ignore-msg1"},
+	}
+	for _, block := range blocks {
+		if err := blockStore.Add(&block); err != nil {
+			t.Error(err)
+		}
+	}
+
+	all := blockStore.ListByQuery(&repo.BlockQuery{})
+	if len(all) != 3 {
+		t.Errorf("expected ignored and expired blocks to be excluded, got %d blocks", len(all))
+		return
+	}
+	if all[0].Id != "ignore" {
+		t.Error("expected newest block first")
+	}
+	all = blockStore.ListByQuery(&repo.BlockQuery{Ignored: true, Expired: true})
+	if len(all) != 5 {
+		t.Errorf("expected all blocks, got %d", len(all))
+	}
+
+	msgs := blockStore.ListByQuery(&repo.BlockQuery{
+		ThreadIds: []string{"thread1"},
+		Types:     []repo.BlockType{repo.MessageBlock},
+		Ignored:   true,
+		Ascending: true,
+	})
+	if len(msgs) != 2 || msgs[0].Id != "msg1" {
+		t.Error("expected oldest message first")
+	}
+
+	authored := blockStore.ListByQuery(&repo.BlockQuery{AuthorIds: []string{"author2"}})
+	if len(authored) != 1 || authored[0].Id != "msg2" {
+		t.Error("returned incorrect blocks for author")
+	}
+
+	since := now.Add(-time.Minute * 2)
+	dated := blockStore.ListByQuery(&repo.BlockQuery{Since: &since, Until: &now})
+	if len(dated) != 3 {
+		t.Error("returned incorrect blocks for date range")
+	}
+
+	targeted := blockStore.ListByQuery(&repo.BlockQuery{Target: "Qmtarget"})
+	if len(targeted) != 1 || targeted[0].Id != "files" {
+		t.Error("returned incorrect blocks for target")
+	}
+
+	// values are bound, so quotes are just part of the value
+	injected := blockStore.ListByQuery(&repo.BlockQuery{ThreadIds: []string{"thread1' or '1'='1"}})
+	if len(injected) != 0 {
+		t.Error("query values should not be interpreted as sql")
+	}
+
+	page := blockStore.ListByQuery(&repo.BlockQuery{Limit: 1})
+	if len(page) != 1 {
+		t.Error("returned incorrect number of blocks")
+		return
+	}
+	next := blockStore.ListByQuery(&repo.BlockQuery{Offset: page[0].Id, Limit: 1})
+	if len(next) != 1 || next[0].Id != "files" {
+		t.Error("returned incorrect blocks after offset")
+	}
+
+	causal := blockStore.ListByQuery(&repo.BlockQuery{
+		ThreadIds: []string{"thread1"},
+		Order:     repo.CausalOrder,
+		Offset:    "ignore",
+		Ignored:   true,
+		Expired:   true,
+	})
+	if len(causal) != 3 || causal[0].Id != "expired" {
+		t.Error("returned incorrect blocks after causal offset")
+	}
+}
+
+func TestBlockDB_CountByQuery(t *testing.T) {
+	cnt := blockStore.CountByQuery(&repo.BlockQuery{
+		ThreadIds: []string{"thread1"},
+		Types:     []repo.BlockType{repo.MessageBlock},
+		Limit:     1,
+	})
+	if cnt != 1 {
+		t.Errorf("expected 1 visible message, got %d", cnt)
+	}
+}
+
+func TestBlockDB_UpdateHidden(t *testing.T) {
+	if err := blockStore.UpdateHidden("msg2", true); err != nil {
+		t.Error(err)
+		return
+	}
+	block := blockStore.Get("msg2")
+	if block == nil || !block.Hidden {
+		t.Error("update hidden failed")
+	}
+	query := &repo.BlockQuery{
+		ThreadIds: []string{"thread1"},
+		Types:     []repo.BlockType{repo.MessageBlock},
+	}
+	if cnt := blockStore.CountByQuery(query); cnt != 0 {
+		t.Errorf("expected hidden blocks to be excluded, got %d", cnt)
+	}
+	query.Hidden = true
+	if cnt := blockStore.CountByQuery(query); cnt != 1 {
+		t.Errorf("expected 1 hidden message, got %d", cnt)
+	}
+}
+
+func TestBlockDB_ListByQueryUndone(t *testing.T) {
+	query := &repo.BlockQuery{
+		ThreadIds: []string{"thread1"},
+		Types:     []repo.BlockType{repo.MessageBlock},
+	}
+
+	// only a verified undo by the author of an ignore can undo it
+	undos := []repo.Block{
+		{Id: "undo1", ThreadId: "thread1", AuthorId: "author2", Type: repo.UndoBlock, Date: time.Now(), Target: "undo-ignore", Verified: true},
+		{Id: "undo2", ThreadId: "thread1", AuthorId: "author1", Type: repo.UndoBlock, Date: time.Now(), Target: "undo-ignore"},
+		{Id: "undo3", ThreadId: "thread1", AuthorId: "author1", Type: repo.UndoBlock, Date: time.Now(), Target: "undo-ignore", Verified: true},
+	}
+	if err := blockStore.Add(&undos[0]); err != nil {
+		t.Error(err)
+	}
+	if cnt := blockStore.CountByQuery(query); cnt != 0 {
+		t.Errorf("expected undo by another peer to be ignored, got %d", cnt)
+	}
+	if err := blockStore.Add(&undos[1]); err != nil {
+		t.Error(err)
+	}
+	if cnt := blockStore.CountByQuery(query); cnt != 0 {
+		t.Errorf("expected unverified undo to be ignored, got %d", cnt)
+	}
+	if err := blockStore.Add(&undos[2]); err != nil {
+		t.Error(err)
+	}
+	msgs := blockStore.ListByQuery(query)
+	if len(msgs) != 1 || msgs[0].Id != "msg1" {
+		t.Error("expected undone ignore to restore its target")
+	}
+	ignores := blockStore.ListByQuery(&repo.BlockQuery{Types: []repo.BlockType{repo.IgnoreBlock}})
+	if len(ignores) != 0 {
+		t.Error("expected undone ignore to be excluded")
+	}
+}
+
+func TestBlockDB_Sweep(t *testing.T) {
+	if err := blockStore.Add(&repo.Block{
+		Id:       "swept",
+		ThreadId: "thread_swept",
+		AuthorId: "author1",
+		Type:     repo.MessageBlock,
+		Date:     time.Now(),
+		Body:     "hi",
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := blockStore.Sweep("swept", time.Now()); err != nil {
+		t.Error(err)
+		return
+	}
+	block := blockStore.Get("swept")
+	if block == nil {
+		t.Error("swept block should be kept")
+		return
+	}
+	if !block.Swept || block.Body != "" || block.Expires == nil {
+		t.Error("sweep failed")
+	}
+	query := &repo.BlockQuery{ThreadIds: []string{"thread_swept"}}
+	if cnt := blockStore.CountByQuery(query); cnt != 0 {
+		t.Errorf("expected swept blocks to be excluded, got %d", cnt)
+	}
+}
+
 func TestBlockDB_Count(t *testing.T) {
 	setupBlockDB()
 	err := blockStore.Add(&repo.Block{
@@ -164,3 +419,47 @@ func TestBlockDB_DeleteByThread(t *testing.T) {
 		t.Error("delete by thread id failed")
 	}
 }
+
+func TestBlockDB_ListByQueryFields(t *testing.T) {
+	setupBlockDB()
+	now := time.Now()
+	expired := now.Add(-time.Minute)
+	blocks := []repo.Block{
+		{Id: "root", ThreadId: "thread1", AuthorId: "author1", Type: repo.MessageBlock, Date: now.Add(-time.Hour), Body: "hi"},
+		{Id: "child", ThreadId: "thread1", AuthorId: "author1", Type: repo.MessageBlock, Date: now.Add(-time.Minute), Parents: []string{"other", "root"}, Body: "hi", Verified: true},
+		{Id: "rootling", ThreadId: "thread1", AuthorId: "author1", Type: repo.MessageBlock, Date: now, Parents: []string{"rootish"}, Expires: &expired},
+	}
+	for _, block := range blocks {
+		if err := blockStore.Add(&block); err != nil {
+			t.Error(err)
+		}
+	}
+
+	bodied := blockStore.ListByQuery(&repo.BlockQuery{Body: "hi"})
+	if len(bodied) != 2 {
+		t.Error("returned incorrect blocks for body")
+	}
+
+	// parents must match as a whole id
+	children := blockStore.ListByQuery(&repo.BlockQuery{Parent: "root", Expired: true})
+	if len(children) != 1 || children[0].Id != "child" {
+		t.Error("returned incorrect blocks for parent")
+	}
+
+	verified := blockStore.ListByQuery(&repo.BlockQuery{Verified: true})
+	if len(verified) != 1 || verified[0].Id != "child" {
+		t.Error("returned incorrect verified blocks")
+	}
+
+	due := blockStore.ListByQuery(&repo.BlockQuery{Due: &now, Expired: true})
+	if len(due) != 1 || due[0].Id != "rootling" {
+		t.Error("returned incorrect due blocks")
+		return
+	}
+	if err := blockStore.Sweep("rootling", expired); err != nil {
+		t.Error(err)
+	}
+	if len(blockStore.ListByQuery(&repo.BlockQuery{Due: &now, Expired: true})) != 0 {
+		t.Error("swept blocks should not be due")
+	}
+}
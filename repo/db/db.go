@@ -3,6 +3,7 @@ package db
 import (
 	"database/sql"
 	"path"
+	"strings"
 	"sync"
 
 	logging "gx/ipfs/QmZChCsSt8DctjceaL56Eibc29CVQq4dGKRXC5JRZ6Ppae/go-log"
@@ -14,25 +15,31 @@ import (
 var log = logging.Logger("tex-datastore")
 
 type SQLiteDatastore struct {
-	config             repo.ConfigStore
-	profile            repo.ProfileStore
-	contacts           repo.ContactStore
-	files              repo.FileStore
-	threads            repo.ThreadStore
-	threadInvites      repo.ThreadInviteStore
-	threadPeers        repo.ThreadPeerStore
-	threadMessages     repo.ThreadMessageStore
-	blocks             repo.BlockStore
-	notifications      repo.NotificationStore
-	cafeSessions       repo.CafeSessionStore
-	cafeRequests       repo.CafeRequestStore
-	cafeMessages       repo.CafeMessageStore
-	cafeClientNonces   repo.CafeClientNonceStore
-	cafeClients        repo.CafeClientStore
-	cafeClientThreads  repo.CafeClientThreadStore
-	cafeClientMessages repo.CafeClientMessageStore
-	db                 *sql.DB
-	lock               *sync.Mutex
+	config                repo.ConfigStore
+	profile               repo.ProfileStore
+	contacts              repo.ContactStore
+	files                 repo.FileStore
+	threads               repo.ThreadStore
+	threadInvites         repo.ThreadInviteStore
+	threadKeys            repo.ThreadKeyStore
+	threadPeers           repo.ThreadPeerStore
+	threadSyncs           repo.ThreadSyncStore
+	threadReceipts        repo.ThreadReceiptStore
+	threadExternalInvites repo.ThreadExternalInviteStore
+	threadJoinRequests    repo.ThreadJoinRequestStore
+	threadMessages        repo.ThreadMessageStore
+	blocks                repo.BlockStore
+	search                repo.SearchStore
+	notifications         repo.NotificationStore
+	cafeSessions          repo.CafeSessionStore
+	cafeRequests          repo.CafeRequestStore
+	cafeMessages          repo.CafeMessageStore
+	cafeClientNonces      repo.CafeClientNonceStore
+	cafeClients           repo.CafeClientStore
+	cafeClientThreads     repo.CafeClientThreadStore
+	cafeClientMessages    repo.CafeClientMessageStore
+	db                    *sql.DB
+	lock                  *sync.Mutex
 }
 
 func Create(repoPath, pin string) (*SQLiteDatastore, error) {
@@ -48,25 +55,31 @@ func Create(repoPath, pin string) (*SQLiteDatastore, error) {
 	}
 	mux := new(sync.Mutex)
 	sqliteDB := &SQLiteDatastore{
-		config:             NewConfigStore(conn, mux, dbPath),
-		profile:            NewProfileStore(conn, mux),
-		contacts:           NewContactStore(conn, mux),
-		files:              NewFileStore(conn, mux),
-		threads:            NewThreadStore(conn, mux),
-		threadInvites:      NewThreadInviteStore(conn, mux),
-		threadPeers:        NewThreadPeerStore(conn, mux),
-		threadMessages:     NewThreadMessageStore(conn, mux),
-		blocks:             NewBlockStore(conn, mux),
-		notifications:      NewNotificationStore(conn, mux),
-		cafeSessions:       NewCafeSessionStore(conn, mux),
-		cafeRequests:       NewCafeRequestStore(conn, mux),
-		cafeMessages:       NewCafeMessageStore(conn, mux),
-		cafeClientNonces:   NewCafeClientNonceStore(conn, mux),
-		cafeClients:        NewCafeClientStore(conn, mux),
-		cafeClientThreads:  NewCafeClientThreadStore(conn, mux),
-		cafeClientMessages: NewCafeClientMessageStore(conn, mux),
-		db:                 conn,
-		lock:               mux,
+		config:                NewConfigStore(conn, mux, dbPath),
+		profile:               NewProfileStore(conn, mux),
+		contacts:              NewContactStore(conn, mux),
+		files:                 NewFileStore(conn, mux),
+		threads:               NewThreadStore(conn, mux),
+		threadInvites:         NewThreadInviteStore(conn, mux),
+		threadKeys:            NewThreadKeyStore(conn, mux),
+		threadPeers:           NewThreadPeerStore(conn, mux),
+		threadSyncs:           NewThreadSyncStore(conn, mux),
+		threadReceipts:        NewThreadReceiptStore(conn, mux),
+		threadExternalInvites: NewThreadExternalInviteStore(conn, mux),
+		threadJoinRequests:    NewThreadJoinRequestStore(conn, mux),
+		threadMessages:        NewThreadMessageStore(conn, mux),
+		blocks:                NewBlockStore(conn, mux),
+		search:                NewSearchStore(conn, mux),
+		notifications:         NewNotificationStore(conn, mux),
+		cafeSessions:          NewCafeSessionStore(conn, mux),
+		cafeRequests:          NewCafeRequestStore(conn, mux),
+		cafeMessages:          NewCafeMessageStore(conn, mux),
+		cafeClientNonces:      NewCafeClientNonceStore(conn, mux),
+		cafeClients:           NewCafeClientStore(conn, mux),
+		cafeClientThreads:     NewCafeClientThreadStore(conn, mux),
+		cafeClientMessages:    NewCafeClientMessageStore(conn, mux),
+		db:                    conn,
+		lock:                  mux,
 	}
 
 	return sqliteDB, nil
@@ -104,10 +117,30 @@ func (d *SQLiteDatastore) ThreadInvites() repo.ThreadInviteStore {
 	return d.threadInvites
 }
 
+func (d *SQLiteDatastore) ThreadKeys() repo.ThreadKeyStore {
+	return d.threadKeys
+}
+
 func (d *SQLiteDatastore) ThreadPeers() repo.ThreadPeerStore {
 	return d.threadPeers
 }
 
+func (d *SQLiteDatastore) ThreadSyncs() repo.ThreadSyncStore {
+	return d.threadSyncs
+}
+
+func (d *SQLiteDatastore) ThreadReceipts() repo.ThreadReceiptStore {
+	return d.threadReceipts
+}
+
+func (d *SQLiteDatastore) ThreadExternalInvites() repo.ThreadExternalInviteStore {
+	return d.threadExternalInvites
+}
+
+func (d *SQLiteDatastore) ThreadJoinRequests() repo.ThreadJoinRequestStore {
+	return d.threadJoinRequests
+}
+
 func (d *SQLiteDatastore) ThreadMessages() repo.ThreadMessageStore {
 	return d.threadMessages
 }
@@ -116,6 +149,10 @@ func (d *SQLiteDatastore) Blocks() repo.BlockStore {
 	return d.blocks
 }
 
+func (d *SQLiteDatastore) Search() repo.SearchStore {
+	return d.search
+}
+
 func (d *SQLiteDatastore) Notifications() repo.NotificationStore {
 	return d.notifications
 }
@@ -164,6 +201,10 @@ func (d *SQLiteDatastore) Copy(dbPath string, pin string) error {
 		if err := rows.Scan(&name); err != nil {
 			return err
 		}
+		// full-text shadow tables are filled by their virtual table
+		if strings.HasPrefix(name, "search_") {
+			continue
+		}
 		tables = append(tables, name)
 	}
 	if pin == "" {
@@ -207,22 +248,44 @@ func initDatabaseTables(db *sql.DB, pin string) error {
     create index file_hash on files (hash);
     create unique index file_mill_source_opts on files (mill, source, opts);
 
-    create table threads (id text primary key not null, key text not null, sk blob not null, name text not null, schema text not null, initiator text not null, type integer not null, state integer not null, head text not null);
+    create table threads (id text primary key not null, key text not null, sk blob not null, name text not null, schema text not null, initiator text not null, type integer not null, state integer not null, head text not null, description text not null, cover text not null, ttl integer not null, receiptsOff integer not null, flagThreshold integer not null);
     create unique index thread_key on threads (key);
 
     create table thread_invites (id text primary key not null, block blob not null, name text not null, inviter text not null, date integer not null);
     create index thread_invite_date on thread_invites (date);
 
-    create table thread_peers (id text not null, threadId text not null, welcomed integer not null, primary key (id, threadId));
+    create table thread_keys (id text primary key not null, threadId text not null, sk blob not null, date integer not null, blockId text not null);
+    create index thread_key_threadId on thread_keys (threadId);
+    create index thread_key_date on thread_keys (date);
+
+    create table thread_peers (id text not null, threadId text not null, welcomed integer not null, role integer not null, primary key (id, threadId));
     create index thread_peer_id on thread_peers (id);
     create index thread_peer_threadId on thread_peers (threadId);
     create index thread_peer_welcomed on thread_peers (welcomed);
 
-    create table blocks (id text primary key not null, threadId text not null, authorId text not null, type integer not null, date integer not null, parents text not null, target text not null, body text not null);
+    create table thread_syncs (id text not null, threadId text not null, date integer not null, attempts integer not null, primary key (id, threadId));
+    create index thread_sync_threadId on thread_syncs (threadId);
+    create index thread_sync_date on thread_syncs (date);
+
+    create table thread_receipts (threadId text not null, peerId text not null, blockId text not null, date integer not null, primary key (threadId, peerId));
+    create index thread_receipt_threadId on thread_receipts (threadId);
+
+    create table thread_external_invites (id text primary key not null, threadId text not null, inviterId text not null, expires integer not null, maxUses integer not null, revoked integer not null, date integer not null);
+    create index thread_external_invite_threadId on thread_external_invites (threadId);
+
+    create table thread_join_requests (id text primary key not null, threadId text not null, peerId text not null, address text not null, username text not null, inboxes text not null, note text not null, date integer not null, status integer not null, deciderId text not null, decided integer not null, outgoing integer not null);
+    create index thread_join_request_threadId on thread_join_requests (threadId);
+    create index thread_join_request_peerId on thread_join_requests (peerId);
+
+    create table blocks (id text primary key not null, threadId text not null, authorId text not null, type integer not null, date integer not null, parents text not null, target text not null, body text not null, verified integer not null, clock integer not null, expires integer not null, swept integer not null, hidden integer not null, address text not null);
     create index block_threadId on blocks (threadId);
     create index block_type on blocks (type);
     create index block_date on blocks (date);
     create index block_target on blocks (target);
+    create index block_clock on blocks (clock);
+    create index block_expires on blocks (expires);
+
+    create virtual table search using fts4(blockId, threadId, authorId, type, date, field, text, notindexed=blockId, notindexed=threadId, notindexed=authorId, notindexed=type, notindexed=date, notindexed=field, tokenize=unicode61);
 
     create table thread_messages (id text primary key not null, peerId text not null, envelope blob not null, date integer not null);
     create index thread_message_date on thread_messages (date);
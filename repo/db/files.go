@@ -101,6 +101,18 @@ func (c *FileDB) GetBySource(mill string, source string, opts string) *repo.File
 	return &ret[0]
 }
 
+func (c *FileDB) ListByTarget(target string) []repo.File {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	var ret []repo.File
+	for _, file := range c.handleQuery("select * from files where targets like '%" + target + "%';") {
+		if targetExists(target, file.Targets) {
+			ret = append(ret, file)
+		}
+	}
+	return ret
+}
+
 func (c *FileDB) AddTarget(hash string, target string) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -0,0 +1,180 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/binary"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/textileio/textile-go/repo"
+)
+
+type SearchDB struct {
+	modelStore
+}
+
+func NewSearchStore(db *sql.DB, lock *sync.Mutex) repo.SearchStore {
+	return &SearchDB{modelStore{db, lock}}
+}
+
+// Add indexes a doc, replacing any existing doc with the same block and field
+func (c *SearchDB) Add(doc *repo.SearchDoc) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec("delete from search where blockId=? and field=?", doc.BlockId, doc.Field); err != nil {
+		tx.Rollback()
+		return err
+	}
+	stm := `insert into search(blockId, threadId, authorId, type, date, field, text) values(?,?,?,?,?,?,?)`
+	stmt, err := tx.Prepare(stm)
+	if err != nil {
+		log.Errorf("error in tx prepare: %s", err)
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(
+		doc.BlockId,
+		doc.ThreadId,
+		doc.AuthorId,
+		int(doc.Type),
+		int(doc.Date.UnixNano()),
+		doc.Field,
+		doc.Text,
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+// Search returns blocks matching a full-text query, ranked best first.
+// Each block is returned once, with a snippet from its best matching doc.
+func (c *SearchDB) Search(query *repo.SearchQuery) ([]repo.SearchResult, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	stm := "select blockId, threadId, authorId, type, date, snippet(search, '<b>', '</b>', '...', -1, 16), matchinfo(search, 'pcx') from search where search match ?"
+	args := []interface{}{query.Text}
+	if query.ThreadId != "" {
+		stm += " and threadId=?"
+		args = append(args, query.ThreadId)
+	}
+	if query.AuthorId != "" {
+		stm += " and authorId=?"
+		args = append(args, query.AuthorId)
+	}
+	if len(query.Types) > 0 {
+		var types []string
+		for _, t := range query.Types {
+			types = append(types, strconv.Itoa(int(t)))
+		}
+		stm += " and type in (" + strings.Join(types, ",") + ")"
+	}
+	if query.Since != nil {
+		stm += " and date>=?"
+		args = append(args, int(query.Since.UnixNano()))
+	}
+	if query.Until != nil {
+		stm += " and date<=?"
+		args = append(args, int(query.Until.UnixNano()))
+	}
+	rows, err := c.db.Query(stm+";", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	index := make(map[string]int)
+	var ret []repo.SearchResult
+	var best []float64
+	for rows.Next() {
+		var blockId, threadId, authorId, snippet string
+		var typeInt, dateInt int
+		var info []byte
+		if err := rows.Scan(&blockId, &threadId, &authorId, &typeInt, &dateInt, &snippet, &info); err != nil {
+			log.Errorf("error in db scan: %s", err)
+			continue
+		}
+		score := rank(info)
+		if i, ok := index[blockId]; ok {
+			ret[i].Rank += score
+			if score > best[i] {
+				ret[i].Snippet = snippet
+				best[i] = score
+			}
+			continue
+		}
+		index[blockId] = len(ret)
+		best = append(best, score)
+		ret = append(ret, repo.SearchResult{
+			BlockId:  blockId,
+			ThreadId: threadId,
+			AuthorId: authorId,
+			Type:     repo.BlockType(typeInt),
+			Date:     time.Unix(0, int64(dateInt)),
+			Snippet:  snippet,
+			Rank:     score,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].Rank != ret[j].Rank {
+			return ret[i].Rank > ret[j].Rank
+		}
+		return ret[i].Date.After(ret[j].Date)
+	})
+	return ret, nil
+}
+
+func (c *SearchDB) Delete(blockId string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err := c.db.Exec("delete from search where blockId=?", blockId)
+	return err
+}
+
+func (c *SearchDB) DeleteByThread(threadId string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err := c.db.Exec("delete from search where threadId=?", threadId)
+	return err
+}
+
+// rank scores a match from its matchinfo 'pcx' blob. Each phrase hit counts
+// in proportion to how rare the phrase is across all docs.
+func rank(info []byte) float64 {
+	vals := make([]uint32, len(info)/4)
+	for i := range vals {
+		vals[i] = binary.LittleEndian.Uint32(info[i*4:])
+	}
+	if len(vals) < 2 {
+		return 0
+	}
+	phrases, cols := int(vals[0]), int(vals[1])
+	if len(vals) < 2+phrases*cols*3 {
+		return 0
+	}
+
+	var score float64
+	for p := 0; p < phrases; p++ {
+		for c := 0; c < cols; c++ {
+			x := 2 + (p*cols+c)*3
+			hits, total := vals[x], vals[x+1]
+			if hits > 0 && total > 0 {
+				score += float64(hits) / float64(total)
+			}
+		}
+	}
+	return score
+}
@@ -0,0 +1,147 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/textileio/textile-go/repo"
+)
+
+var searchStore repo.SearchStore
+
+func init() {
+	setupSearchDB()
+}
+
+func setupSearchDB() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	searchStore = NewSearchStore(conn, new(sync.Mutex))
+}
+
+func TestSearchDB_Add(t *testing.T) {
+	docs := []repo.SearchDoc{
+		{BlockId: "Qmmsg", ThreadId: "Qmthread", AuthorId: "Qmpeer1", Type: repo.MessageBlock,
+			Field: "body", Text: "meet at the harbor at noon"},
+		{BlockId: "Qmcomment", ThreadId: "Qmthread", AuthorId: "Qmpeer2", Type: repo.CommentBlock,
+			Field: "body", Text: "the harbor is closed, harbor tours only"},
+		{BlockId: "Qmfiles", ThreadId: "Qmother", AuthorId: "Qmpeer1", Type: repo.FilesBlock,
+			Field: "file:Qmfile", Text: "harbor.jpg"},
+	}
+	for _, doc := range docs {
+		doc.Date = time.Now()
+		if err := searchStore.Add(&doc); err != nil {
+			t.Error(err)
+		}
+	}
+
+	// replaces the existing doc
+	err := searchStore.Add(&repo.SearchDoc{
+		BlockId:  "Qmmsg",
+		ThreadId: "Qmthread",
+		AuthorId: "Qmpeer1",
+		Type:     repo.MessageBlock,
+		Date:     time.Now(),
+		Field:    "body",
+		Text:     "meet at the harbor at one",
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	res, err := searchStore.Search(&repo.SearchQuery{Text: "noon"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(res) != 0 {
+		t.Error("edited doc should be replaced")
+	}
+}
+
+func TestSearchDB_Search(t *testing.T) {
+	res, err := searchStore.Search(&repo.SearchQuery{Text: "harbor"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(res) != 3 {
+		t.Errorf("expected 3 results, got %d", len(res))
+		return
+	}
+	if res[0].BlockId != "Qmcomment" {
+		t.Error("more hits should rank first")
+	}
+	if res[0].Snippet == "" {
+		t.Error("result is missing snippet")
+	}
+}
+
+func TestSearchDB_SearchFilters(t *testing.T) {
+	res, err := searchStore.Search(&repo.SearchQuery{
+		Text:     "harbor",
+		ThreadId: "Qmthread",
+		AuthorId: "Qmpeer1",
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(res) != 1 || res[0].BlockId != "Qmmsg" {
+		t.Error("search should be filtered by thread and author")
+	}
+	res, err = searchStore.Search(&repo.SearchQuery{
+		Text:  "harbor",
+		Types: []repo.BlockType{repo.FilesBlock},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(res) != 1 || res[0].BlockId != "Qmfiles" {
+		t.Error("search should be filtered by type")
+	}
+	since := time.Now().Add(time.Hour)
+	res, err = searchStore.Search(&repo.SearchQuery{
+		Text:  "harbor",
+		Since: &since,
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(res) != 0 {
+		t.Error("search should be filtered by date")
+	}
+}
+
+func TestSearchDB_Delete(t *testing.T) {
+	if err := searchStore.Delete("Qmmsg"); err != nil {
+		t.Error(err)
+		return
+	}
+	res, err := searchStore.Search(&repo.SearchQuery{Text: "harbor"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(res) != 2 {
+		t.Error("delete failed")
+	}
+}
+
+func TestSearchDB_DeleteByThread(t *testing.T) {
+	if err := searchStore.DeleteByThread("Qmthread"); err != nil {
+		t.Error(err)
+		return
+	}
+	res, err := searchStore.Search(&repo.SearchQuery{Text: "harbor"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(res) != 1 {
+		t.Error("delete by thread failed")
+	}
+}
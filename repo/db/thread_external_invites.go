@@ -0,0 +1,112 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/textileio/textile-go/repo"
+)
+
+type ThreadExternalInviteDB struct {
+	modelStore
+}
+
+func NewThreadExternalInviteStore(db *sql.DB, lock *sync.Mutex) repo.ThreadExternalInviteStore {
+	return &ThreadExternalInviteDB{modelStore{db, lock}}
+}
+
+func (c *ThreadExternalInviteDB) AddOrUpdate(invite *repo.ThreadExternalInvite) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	stm := `insert or replace into thread_external_invites(id, threadId, inviterId, expires, maxUses, revoked, date) values(?,?,?,?,?,?,?)`
+	stmt, err := tx.Prepare(stm)
+	if err != nil {
+		log.Errorf("error in tx prepare: %s", err)
+		return err
+	}
+	defer stmt.Close()
+	var expires int
+	if invite.Expires != nil {
+		expires = int(invite.Expires.UnixNano())
+	}
+	var revoked int
+	if invite.Revoked {
+		revoked = 1
+	}
+	_, err = stmt.Exec(
+		invite.Id,
+		invite.ThreadId,
+		invite.InviterId,
+		expires,
+		invite.MaxUses,
+		revoked,
+		int(invite.Date.UnixNano()),
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+func (c *ThreadExternalInviteDB) Get(id string) *repo.ThreadExternalInvite {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	ret := c.handleQuery("select * from thread_external_invites where id='" + id + "';")
+	if len(ret) == 0 {
+		return nil
+	}
+	return &ret[0]
+}
+
+func (c *ThreadExternalInviteDB) ListByThread(threadId string) []repo.ThreadExternalInvite {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	stm := "select * from thread_external_invites where threadId='" + threadId + "' order by date desc;"
+	return c.handleQuery(stm)
+}
+
+func (c *ThreadExternalInviteDB) DeleteByThread(threadId string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err := c.db.Exec("delete from thread_external_invites where threadId=?", threadId)
+	return err
+}
+
+func (c *ThreadExternalInviteDB) handleQuery(stm string) []repo.ThreadExternalInvite {
+	var ret []repo.ThreadExternalInvite
+	rows, err := c.db.Query(stm)
+	if err != nil {
+		log.Errorf("error in db query: %s", err)
+		return nil
+	}
+	for rows.Next() {
+		var id, threadId, inviterId string
+		var expiresInt, maxUses, revokedInt, dateInt int
+		if err := rows.Scan(&id, &threadId, &inviterId, &expiresInt, &maxUses, &revokedInt, &dateInt); err != nil {
+			log.Errorf("error in db scan: %s", err)
+			continue
+		}
+		var expires *time.Time
+		if expiresInt > 0 {
+			exp := time.Unix(0, int64(expiresInt))
+			expires = &exp
+		}
+		ret = append(ret, repo.ThreadExternalInvite{
+			Id:        id,
+			ThreadId:  threadId,
+			InviterId: inviterId,
+			Expires:   expires,
+			MaxUses:   maxUses,
+			Revoked:   revokedInt == 1,
+			Date:      time.Unix(0, int64(dateInt)),
+		})
+	}
+	return ret
+}
@@ -0,0 +1,96 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/textileio/textile-go/repo"
+)
+
+var threadExternalInviteStore repo.ThreadExternalInviteStore
+
+func init() {
+	setupThreadExternalInviteDB()
+}
+
+func setupThreadExternalInviteDB() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	threadExternalInviteStore = NewThreadExternalInviteStore(conn, new(sync.Mutex))
+}
+
+func TestThreadExternalInviteDB_AddOrUpdate(t *testing.T) {
+	expires := time.Now().Add(time.Hour)
+	err := threadExternalInviteStore.AddOrUpdate(&repo.ThreadExternalInvite{
+		Id:        "Qminvite",
+		ThreadId:  "Qmthread",
+		InviterId: "Qmpeer",
+		Expires:   &expires,
+		MaxUses:   5,
+		Date:      time.Now(),
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	err = threadExternalInviteStore.AddOrUpdate(&repo.ThreadExternalInvite{
+		Id:        "Qminvite",
+		ThreadId:  "Qmthread",
+		InviterId: "Qmpeer",
+		Expires:   &expires,
+		MaxUses:   5,
+		Revoked:   true,
+		Date:      time.Now(),
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	invite := threadExternalInviteStore.Get("Qminvite")
+	if invite == nil {
+		t.Error("could not get invite")
+		return
+	}
+	if !invite.Revoked {
+		t.Error("invite should be revoked")
+	}
+	if invite.MaxUses != 5 {
+		t.Errorf("expected 5 max uses got %d", invite.MaxUses)
+	}
+	if invite.Expires == nil || !invite.Expires.Equal(expires) {
+		t.Error("invite expiry mismatch")
+	}
+}
+
+func TestThreadExternalInviteDB_ListByThread(t *testing.T) {
+	err := threadExternalInviteStore.AddOrUpdate(&repo.ThreadExternalInvite{
+		Id:        "Qminvite2",
+		ThreadId:  "Qmthread",
+		InviterId: "Qmpeer",
+		Date:      time.Now(),
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	list := threadExternalInviteStore.ListByThread("Qmthread")
+	if len(list) != 2 {
+		t.Error("wrong number of invites")
+		return
+	}
+	if list[0].Id != "Qminvite2" {
+		t.Error("invites should be newest first")
+	}
+	if list[0].Expires != nil {
+		t.Error("invite should not expire")
+	}
+}
+
+func TestThreadExternalInviteDB_DeleteByThread(t *testing.T) {
+	if err := threadExternalInviteStore.DeleteByThread("Qmthread"); err != nil {
+		t.Error(err)
+		return
+	}
+	if threadExternalInviteStore.Get("Qminvite") != nil {
+		t.Error("delete by thread failed")
+	}
+}
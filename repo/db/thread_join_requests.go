@@ -0,0 +1,160 @@
+package db
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/textileio/textile-go/repo"
+)
+
+type ThreadJoinRequestDB struct {
+	modelStore
+}
+
+func NewThreadJoinRequestStore(db *sql.DB, lock *sync.Mutex) repo.ThreadJoinRequestStore {
+	return &ThreadJoinRequestDB{modelStore{db, lock}}
+}
+
+func (c *ThreadJoinRequestDB) Add(req *repo.ThreadJoinRequest) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	stm := `insert into thread_join_requests(id, threadId, peerId, address, username, inboxes, note, date, status, deciderId, decided, outgoing) values(?,?,?,?,?,?,?,?,?,?,?,?)`
+	stmt, err := tx.Prepare(stm)
+	if err != nil {
+		log.Errorf("error in tx prepare: %s", err)
+		return err
+	}
+	defer stmt.Close()
+	var decided int
+	if req.Decided != nil {
+		decided = int(req.Decided.UnixNano())
+	}
+	outgoing := 0
+	if req.Outgoing {
+		outgoing = 1
+	}
+	_, err = stmt.Exec(
+		req.Id,
+		req.ThreadId,
+		req.PeerId,
+		req.Address,
+		req.Username,
+		strings.Join(req.Inboxes, ","),
+		req.Note,
+		int(req.Date.UnixNano()),
+		int(req.Status),
+		req.DeciderId,
+		decided,
+		outgoing,
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+func (c *ThreadJoinRequestDB) Get(id string) *repo.ThreadJoinRequest {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	ret := c.handleQuery("select * from thread_join_requests where id='" + id + "';")
+	if len(ret) == 0 {
+		return nil
+	}
+	return &ret[0]
+}
+
+func (c *ThreadJoinRequestDB) GetPending(threadId string, peerId string) *repo.ThreadJoinRequest {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	stm := "select * from thread_join_requests where threadId='" + threadId + "' and peerId='" + peerId + "' and status=" + strconv.Itoa(int(repo.PendingJoinRequest)) + " and outgoing=0;"
+	ret := c.handleQuery(stm)
+	if len(ret) == 0 {
+		return nil
+	}
+	return &ret[0]
+}
+
+func (c *ThreadJoinRequestDB) GetSent(threadId string, peerId string) *repo.ThreadJoinRequest {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	stm := "select * from thread_join_requests where threadId='" + threadId + "' and peerId='" + peerId + "' and status=" + strconv.Itoa(int(repo.PendingJoinRequest)) + " and outgoing=1;"
+	ret := c.handleQuery(stm)
+	if len(ret) == 0 {
+		return nil
+	}
+	return &ret[0]
+}
+
+func (c *ThreadJoinRequestDB) ListByThread(threadId string) []repo.ThreadJoinRequest {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	stm := "select * from thread_join_requests where threadId='" + threadId + "' and outgoing=0 order by date desc;"
+	return c.handleQuery(stm)
+}
+
+func (c *ThreadJoinRequestDB) Decide(id string, status repo.JoinRequestStatus, deciderId string, date time.Time) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err := c.db.Exec("update thread_join_requests set status=?, deciderId=?, decided=? where id=?",
+		int(status), deciderId, int(date.UnixNano()), id)
+	return err
+}
+
+func (c *ThreadJoinRequestDB) DeleteByThread(threadId string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err := c.db.Exec("delete from thread_join_requests where threadId=?", threadId)
+	return err
+}
+
+func (c *ThreadJoinRequestDB) handleQuery(stm string) []repo.ThreadJoinRequest {
+	var ret []repo.ThreadJoinRequest
+	rows, err := c.db.Query(stm)
+	if err != nil {
+		log.Errorf("error in db query: %s", err)
+		return nil
+	}
+	for rows.Next() {
+		var id, threadId, peerId, address, username, inboxes, note, deciderId string
+		var dateInt, statusInt, decidedInt, outgoingInt int
+		if err := rows.Scan(&id, &threadId, &peerId, &address, &username, &inboxes, &note, &dateInt, &statusInt, &deciderId, &decidedInt, &outgoingInt); err != nil {
+			log.Errorf("error in db scan: %s", err)
+			continue
+		}
+		ilist := make([]string, 0)
+		for _, p := range strings.Split(inboxes, ",") {
+			if p != "" {
+				ilist = append(ilist, p)
+			}
+		}
+		var decided *time.Time
+		if decidedInt > 0 {
+			d := time.Unix(0, int64(decidedInt))
+			decided = &d
+		}
+		ret = append(ret, repo.ThreadJoinRequest{
+			Id:        id,
+			ThreadId:  threadId,
+			PeerId:    peerId,
+			Address:   address,
+			Username:  username,
+			Inboxes:   ilist,
+			Note:      note,
+			Date:      time.Unix(0, int64(dateInt)),
+			Status:    repo.JoinRequestStatus(statusInt),
+			DeciderId: deciderId,
+			Decided:   decided,
+			Outgoing:  outgoingInt == 1,
+		})
+	}
+	return ret
+}
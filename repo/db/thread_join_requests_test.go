@@ -0,0 +1,114 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/textileio/textile-go/repo"
+)
+
+var threadJoinRequestStore repo.ThreadJoinRequestStore
+
+func init() {
+	setupThreadJoinRequestDB()
+}
+
+func setupThreadJoinRequestDB() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	threadJoinRequestStore = NewThreadJoinRequestStore(conn, new(sync.Mutex))
+}
+
+func TestThreadJoinRequestDB_Add(t *testing.T) {
+	err := threadJoinRequestStore.Add(&repo.ThreadJoinRequest{
+		Id:       "abc",
+		ThreadId: "Qmthread",
+		PeerId:   "Qmpeer",
+		Address:  "P123",
+		Username: "hal",
+		Inboxes:  []string{"Qmcafe1", "Qmcafe2"},
+		Note:     "let me in",
+		Date:     time.Now(),
+		Status:   repo.PendingJoinRequest,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestThreadJoinRequestDB_Get(t *testing.T) {
+	req := threadJoinRequestStore.Get("abc")
+	if req == nil {
+		t.Error("could not get request")
+		return
+	}
+	if len(req.Inboxes) != 2 || req.Decided != nil {
+		t.Error("bad request")
+	}
+}
+
+func TestThreadJoinRequestDB_GetPending(t *testing.T) {
+	if threadJoinRequestStore.GetPending("Qmthread", "Qmpeer") == nil {
+		t.Error("could not get pending request")
+	}
+	if threadJoinRequestStore.GetPending("Qmthread", "Qmother") != nil {
+		t.Error("got pending request for wrong peer")
+	}
+}
+
+func TestThreadJoinRequestDB_GetSent(t *testing.T) {
+	err := threadJoinRequestStore.Add(&repo.ThreadJoinRequest{
+		Id:       "def",
+		ThreadId: "Qmthread",
+		PeerId:   "Qmadmin",
+		Date:     time.Now(),
+		Status:   repo.PendingJoinRequest,
+		Outgoing: true,
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	req := threadJoinRequestStore.GetSent("Qmthread", "Qmadmin")
+	if req == nil || !req.Outgoing {
+		t.Error("could not get sent request")
+	}
+	if threadJoinRequestStore.GetSent("Qmthread", "Qmpeer") != nil {
+		t.Error("got received request as sent")
+	}
+	if threadJoinRequestStore.GetPending("Qmthread", "Qmadmin") != nil {
+		t.Error("got sent request as pending")
+	}
+}
+
+func TestThreadJoinRequestDB_Decide(t *testing.T) {
+	if err := threadJoinRequestStore.Decide("abc", repo.DeniedJoinRequest, "Qmadmin", time.Now()); err != nil {
+		t.Error(err)
+		return
+	}
+	req := threadJoinRequestStore.Get("abc")
+	if req == nil || req.Status != repo.DeniedJoinRequest || req.DeciderId != "Qmadmin" || req.Decided == nil {
+		t.Error("decide failed")
+	}
+	if threadJoinRequestStore.GetPending("Qmthread", "Qmpeer") != nil {
+		t.Error("decided request should not be pending")
+	}
+}
+
+func TestThreadJoinRequestDB_ListByThread(t *testing.T) {
+	if len(threadJoinRequestStore.ListByThread("Qmthread")) != 1 {
+		t.Error("wrong number of requests")
+	}
+}
+
+func TestThreadJoinRequestDB_DeleteByThread(t *testing.T) {
+	if err := threadJoinRequestStore.DeleteByThread("Qmthread"); err != nil {
+		t.Error(err)
+		return
+	}
+	if threadJoinRequestStore.Get("abc") != nil {
+		t.Error("delete by thread failed")
+	}
+}
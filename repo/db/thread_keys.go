@@ -0,0 +1,96 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/textileio/textile-go/repo"
+)
+
+type ThreadKeyDB struct {
+	modelStore
+}
+
+func NewThreadKeyStore(db *sql.DB, lock *sync.Mutex) repo.ThreadKeyStore {
+	return &ThreadKeyDB{modelStore{db, lock}}
+}
+
+func (c *ThreadKeyDB) Add(key *repo.ThreadKey) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	stm := `insert into thread_keys(id, threadId, sk, date, blockId) values(?,?,?,?,?)`
+	stmt, err := tx.Prepare(stm)
+	if err != nil {
+		log.Errorf("error in tx prepare: %s", err)
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(
+		key.Id,
+		key.ThreadId,
+		key.PrivKey,
+		int(key.Date.UnixNano()),
+		key.BlockId,
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+func (c *ThreadKeyDB) Get(id string) *repo.ThreadKey {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	ret := c.handleQuery("select * from thread_keys where id='" + id + "';")
+	if len(ret) == 0 {
+		return nil
+	}
+	return &ret[0]
+}
+
+func (c *ThreadKeyDB) ListByThread(threadId string) []repo.ThreadKey {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	stm := "select * from thread_keys where threadId='" + threadId + "' order by date desc;"
+	return c.handleQuery(stm)
+}
+
+func (c *ThreadKeyDB) DeleteByThread(threadId string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err := c.db.Exec("delete from thread_keys where threadId=?", threadId)
+	return err
+}
+
+func (c *ThreadKeyDB) handleQuery(stm string) []repo.ThreadKey {
+	var ret []repo.ThreadKey
+	rows, err := c.db.Query(stm)
+	if err != nil {
+		log.Errorf("error in db query: %s", err)
+		return nil
+	}
+	for rows.Next() {
+		var id, threadId, blockId string
+		var sk []byte
+		var dateInt int
+		if err := rows.Scan(&id, &threadId, &sk, &dateInt, &blockId); err != nil {
+			log.Errorf("error in db scan: %s", err)
+			continue
+		}
+		ret = append(ret, repo.ThreadKey{
+			Id:       id,
+			ThreadId: threadId,
+			PrivKey:  sk,
+			Date:     time.Unix(0, int64(dateInt)),
+			BlockId:  blockId,
+		})
+	}
+	return ret
+}
@@ -0,0 +1,88 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/textileio/textile-go/repo"
+)
+
+var threadKeyStore repo.ThreadKeyStore
+
+func init() {
+	setupThreadKeyDB()
+}
+
+func setupThreadKeyDB() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	threadKeyStore = NewThreadKeyStore(conn, new(sync.Mutex))
+}
+
+func TestThreadKeyDB_Add(t *testing.T) {
+	err := threadKeyStore.Add(&repo.ThreadKey{
+		Id:       "Qmkey1",
+		ThreadId: "Qmthread",
+		PrivKey:  make([]byte, 8),
+		Date:     time.Now(),
+		BlockId:  "Qmblock1",
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	stmt, err := threadKeyStore.PrepareQuery("select id from thread_keys where id=?")
+	defer stmt.Close()
+	var id string
+	err = stmt.QueryRow("Qmkey1").Scan(&id)
+	if err != nil {
+		t.Error(err)
+	}
+	if id != "Qmkey1" {
+		t.Errorf(`expected "Qmkey1" got %s`, id)
+	}
+}
+
+func TestThreadKeyDB_Get(t *testing.T) {
+	key := threadKeyStore.Get("Qmkey1")
+	if key == nil {
+		t.Error("could not get thread key")
+		return
+	}
+	if key.ThreadId != "Qmthread" {
+		t.Errorf(`expected "Qmthread" got %s`, key.ThreadId)
+	}
+	if key.BlockId != "Qmblock1" {
+		t.Errorf(`expected "Qmblock1" got %s`, key.BlockId)
+	}
+}
+
+func TestThreadKeyDB_ListByThread(t *testing.T) {
+	err := threadKeyStore.Add(&repo.ThreadKey{
+		Id:       "Qmkey2",
+		ThreadId: "Qmthread",
+		PrivKey:  make([]byte, 8),
+		Date:     time.Now().Add(time.Minute),
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	list := threadKeyStore.ListByThread("Qmthread")
+	if len(list) != 2 {
+		t.Error("returned incorrect number of thread keys")
+		return
+	}
+	if list[0].Id != "Qmkey2" {
+		t.Error("thread keys should be ordered newest first")
+	}
+}
+
+func TestThreadKeyDB_DeleteByThread(t *testing.T) {
+	if err := threadKeyStore.DeleteByThread("Qmthread"); err != nil {
+		t.Error(err)
+	}
+	if len(threadKeyStore.ListByThread("Qmthread")) != 0 {
+		t.Error("delete by thread failed")
+	}
+}
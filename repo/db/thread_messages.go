@@ -64,6 +64,13 @@ func (c *ThreadMessageDB) List(offset string, limit int) []repo.ThreadMessage {
 	return c.handleQuery(stm)
 }
 
+func (c *ThreadMessageDB) ListByPeer(peerId string) []repo.ThreadMessage {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	stm := "select * from thread_messages where peerId='" + peerId + "' order by date asc;"
+	return c.handleQuery(stm)
+}
+
 func (c *ThreadMessageDB) Delete(id string) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -22,7 +22,7 @@ func (c *ThreadPeerDB) Add(peer *repo.ThreadPeer) error {
 	if err != nil {
 		return err
 	}
-	stm := `insert into thread_peers(id, threadId, welcomed) values(?,?,?)`
+	stm := `insert into thread_peers(id, threadId, welcomed, role) values(?,?,?,?)`
 	stmt, err := tx.Prepare(stm)
 	if err != nil {
 		log.Errorf("error in tx prepare: %s", err)
@@ -33,6 +33,7 @@ func (c *ThreadPeerDB) Add(peer *repo.ThreadPeer) error {
 		peer.Id,
 		peer.ThreadId,
 		false,
+		int(peer.Role),
 	)
 	if err != nil {
 		tx.Rollback()
@@ -77,6 +78,13 @@ func (c *ThreadPeerDB) WelcomeByThread(threadId string) error {
 	return err
 }
 
+func (c *ThreadPeerDB) UpdateRole(id string, threadId string, role repo.ThreadRole) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err := c.db.Exec("update thread_peers set role=? where id=? and threadId=?", int(role), id, threadId)
+	return err
+}
+
 func (c *ThreadPeerDB) Count(distinct bool) int {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -122,8 +130,8 @@ func (c *ThreadPeerDB) handleQuery(stm string) []repo.ThreadPeer {
 	}
 	for rows.Next() {
 		var id, threadId string
-		var welcomedInt int
-		if err := rows.Scan(&id, &threadId, &welcomedInt); err != nil {
+		var welcomedInt, roleInt int
+		if err := rows.Scan(&id, &threadId, &welcomedInt, &roleInt); err != nil {
 			log.Errorf("error in db scan: %s", err)
 			continue
 		}
@@ -135,6 +143,7 @@ func (c *ThreadPeerDB) handleQuery(stm string) []repo.ThreadPeer {
 			Id:       id,
 			ThreadId: threadId,
 			Welcomed: welcomed,
+			Role:     repo.ThreadRole(roleInt),
 		})
 	}
 	return ret
@@ -130,6 +130,22 @@ func TestThreadPeerDB_Count(t *testing.T) {
 	}
 }
 
+func TestThreadPeerDB_UpdateRole(t *testing.T) {
+	err := threadPeerStore.UpdateRole("bar", "2", repo.ModeratorRole)
+	if err != nil {
+		t.Error(err)
+	}
+	filtered := threadPeerStore.ListByThread("2")
+	for _, p := range filtered {
+		if p.Id == "bar" && p.Role != repo.ModeratorRole {
+			t.Error("update role failed")
+		}
+		if p.Id == "bar2" && p.Role != repo.DefaultRole {
+			t.Error("update role changed the wrong peer")
+		}
+	}
+}
+
 func TestThreadPeerDB_Delete(t *testing.T) {
 	err := threadPeerStore.Add(&repo.ThreadPeer{
 		Id:       "car",
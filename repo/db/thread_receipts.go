@@ -0,0 +1,93 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/textileio/textile-go/repo"
+)
+
+type ThreadReceiptDB struct {
+	modelStore
+}
+
+func NewThreadReceiptStore(db *sql.DB, lock *sync.Mutex) repo.ThreadReceiptStore {
+	return &ThreadReceiptDB{modelStore{db, lock}}
+}
+
+func (c *ThreadReceiptDB) AddOrUpdate(receipt *repo.ThreadReceipt) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	stm := `insert or replace into thread_receipts(threadId, peerId, blockId, date) values(?,?,?,?)`
+	stmt, err := tx.Prepare(stm)
+	if err != nil {
+		log.Errorf("error in tx prepare: %s", err)
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(
+		receipt.ThreadId,
+		receipt.PeerId,
+		receipt.BlockId,
+		int(receipt.Date.UnixNano()),
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+func (c *ThreadReceiptDB) Get(threadId string, peerId string) *repo.ThreadReceipt {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	ret := c.handleQuery("select * from thread_receipts where threadId='" + threadId + "' and peerId='" + peerId + "';")
+	if len(ret) == 0 {
+		return nil
+	}
+	return &ret[0]
+}
+
+func (c *ThreadReceiptDB) ListByThread(threadId string) []repo.ThreadReceipt {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	stm := "select * from thread_receipts where threadId='" + threadId + "' order by date desc;"
+	return c.handleQuery(stm)
+}
+
+func (c *ThreadReceiptDB) DeleteByThread(threadId string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err := c.db.Exec("delete from thread_receipts where threadId=?", threadId)
+	return err
+}
+
+func (c *ThreadReceiptDB) handleQuery(stm string) []repo.ThreadReceipt {
+	var ret []repo.ThreadReceipt
+	rows, err := c.db.Query(stm)
+	if err != nil {
+		log.Errorf("error in db query: %s", err)
+		return nil
+	}
+	for rows.Next() {
+		var threadId, peerId, blockId string
+		var dateInt int
+		if err := rows.Scan(&threadId, &peerId, &blockId, &dateInt); err != nil {
+			log.Errorf("error in db scan: %s", err)
+			continue
+		}
+		ret = append(ret, repo.ThreadReceipt{
+			ThreadId: threadId,
+			PeerId:   peerId,
+			BlockId:  blockId,
+			Date:     time.Unix(0, int64(dateInt)),
+		})
+	}
+	return ret
+}
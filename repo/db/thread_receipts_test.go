@@ -0,0 +1,81 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/textileio/textile-go/repo"
+)
+
+var threadReceiptStore repo.ThreadReceiptStore
+
+func init() {
+	setupThreadReceiptDB()
+}
+
+func setupThreadReceiptDB() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	threadReceiptStore = NewThreadReceiptStore(conn, new(sync.Mutex))
+}
+
+func TestThreadReceiptDB_AddOrUpdate(t *testing.T) {
+	err := threadReceiptStore.AddOrUpdate(&repo.ThreadReceipt{
+		ThreadId: "Qmthread",
+		PeerId:   "Qmpeer",
+		BlockId:  "Qmblock1",
+		Date:     time.Now(),
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	err = threadReceiptStore.AddOrUpdate(&repo.ThreadReceipt{
+		ThreadId: "Qmthread",
+		PeerId:   "Qmpeer",
+		BlockId:  "Qmblock2",
+		Date:     time.Now(),
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	receipt := threadReceiptStore.Get("Qmthread", "Qmpeer")
+	if receipt == nil {
+		t.Error("could not get receipt")
+		return
+	}
+	if receipt.BlockId != "Qmblock2" {
+		t.Errorf(`expected "Qmblock2" got %s`, receipt.BlockId)
+	}
+}
+
+func TestThreadReceiptDB_ListByThread(t *testing.T) {
+	setupThreadReceiptDB()
+	for _, pid := range []string{"Qmpeer1", "Qmpeer2"} {
+		err := threadReceiptStore.AddOrUpdate(&repo.ThreadReceipt{
+			ThreadId: "Qmthread",
+			PeerId:   pid,
+			BlockId:  "Qmblock",
+			Date:     time.Now(),
+		})
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	if len(threadReceiptStore.ListByThread("Qmthread")) != 2 {
+		t.Error("list by thread failed")
+	}
+	if len(threadReceiptStore.ListByThread("Qmother")) != 0 {
+		t.Error("list by thread should be scoped to thread")
+	}
+}
+
+func TestThreadReceiptDB_DeleteByThread(t *testing.T) {
+	if err := threadReceiptStore.DeleteByThread("Qmthread"); err != nil {
+		t.Error(err)
+	}
+	if len(threadReceiptStore.ListByThread("Qmthread")) != 0 {
+		t.Error("delete by thread failed")
+	}
+}
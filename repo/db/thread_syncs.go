@@ -0,0 +1,107 @@
+package db
+
+import (
+	"database/sql"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/textileio/textile-go/repo"
+)
+
+type ThreadSyncDB struct {
+	modelStore
+}
+
+func NewThreadSyncStore(db *sql.DB, lock *sync.Mutex) repo.ThreadSyncStore {
+	return &ThreadSyncDB{modelStore{db, lock}}
+}
+
+func (c *ThreadSyncDB) Add(ts *repo.ThreadSync) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	stm := `insert into thread_syncs(id, threadId, date, attempts) values(?,?,?,?)`
+	stmt, err := tx.Prepare(stm)
+	if err != nil {
+		log.Errorf("error in tx prepare: %s", err)
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(
+		ts.Id,
+		ts.ThreadId,
+		int(ts.Date.UnixNano()),
+		ts.Attempts,
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+func (c *ThreadSyncDB) ListByThread(threadId string, limit int) []repo.ThreadSync {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	stm := "select * from thread_syncs where threadId='" + threadId + "' order by date asc limit " + strconv.Itoa(limit) + ";"
+	return c.handleQuery(stm)
+}
+
+func (c *ThreadSyncDB) CountByThread(threadId string) int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	row := c.db.QueryRow("select Count(*) from thread_syncs where threadId='" + threadId + "';")
+	var count int
+	row.Scan(&count)
+	return count
+}
+
+func (c *ThreadSyncDB) AddAttempt(id string, threadId string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err := c.db.Exec("update thread_syncs set attempts=attempts+1 where id=? and threadId=?", id, threadId)
+	return err
+}
+
+func (c *ThreadSyncDB) Delete(id string, threadId string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err := c.db.Exec("delete from thread_syncs where id=? and threadId=?", id, threadId)
+	return err
+}
+
+func (c *ThreadSyncDB) DeleteByThread(threadId string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err := c.db.Exec("delete from thread_syncs where threadId=?", threadId)
+	return err
+}
+
+func (c *ThreadSyncDB) handleQuery(stm string) []repo.ThreadSync {
+	var ret []repo.ThreadSync
+	rows, err := c.db.Query(stm)
+	if err != nil {
+		log.Errorf("error in db query: %s", err)
+		return nil
+	}
+	for rows.Next() {
+		var id, threadId string
+		var dateInt, attempts int
+		if err := rows.Scan(&id, &threadId, &dateInt, &attempts); err != nil {
+			log.Errorf("error in db scan: %s", err)
+			continue
+		}
+		ret = append(ret, repo.ThreadSync{
+			Id:       id,
+			ThreadId: threadId,
+			Date:     time.Unix(0, int64(dateInt)),
+			Attempts: attempts,
+		})
+	}
+	return ret
+}
@@ -0,0 +1,117 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/textileio/textile-go/repo"
+)
+
+var threadSyncStore repo.ThreadSyncStore
+
+func init() {
+	setupThreadSyncDB()
+}
+
+func setupThreadSyncDB() {
+	conn, _ := sql.Open("sqlite3", ":memory:")
+	initDatabaseTables(conn, "")
+	threadSyncStore = NewThreadSyncStore(conn, new(sync.Mutex))
+}
+
+func TestThreadSyncDB_Add(t *testing.T) {
+	err := threadSyncStore.Add(&repo.ThreadSync{
+		Id:       "Qmblock1",
+		ThreadId: "Qmthread",
+		Date:     time.Now(),
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	stmt, err := threadSyncStore.PrepareQuery("select id from thread_syncs where id=?")
+	defer stmt.Close()
+	var id string
+	err = stmt.QueryRow("Qmblock1").Scan(&id)
+	if err != nil {
+		t.Error(err)
+	}
+	if id != "Qmblock1" {
+		t.Errorf(`expected "Qmblock1" got %s`, id)
+	}
+}
+
+func TestThreadSyncDB_AddDuplicate(t *testing.T) {
+	err := threadSyncStore.Add(&repo.ThreadSync{
+		Id:       "Qmblock1",
+		ThreadId: "Qmthread",
+		Date:     time.Now(),
+	})
+	if err == nil || !repo.ConflictError(err) {
+		t.Error("adding a duplicate sync should conflict")
+	}
+}
+
+func TestThreadSyncDB_ListByThread(t *testing.T) {
+	err := threadSyncStore.Add(&repo.ThreadSync{
+		Id:       "Qmblock2",
+		ThreadId: "Qmthread",
+		Date:     time.Now().Add(time.Minute),
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	list := threadSyncStore.ListByThread("Qmthread", -1)
+	if len(list) != 2 {
+		t.Error("returned incorrect number of thread syncs")
+		return
+	}
+	if list[0].Id != "Qmblock1" {
+		t.Error("thread syncs should be ordered oldest first")
+	}
+	if len(threadSyncStore.ListByThread("Qmthread", 1)) != 1 {
+		t.Error("thread syncs limit was not applied")
+	}
+}
+
+func TestThreadSyncDB_CountByThread(t *testing.T) {
+	if threadSyncStore.CountByThread("Qmthread") != 2 {
+		t.Error("returned incorrect count of thread syncs")
+	}
+}
+
+func TestThreadSyncDB_AddAttempt(t *testing.T) {
+	if err := threadSyncStore.AddAttempt("Qmblock1", "Qmthread"); err != nil {
+		t.Error(err)
+	}
+	list := threadSyncStore.ListByThread("Qmthread", -1)
+	if len(list) != 2 {
+		t.Error("returned incorrect number of thread syncs")
+		return
+	}
+	if list[0].Attempts != 1 {
+		t.Errorf("expected 1 attempt got %d", list[0].Attempts)
+	}
+	if list[1].Attempts != 0 {
+		t.Error("attempt should only be added to the target sync")
+	}
+}
+
+func TestThreadSyncDB_Delete(t *testing.T) {
+	if err := threadSyncStore.Delete("Qmblock1", "Qmthread"); err != nil {
+		t.Error(err)
+	}
+	if threadSyncStore.CountByThread("Qmthread") != 1 {
+		t.Error("delete failed")
+	}
+}
+
+func TestThreadSyncDB_DeleteByThread(t *testing.T) {
+	if err := threadSyncStore.DeleteByThread("Qmthread"); err != nil {
+		t.Error(err)
+	}
+	if threadSyncStore.CountByThread("Qmthread") != 0 {
+		t.Error("delete by thread failed")
+	}
+}
@@ -22,7 +22,7 @@ func (c *ThreadDB) Add(thread *repo.Thread) error {
 	if err != nil {
 		return err
 	}
-	stm := `insert into threads(id, key, sk, name, schema, initiator, type, state, head) values(?,?,?,?,?,?,?,?,?)`
+	stm := `insert into threads(id, key, sk, name, schema, initiator, type, state, head, description, cover, ttl, receiptsOff, flagThreshold) values(?,?,?,?,?,?,?,?,?,?,?,?,?,?)`
 	stmt, err := tx.Prepare(stm)
 	if err != nil {
 		log.Errorf("error in tx prepare: %s", err)
@@ -39,6 +39,11 @@ func (c *ThreadDB) Add(thread *repo.Thread) error {
 		int(thread.Type),
 		int(thread.State),
 		thread.Head,
+		thread.Description,
+		thread.Cover,
+		int(thread.Ttl),
+		thread.ReceiptsOff,
+		thread.FlagThreshold,
 	)
 	if err != nil {
 		tx.Rollback()
@@ -90,6 +95,27 @@ func (c *ThreadDB) UpdateHead(id string, head string) error {
 	return err
 }
 
+func (c *ThreadDB) UpdateMeta(id string, name string, description string, cover string, ttl int64) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err := c.db.Exec("update threads set name=?, description=?, cover=?, ttl=? where id=?", name, description, cover, int(ttl), id)
+	return err
+}
+
+func (c *ThreadDB) UpdateReceiptsOff(id string, off bool) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err := c.db.Exec("update threads set receiptsOff=? where id=?", off, id)
+	return err
+}
+
+func (c *ThreadDB) UpdateFlagThreshold(id string, threshold int) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err := c.db.Exec("update threads set flagThreshold=? where id=?", threshold, id)
+	return err
+}
+
 func (c *ThreadDB) Delete(id string) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -105,23 +131,28 @@ func (c *ThreadDB) handleQuery(stm string) []repo.Thread {
 		return nil
 	}
 	for rows.Next() {
-		var id, key, name, schema, initiator, head string
+		var id, key, name, schema, initiator, head, description, cover string
 		var skb []byte
-		var typeInt, stateInt int
-		if err := rows.Scan(&id, &key, &skb, &name, &schema, &initiator, &typeInt, &stateInt, &head); err != nil {
+		var typeInt, stateInt, ttlInt, receiptsOffInt, flagThresholdInt int
+		if err := rows.Scan(&id, &key, &skb, &name, &schema, &initiator, &typeInt, &stateInt, &head, &description, &cover, &ttlInt, &receiptsOffInt, &flagThresholdInt); err != nil {
 			log.Errorf("error in db scan: %s", err)
 			continue
 		}
 		ret = append(ret, repo.Thread{
-			Id:        id,
-			Key:       key,
-			PrivKey:   skb,
-			Name:      name,
-			Schema:    schema,
-			Initiator: initiator,
-			Type:      repo.ThreadType(typeInt),
-			State:     repo.ThreadState(stateInt),
-			Head:      head,
+			Id:            id,
+			Key:           key,
+			PrivKey:       skb,
+			Name:          name,
+			Schema:        schema,
+			Initiator:     initiator,
+			Type:          repo.ThreadType(typeInt),
+			State:         repo.ThreadState(stateInt),
+			Head:          head,
+			Description:   description,
+			Cover:         cover,
+			Ttl:           int64(ttlInt),
+			ReceiptsOff:   receiptsOffInt == 1,
+			FlagThreshold: flagThresholdInt,
 		})
 	}
 	return ret
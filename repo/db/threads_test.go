@@ -152,6 +152,91 @@ func TestThreadDB_UpdateHead(t *testing.T) {
 	}
 }
 
+func TestThreadDB_UpdateMeta(t *testing.T) {
+	setupThreadDB()
+	err := threadStore.Add(&repo.Thread{
+		Id:        "Qmabc",
+		Key:       ksuid.New().String(),
+		PrivKey:   make([]byte, 8),
+		Name:      "boom",
+		Schema:    "Qm...",
+		Initiator: "123",
+		Type:      repo.PrivateThread,
+		State:     repo.ThreadLoaded,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	err = threadStore.UpdateMeta("Qmabc", "bam", "desc", "Qmcover", 3600)
+	if err != nil {
+		t.Error(err)
+	}
+	th := threadStore.Get("Qmabc")
+	if th == nil {
+		t.Error("could not get thread")
+		return
+	}
+	if th.Name != "bam" || th.Description != "desc" || th.Cover != "Qmcover" || th.Ttl != 3600 {
+		t.Error("update meta failed")
+	}
+}
+
+func TestThreadDB_UpdateReceiptsOff(t *testing.T) {
+	setupThreadDB()
+	err := threadStore.Add(&repo.Thread{
+		Id:        "Qmabc",
+		Key:       ksuid.New().String(),
+		PrivKey:   make([]byte, 8),
+		Name:      "boom",
+		Schema:    "Qm...",
+		Initiator: "123",
+		Type:      repo.PrivateThread,
+		State:     repo.ThreadLoaded,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if err := threadStore.UpdateReceiptsOff("Qmabc", true); err != nil {
+		t.Error(err)
+	}
+	th := threadStore.Get("Qmabc")
+	if th == nil {
+		t.Error("could not get thread")
+		return
+	}
+	if !th.ReceiptsOff {
+		t.Error("update receipts off failed")
+	}
+}
+
+func TestThreadDB_UpdateFlagThreshold(t *testing.T) {
+	setupThreadDB()
+	err := threadStore.Add(&repo.Thread{
+		Id:        "Qmabc",
+		Key:       ksuid.New().String(),
+		PrivKey:   make([]byte, 8),
+		Name:      "boom",
+		Schema:    "Qm...",
+		Initiator: "123",
+		Type:      repo.PrivateThread,
+		State:     repo.ThreadLoaded,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if err := threadStore.UpdateFlagThreshold("Qmabc", 3); err != nil {
+		t.Error(err)
+	}
+	th := threadStore.Get("Qmabc")
+	if th == nil {
+		t.Error("could not get thread")
+		return
+	}
+	if th.FlagThreshold != 3 {
+		t.Error("update flag threshold failed")
+	}
+}
+
 func TestThreadDB_Delete(t *testing.T) {
 	setupThreadDB()
 	err := threadStore.Add(&repo.Thread{
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path"
 	"strconv"
+	"strings"
 
 	m "github.com/textileio/textile-go/repo/migrations"
 )
@@ -24,6 +25,18 @@ var migrations = []Migration{
 	m.Minor003{},
 	m.Minor004{},
 	m.Major005{},
+	m.Minor006{},
+	m.Minor007{},
+	m.Minor008{},
+	m.Minor009{},
+	m.Minor010{},
+	m.Minor011{},
+	m.Minor012{},
+	m.Minor013{},
+	m.Minor014{},
+	m.Minor015{},
+	m.Minor016{},
+	m.Minor017{},
 }
 
 // Stat returns whether or not there's a major migration ahead of the current repover
@@ -73,7 +86,7 @@ func version(repoPath string) (int, error) {
 	} else if err != nil && os.IsNotExist(err) {
 		version = []byte("0")
 	}
-	v, err := strconv.Atoi(string(version[0]))
+	v, err := strconv.Atoi(strings.TrimSpace(string(version)))
 	if err != nil {
 		return 0, err
 	}
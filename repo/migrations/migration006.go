@@ -0,0 +1,65 @@
+package migrations
+
+import (
+	"database/sql"
+	"os"
+	"path"
+
+	_ "github.com/mutecomm/go-sqlcipher"
+)
+
+type Minor006 struct{}
+
+func (Minor006) Up(repoPath string, pinCode string, testnet bool) error {
+	var dbPath string
+	if testnet {
+		dbPath = path.Join(repoPath, "datastore", "testnet.db")
+	} else {
+		dbPath = path.Join(repoPath, "datastore", "mainnet.db")
+	}
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return err
+	}
+	if pinCode != "" {
+		if _, err := db.Exec("pragma key='" + pinCode + "';"); err != nil {
+			return err
+		}
+	}
+
+	// add column for author signature verification to blocks
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt1, err := tx.Prepare("alter table blocks add column verified integer not null default 0;")
+	if err != nil {
+		return err
+	}
+	defer stmt1.Close()
+	_, err = stmt1.Exec()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+
+	// update version
+	f7, err := os.Create(path.Join(repoPath, "repover"))
+	if err != nil {
+		return err
+	}
+	defer f7.Close()
+	if _, err = f7.Write([]byte("7")); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (Minor006) Down(repoPath string, pinCode string, testnet bool) error {
+	return nil
+}
+
+func (Minor006) Major() bool {
+	return false
+}
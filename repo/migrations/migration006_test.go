@@ -0,0 +1,89 @@
+package migrations
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func initAt005(db *sql.DB, pin string) error {
+	var sqlStmt string
+	if pin != "" {
+		sqlStmt = "PRAGMA key = '" + pin + "';"
+	}
+	sqlStmt += `
+    create table blocks (id text primary key not null, threadId text not null, authorId text not null, type integer not null, date integer not null, parents text not null, target text not null, body text not null);
+    create index block_threadId on blocks (threadId);
+    create index block_type on blocks (type);
+    create index block_date on blocks (date);
+    create index block_target on blocks (target);
+    `
+	_, err := db.Exec(sqlStmt)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec("insert into blocks(id, threadId, authorId, type, date, parents, target, body) values(?,?,?,?,?,?,?,?)", "test", "thread", "author", 6, 0, "", "", "hey!")
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func Test006(t *testing.T) {
+	var dbPath string
+	os.Mkdir("./datastore", os.ModePerm)
+	dbPath = path.Join("./", "datastore", "mainnet.db")
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err := initAt005(db, ""); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// go up
+	var m Minor006
+	err = m.Up("./", "", false)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// test new field
+	var verified int
+	if err := db.QueryRow("select verified from blocks where id=?", "test").Scan(&verified); err != nil {
+		t.Error(err)
+		return
+	}
+	if verified != 0 {
+		t.Error("existing blocks should default to unverified")
+		return
+	}
+	_, err = db.Exec("update blocks set verified=? where id=?", 1, "test")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// ensure that version file was updated
+	version, err := ioutil.ReadFile("./repover")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if string(version) != "7" {
+		t.Error("failed to write new repo version")
+		return
+	}
+
+	if err := m.Down("./", "", false); err != nil {
+		t.Error(err)
+		return
+	}
+	os.RemoveAll("./datastore")
+	os.RemoveAll("./repover")
+}
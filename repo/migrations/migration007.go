@@ -0,0 +1,64 @@
+package migrations
+
+import (
+	"database/sql"
+	"os"
+	"path"
+
+	_ "github.com/mutecomm/go-sqlcipher"
+)
+
+type Minor007 struct{}
+
+func (Minor007) Up(repoPath string, pinCode string, testnet bool) error {
+	var dbPath string
+	if testnet {
+		dbPath = path.Join(repoPath, "datastore", "testnet.db")
+	} else {
+		dbPath = path.Join(repoPath, "datastore", "mainnet.db")
+	}
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return err
+	}
+	if pinCode != "" {
+		if _, err := db.Exec("pragma key='" + pinCode + "';"); err != nil {
+			return err
+		}
+	}
+
+	// add table for rotated thread keys
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	query := `
+    create table thread_keys (id text primary key not null, threadId text not null, sk blob not null, date integer not null, blockId text not null);
+    create index thread_key_threadId on thread_keys (threadId);
+    create index thread_key_date on thread_keys (date);
+    `
+	if _, err := tx.Exec(query); err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+
+	// update version
+	f8, err := os.Create(path.Join(repoPath, "repover"))
+	if err != nil {
+		return err
+	}
+	defer f8.Close()
+	if _, err = f8.Write([]byte("8")); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (Minor007) Down(repoPath string, pinCode string, testnet bool) error {
+	return nil
+}
+
+func (Minor007) Major() bool {
+	return false
+}
@@ -0,0 +1,83 @@
+package migrations
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func initAt007(db *sql.DB, pin string) error {
+	var sqlStmt string
+	if pin != "" {
+		sqlStmt = "PRAGMA key = '" + pin + "';"
+	}
+	sqlStmt += `
+    create table thread_peers (id text not null, threadId text not null, welcomed integer not null, primary key (id, threadId));
+    create index thread_peer_id on thread_peers (id);
+    create index thread_peer_threadId on thread_peers (threadId);
+    create index thread_peer_welcomed on thread_peers (welcomed);
+    `
+	_, err := db.Exec(sqlStmt)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec("insert into thread_peers(id, threadId, welcomed) values(?,?,?)", "peer", "thread", 1)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func Test008(t *testing.T) {
+	var dbPath string
+	os.Mkdir("./datastore", os.ModePerm)
+	dbPath = path.Join("./", "datastore", "mainnet.db")
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err := initAt007(db, ""); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// go up
+	var m Minor008
+	err = m.Up("./", "", false)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// test new field
+	var role int
+	if err := db.QueryRow("select role from thread_peers where id=?", "peer").Scan(&role); err != nil {
+		t.Error(err)
+		return
+	}
+	if role != 0 {
+		t.Error("existing peers should default to no role")
+		return
+	}
+
+	// ensure that version file was updated
+	version, err := ioutil.ReadFile("./repover")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if string(version) != "9" {
+		t.Error("failed to write new repo version")
+		return
+	}
+
+	if err := m.Down("./", "", false); err != nil {
+		t.Error(err)
+		return
+	}
+	os.RemoveAll("./datastore")
+	os.RemoveAll("./repover")
+}
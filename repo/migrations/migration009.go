@@ -0,0 +1,75 @@
+package migrations
+
+import (
+	"database/sql"
+	"os"
+	"path"
+
+	_ "github.com/mutecomm/go-sqlcipher"
+)
+
+type Minor009 struct{}
+
+func (Minor009) Up(repoPath string, pinCode string, testnet bool) error {
+	var dbPath string
+	if testnet {
+		dbPath = path.Join(repoPath, "datastore", "testnet.db")
+	} else {
+		dbPath = path.Join(repoPath, "datastore", "mainnet.db")
+	}
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return err
+	}
+	if pinCode != "" {
+		if _, err := db.Exec("pragma key='" + pinCode + "';"); err != nil {
+			return err
+		}
+	}
+
+	// add columns for thread description and cover
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt1, err := tx.Prepare("alter table threads add column description text not null default '';")
+	if err != nil {
+		return err
+	}
+	defer stmt1.Close()
+	_, err = stmt1.Exec()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	stmt2, err := tx.Prepare("alter table threads add column cover text not null default '';")
+	if err != nil {
+		return err
+	}
+	defer stmt2.Close()
+	_, err = stmt2.Exec()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+
+	// update version
+	f10, err := os.Create(path.Join(repoPath, "repover"))
+	if err != nil {
+		return err
+	}
+	defer f10.Close()
+	if _, err = f10.Write([]byte("10")); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (Minor009) Down(repoPath string, pinCode string, testnet bool) error {
+	return nil
+}
+
+func (Minor009) Major() bool {
+	return false
+}
@@ -0,0 +1,64 @@
+package migrations
+
+import (
+	"database/sql"
+	"os"
+	"path"
+
+	_ "github.com/mutecomm/go-sqlcipher"
+)
+
+type Minor010 struct{}
+
+func (Minor010) Up(repoPath string, pinCode string, testnet bool) error {
+	var dbPath string
+	if testnet {
+		dbPath = path.Join(repoPath, "datastore", "testnet.db")
+	} else {
+		dbPath = path.Join(repoPath, "datastore", "mainnet.db")
+	}
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return err
+	}
+	if pinCode != "" {
+		if _, err := db.Exec("pragma key='" + pinCode + "';"); err != nil {
+			return err
+		}
+	}
+
+	// add table for pending thread history syncs
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	query := `
+    create table thread_syncs (id text not null, threadId text not null, date integer not null, attempts integer not null, primary key (id, threadId));
+    create index thread_sync_threadId on thread_syncs (threadId);
+    create index thread_sync_date on thread_syncs (date);
+    `
+	if _, err := tx.Exec(query); err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+
+	// update version
+	f11, err := os.Create(path.Join(repoPath, "repover"))
+	if err != nil {
+		return err
+	}
+	defer f11.Close()
+	if _, err = f11.Write([]byte("11")); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (Minor010) Down(repoPath string, pinCode string, testnet bool) error {
+	return nil
+}
+
+func (Minor010) Major() bool {
+	return false
+}
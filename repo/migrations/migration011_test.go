@@ -0,0 +1,85 @@
+package migrations
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func initAt010(db *sql.DB, pin string) error {
+	var sqlStmt string
+	if pin != "" {
+		sqlStmt = "PRAGMA key = '" + pin + "';"
+	}
+	sqlStmt += `
+    create table blocks (id text primary key not null, threadId text not null, authorId text not null, type integer not null, date integer not null, parents text not null, target text not null, body text not null, verified integer not null);
+    create index block_threadId on blocks (threadId);
+    create index block_type on blocks (type);
+    create index block_date on blocks (date);
+    create index block_target on blocks (target);
+    `
+	_, err := db.Exec(sqlStmt)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec("insert into blocks(id, threadId, authorId, type, date, parents, target, body, verified) values(?,?,?,?,?,?,?,?,?)",
+		"block", "thread", "author", 0, 0, "", "", "", 1)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func Test011(t *testing.T) {
+	var dbPath string
+	os.Mkdir("./datastore", os.ModePerm)
+	dbPath = path.Join("./", "datastore", "mainnet.db")
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err := initAt010(db, ""); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// go up
+	var m Minor011
+	err = m.Up("./", "", false)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// test new field
+	var clock int
+	if err := db.QueryRow("select clock from blocks where id=?", "block").Scan(&clock); err != nil {
+		t.Error(err)
+		return
+	}
+	if clock != 0 {
+		t.Error("existing blocks should default to a zero clock")
+		return
+	}
+
+	// ensure that version file was updated
+	version, err := ioutil.ReadFile("./repover")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if string(version) != "12" {
+		t.Error("failed to write new repo version")
+		return
+	}
+
+	if err := m.Down("./", "", false); err != nil {
+		t.Error(err)
+		return
+	}
+	os.RemoveAll("./datastore")
+	os.RemoveAll("./repover")
+}
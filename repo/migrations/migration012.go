@@ -0,0 +1,96 @@
+package migrations
+
+import (
+	"database/sql"
+	"os"
+	"path"
+
+	_ "github.com/mutecomm/go-sqlcipher"
+)
+
+type Minor012 struct{}
+
+func (Minor012) Up(repoPath string, pinCode string, testnet bool) error {
+	var dbPath string
+	if testnet {
+		dbPath = path.Join(repoPath, "datastore", "testnet.db")
+	} else {
+		dbPath = path.Join(repoPath, "datastore", "mainnet.db")
+	}
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return err
+	}
+	if pinCode != "" {
+		if _, err := db.Exec("pragma key='" + pinCode + "';"); err != nil {
+			return err
+		}
+	}
+
+	// add columns for thread and block ttls
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt1, err := tx.Prepare("alter table threads add column ttl integer not null default 0;")
+	if err != nil {
+		return err
+	}
+	defer stmt1.Close()
+	_, err = stmt1.Exec()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	stmt2, err := tx.Prepare("alter table blocks add column expires integer not null default 0;")
+	if err != nil {
+		return err
+	}
+	defer stmt2.Close()
+	_, err = stmt2.Exec()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	// expired blocks are kept as swept tombstones
+	stmt3, err := tx.Prepare("alter table blocks add column swept integer not null default 0;")
+	if err != nil {
+		return err
+	}
+	defer stmt3.Close()
+	_, err = stmt3.Exec()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	stmt4, err := tx.Prepare("create index block_expires on blocks (expires);")
+	if err != nil {
+		return err
+	}
+	defer stmt4.Close()
+	_, err = stmt4.Exec()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+
+	// update version
+	f13, err := os.Create(path.Join(repoPath, "repover"))
+	if err != nil {
+		return err
+	}
+	defer f13.Close()
+	if _, err = f13.Write([]byte("13")); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (Minor012) Down(repoPath string, pinCode string, testnet bool) error {
+	return nil
+}
+
+func (Minor012) Major() bool {
+	return false
+}
@@ -0,0 +1,110 @@
+package migrations
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func initAt011(db *sql.DB, pin string) error {
+	var sqlStmt string
+	if pin != "" {
+		sqlStmt = "PRAGMA key = '" + pin + "';"
+	}
+	sqlStmt += `
+    create table threads (id text primary key not null, key text not null, sk blob not null, name text not null, schema text not null, initiator text not null, type integer not null, state integer not null, head text not null, description text not null, cover text not null);
+    create table blocks (id text primary key not null, threadId text not null, authorId text not null, type integer not null, date integer not null, parents text not null, target text not null, body text not null, verified integer not null, clock integer not null);
+    create index block_threadId on blocks (threadId);
+    create index block_type on blocks (type);
+    create index block_date on blocks (date);
+    create index block_target on blocks (target);
+    create index block_clock on blocks (clock);
+    `
+	_, err := db.Exec(sqlStmt)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec("insert into threads(id, key, sk, name, schema, initiator, type, state, head, description, cover) values(?,?,?,?,?,?,?,?,?,?,?)",
+		"thread", "key", []byte("sk"), "name", "", "initiator", 0, 0, "", "", "")
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec("insert into blocks(id, threadId, authorId, type, date, parents, target, body, verified, clock) values(?,?,?,?,?,?,?,?,?,?)",
+		"block", "thread", "author", 0, 0, "", "", "", 1, 1)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func Test012(t *testing.T) {
+	var dbPath string
+	os.Mkdir("./datastore", os.ModePerm)
+	dbPath = path.Join("./", "datastore", "mainnet.db")
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err := initAt011(db, ""); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// go up
+	var m Minor012
+	err = m.Up("./", "", false)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// test new fields
+	var ttl int
+	if err := db.QueryRow("select ttl from threads where id=?", "thread").Scan(&ttl); err != nil {
+		t.Error(err)
+		return
+	}
+	if ttl != 0 {
+		t.Error("existing threads should default to no ttl")
+		return
+	}
+	var expires int
+	if err := db.QueryRow("select expires from blocks where id=?", "block").Scan(&expires); err != nil {
+		t.Error(err)
+		return
+	}
+	if expires != 0 {
+		t.Error("existing blocks should default to never expiring")
+		return
+	}
+	var swept int
+	if err := db.QueryRow("select swept from blocks where id=?", "block").Scan(&swept); err != nil {
+		t.Error(err)
+		return
+	}
+	if swept != 0 {
+		t.Error("existing blocks should not be swept")
+		return
+	}
+
+	// ensure that version file was updated
+	version, err := ioutil.ReadFile("./repover")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if string(version) != "13" {
+		t.Error("failed to write new repo version")
+		return
+	}
+
+	if err := m.Down("./", "", false); err != nil {
+		t.Error(err)
+		return
+	}
+	os.RemoveAll("./datastore")
+	os.RemoveAll("./repover")
+}
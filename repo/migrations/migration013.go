@@ -0,0 +1,85 @@
+package migrations
+
+import (
+	"database/sql"
+	"os"
+	"path"
+
+	_ "github.com/mutecomm/go-sqlcipher"
+)
+
+type Minor013 struct{}
+
+func (Minor013) Up(repoPath string, pinCode string, testnet bool) error {
+	var dbPath string
+	if testnet {
+		dbPath = path.Join(repoPath, "datastore", "testnet.db")
+	} else {
+		dbPath = path.Join(repoPath, "datastore", "mainnet.db")
+	}
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return err
+	}
+	if pinCode != "" {
+		if _, err := db.Exec("pragma key='" + pinCode + "';"); err != nil {
+			return err
+		}
+	}
+
+	// add read receipts table and thread opt-out column
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt1, err := tx.Prepare("create table thread_receipts (threadId text not null, peerId text not null, blockId text not null, date integer not null, primary key (threadId, peerId));")
+	if err != nil {
+		return err
+	}
+	defer stmt1.Close()
+	_, err = stmt1.Exec()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	stmt2, err := tx.Prepare("create index thread_receipt_threadId on thread_receipts (threadId);")
+	if err != nil {
+		return err
+	}
+	defer stmt2.Close()
+	_, err = stmt2.Exec()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	stmt3, err := tx.Prepare("alter table threads add column receiptsOff integer not null default 0;")
+	if err != nil {
+		return err
+	}
+	defer stmt3.Close()
+	_, err = stmt3.Exec()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+
+	// update version
+	f14, err := os.Create(path.Join(repoPath, "repover"))
+	if err != nil {
+		return err
+	}
+	defer f14.Close()
+	if _, err = f14.Write([]byte("14")); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (Minor013) Down(repoPath string, pinCode string, testnet bool) error {
+	return nil
+}
+
+func (Minor013) Major() bool {
+	return false
+}
@@ -0,0 +1,86 @@
+package migrations
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func initAt012(db *sql.DB, pin string) error {
+	var sqlStmt string
+	if pin != "" {
+		sqlStmt = "PRAGMA key = '" + pin + "';"
+	}
+	sqlStmt += `
+    create table threads (id text primary key not null, key text not null, sk blob not null, name text not null, schema text not null, initiator text not null, type integer not null, state integer not null, head text not null, description text not null, cover text not null, ttl integer not null);
+    `
+	_, err := db.Exec(sqlStmt)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec("insert into threads(id, key, sk, name, schema, initiator, type, state, head, description, cover, ttl) values(?,?,?,?,?,?,?,?,?,?,?,?)",
+		"thread", "key", []byte("sk"), "name", "", "initiator", 0, 0, "", "", "", 0)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func Test013(t *testing.T) {
+	var dbPath string
+	os.Mkdir("./datastore", os.ModePerm)
+	dbPath = path.Join("./", "datastore", "mainnet.db")
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err := initAt012(db, ""); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// go up
+	var m Minor013
+	err = m.Up("./", "", false)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// test new table and field
+	_, err = db.Exec("insert into thread_receipts(threadId, peerId, blockId, date) values(?,?,?,?)", "thread", "peer", "block", 0)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var off int
+	if err := db.QueryRow("select receiptsOff from threads where id=?", "thread").Scan(&off); err != nil {
+		t.Error(err)
+		return
+	}
+	if off != 0 {
+		t.Error("existing threads should default to sending receipts")
+		return
+	}
+
+	// ensure that version file was updated
+	version, err := ioutil.ReadFile("./repover")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if string(version) != "14" {
+		t.Error("failed to write new repo version")
+		return
+	}
+
+	if err := m.Down("./", "", false); err != nil {
+		t.Error(err)
+		return
+	}
+	os.RemoveAll("./datastore")
+	os.RemoveAll("./repover")
+}
@@ -0,0 +1,99 @@
+package migrations
+
+import (
+	"database/sql"
+	"os"
+	"path"
+
+	_ "github.com/mutecomm/go-sqlcipher"
+)
+
+type Minor014 struct{}
+
+func (Minor014) Up(repoPath string, pinCode string, testnet bool) error {
+	var dbPath string
+	if testnet {
+		dbPath = path.Join(repoPath, "datastore", "testnet.db")
+	} else {
+		dbPath = path.Join(repoPath, "datastore", "mainnet.db")
+	}
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return err
+	}
+	if pinCode != "" {
+		if _, err := db.Exec("pragma key='" + pinCode + "';"); err != nil {
+			return err
+		}
+	}
+
+	// add full-text search table
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt1, err := tx.Prepare("create virtual table search using fts4(blockId, threadId, authorId, type, date, field, text, notindexed=blockId, notindexed=threadId, notindexed=authorId, notindexed=type, notindexed=date, notindexed=field, tokenize=unicode61);")
+	if err != nil {
+		return err
+	}
+	defer stmt1.Close()
+	_, err = stmt1.Exec()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// index the latest body of messages, comments, and files captions (types 6, 8, 7),
+	// skipping ignored blocks (type 1), where an edit is a type 13 block by the same author
+	stmt2, err := tx.Prepare(`insert into search(blockId, threadId, authorId, type, date, field, text)
+        select b.id, b.threadId, b.authorId, b.type, b.date, 'body',
+            coalesce((select e.body from blocks e where e.type=13 and e.target='edit-'||b.id and e.authorId=b.authorId order by e.date desc limit 1), b.body)
+        from blocks b
+        where b.type in (6,7,8) and b.body!=''
+            and not exists (select 1 from blocks i where i.type=1 and i.target='ignore-'||b.id);`)
+	if err != nil {
+		return err
+	}
+	defer stmt2.Close()
+	_, err = stmt2.Exec()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// index file names by files block target, file meta is only indexed for new files
+	stmt3, err := tx.Prepare(`insert into search(blockId, threadId, authorId, type, date, field, text)
+        select b.id, b.threadId, b.authorId, b.type, b.date, 'file:'||f.hash, f.name
+        from blocks b join files f on instr(','||f.targets||',', ','||b.target||',')>0
+        where b.type=7 and f.name!=''
+            and not exists (select 1 from blocks i where i.type=1 and i.target='ignore-'||b.id);`)
+	if err != nil {
+		return err
+	}
+	defer stmt3.Close()
+	_, err = stmt3.Exec()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+
+	// update version
+	f15, err := os.Create(path.Join(repoPath, "repover"))
+	if err != nil {
+		return err
+	}
+	defer f15.Close()
+	if _, err = f15.Write([]byte("15")); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (Minor014) Down(repoPath string, pinCode string, testnet bool) error {
+	return nil
+}
+
+func (Minor014) Major() bool {
+	return false
+}
@@ -0,0 +1,103 @@
+package migrations
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func initAt013(db *sql.DB, pin string) error {
+	var sqlStmt string
+	if pin != "" {
+		sqlStmt = "PRAGMA key = '" + pin + "';"
+	}
+	sqlStmt += `
+    create table files (mill text not null, checksum text not null, source text not null, opts text not null, hash text not null, key text not null, media text not null, name text not null, size integer not null, added integer not null, meta blob, targets text, primary key (mill, checksum));
+    create table blocks (id text primary key not null, threadId text not null, authorId text not null, type integer not null, date integer not null, parents text not null, target text not null, body text not null, verified integer not null, clock integer not null, expires integer not null, swept integer not null);
+    `
+	_, err := db.Exec(sqlStmt)
+	if err != nil {
+		return err
+	}
+	blocks := [][]interface{}{
+		{"message", 6, "", "hello harbor"},
+		{"edit", 13, "edit-message", "hello lighthouse"},
+		{"comment", 8, "files", "nice harbor"},
+		{"files", 7, "Qmtarget", ""},
+		{"ignored", 6, "", "ignored harbor"},
+		{"ignore", 1, "ignore-ignored", ""},
+	}
+	for _, b := range blocks {
+		_, err = db.Exec("insert into blocks(id, threadId, authorId, type, date, parents, target, body, verified, clock, expires, swept) values(?,?,?,?,?,?,?,?,?,?,?,?)",
+			b[0], "thread", "author", b[1], 0, "", b[2], b[3], 1, 0, 0, 0)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = db.Exec("insert into files(mill, checksum, source, opts, hash, key, media, name, size, added, meta, targets) values(?,?,?,?,?,?,?,?,?,?,?,?)",
+		"/blob", "checksum", "source", "", "Qmfile", "", "image/jpeg", "harbor.jpg", 0, 0, nil, "Qmtarget")
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func Test014(t *testing.T) {
+	var dbPath string
+	os.Mkdir("./datastore", os.ModePerm)
+	dbPath = path.Join("./", "datastore", "mainnet.db")
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err := initAt013(db, ""); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// go up
+	var m Minor014
+	err = m.Up("./", "", false)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// test existing blocks were indexed
+	count := func(q string) int {
+		var cnt int
+		if err := db.QueryRow("select count(*) from search where search match ?", q).Scan(&cnt); err != nil {
+			t.Error(err)
+		}
+		return cnt
+	}
+	if count("harbor") != 2 {
+		t.Error("expected a comment and a file name to match")
+		return
+	}
+	if count("lighthouse") != 1 {
+		t.Error("expected edited message body to be indexed")
+		return
+	}
+
+	// ensure that version file was updated
+	version, err := ioutil.ReadFile("./repover")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if string(version) != "15" {
+		t.Error("failed to write new repo version")
+		return
+	}
+
+	if err := m.Down("./", "", false); err != nil {
+		t.Error(err)
+		return
+	}
+	os.RemoveAll("./datastore")
+	os.RemoveAll("./repover")
+}
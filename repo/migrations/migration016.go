@@ -0,0 +1,85 @@
+package migrations
+
+import (
+	"database/sql"
+	"os"
+	"path"
+
+	_ "github.com/mutecomm/go-sqlcipher"
+)
+
+type Minor016 struct{}
+
+func (Minor016) Up(repoPath string, pinCode string, testnet bool) error {
+	var dbPath string
+	if testnet {
+		dbPath = path.Join(repoPath, "datastore", "testnet.db")
+	} else {
+		dbPath = path.Join(repoPath, "datastore", "mainnet.db")
+	}
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return err
+	}
+	if pinCode != "" {
+		if _, err := db.Exec("pragma key='" + pinCode + "';"); err != nil {
+			return err
+		}
+	}
+
+	// add join requests table
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt1, err := tx.Prepare("create table thread_join_requests (id text primary key not null, threadId text not null, peerId text not null, address text not null, username text not null, inboxes text not null, note text not null, date integer not null, status integer not null, deciderId text not null, decided integer not null, outgoing integer not null);")
+	if err != nil {
+		return err
+	}
+	defer stmt1.Close()
+	_, err = stmt1.Exec()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	stmt2, err := tx.Prepare("create index thread_join_request_threadId on thread_join_requests (threadId);")
+	if err != nil {
+		return err
+	}
+	defer stmt2.Close()
+	_, err = stmt2.Exec()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	stmt3, err := tx.Prepare("create index thread_join_request_peerId on thread_join_requests (peerId);")
+	if err != nil {
+		return err
+	}
+	defer stmt3.Close()
+	_, err = stmt3.Exec()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+
+	// update version
+	f17, err := os.Create(path.Join(repoPath, "repover"))
+	if err != nil {
+		return err
+	}
+	defer f17.Close()
+	if _, err = f17.Write([]byte("17")); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (Minor016) Down(repoPath string, pinCode string, testnet bool) error {
+	return nil
+}
+
+func (Minor016) Major() bool {
+	return false
+}
@@ -0,0 +1,86 @@
+package migrations
+
+import (
+	"database/sql"
+	"os"
+	"path"
+
+	_ "github.com/mutecomm/go-sqlcipher"
+)
+
+type Minor017 struct{}
+
+func (Minor017) Up(repoPath string, pinCode string, testnet bool) error {
+	var dbPath string
+	if testnet {
+		dbPath = path.Join(repoPath, "datastore", "testnet.db")
+	} else {
+		dbPath = path.Join(repoPath, "datastore", "mainnet.db")
+	}
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return err
+	}
+	if pinCode != "" {
+		if _, err := db.Exec("pragma key='" + pinCode + "';"); err != nil {
+			return err
+		}
+	}
+
+	// add thread flag threshold, and hidden and address block columns
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt1, err := tx.Prepare("alter table threads add column flagThreshold integer not null default 0;")
+	if err != nil {
+		return err
+	}
+	defer stmt1.Close()
+	_, err = stmt1.Exec()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	stmt2, err := tx.Prepare("alter table blocks add column hidden integer not null default 0;")
+	if err != nil {
+		return err
+	}
+	defer stmt2.Close()
+	_, err = stmt2.Exec()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	// flag authors are checked for moderator roles by their signed address
+	stmt3, err := tx.Prepare("alter table blocks add column address text not null default '';")
+	if err != nil {
+		return err
+	}
+	defer stmt3.Close()
+	_, err = stmt3.Exec()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+
+	// update version
+	f18, err := os.Create(path.Join(repoPath, "repover"))
+	if err != nil {
+		return err
+	}
+	defer f18.Close()
+	if _, err = f18.Write([]byte("18")); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (Minor017) Down(repoPath string, pinCode string, testnet bool) error {
+	return nil
+}
+
+func (Minor017) Major() bool {
+	return false
+}
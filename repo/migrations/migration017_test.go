@@ -0,0 +1,105 @@
+package migrations
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func initAt016(db *sql.DB, pin string) error {
+	var sqlStmt string
+	if pin != "" {
+		sqlStmt = "PRAGMA key = '" + pin + "';"
+	}
+	sqlStmt += `
+    create table threads (id text primary key not null, key text not null, sk blob not null, name text not null, schema text not null, initiator text not null, type integer not null, state integer not null, head text not null, description text not null, cover text not null, ttl integer not null, receiptsOff integer not null);
+    create table blocks (id text primary key not null, threadId text not null, authorId text not null, type integer not null, date integer not null, parents text not null, target text not null, body text not null, verified integer not null, clock integer not null, expires integer not null, swept integer not null);
+    `
+	_, err := db.Exec(sqlStmt)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec("insert into threads(id, key, sk, name, schema, initiator, type, state, head, description, cover, ttl, receiptsOff) values(?,?,?,?,?,?,?,?,?,?,?,?,?)",
+		"thread", "key", []byte("sk"), "name", "", "initiator", 0, 0, "", "", "", 0, 0)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec("insert into blocks(id, threadId, authorId, type, date, parents, target, body, verified, clock, expires, swept) values(?,?,?,?,?,?,?,?,?,?,?,?)",
+		"block", "thread", "author", 0, 0, "", "", "", 1, 0, 0, 0)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func Test017(t *testing.T) {
+	var dbPath string
+	os.Mkdir("./datastore", os.ModePerm)
+	dbPath = path.Join("./", "datastore", "mainnet.db")
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err := initAt016(db, ""); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// go up
+	var m Minor017
+	err = m.Up("./", "", false)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// test new fields
+	var threshold int
+	if err := db.QueryRow("select flagThreshold from threads where id=?", "thread").Scan(&threshold); err != nil {
+		t.Error(err)
+		return
+	}
+	if threshold != 0 {
+		t.Error("existing threads should default to moderator flags only")
+		return
+	}
+	var hidden int
+	if err := db.QueryRow("select hidden from blocks where id=?", "block").Scan(&hidden); err != nil {
+		t.Error(err)
+		return
+	}
+	if hidden != 0 {
+		t.Error("existing blocks should not be hidden")
+		return
+	}
+	var address string
+	if err := db.QueryRow("select address from blocks where id=?", "block").Scan(&address); err != nil {
+		t.Error(err)
+		return
+	}
+	if address != "" {
+		t.Error("existing blocks should have an unknown address")
+		return
+	}
+
+	// ensure that version file was updated
+	version, err := ioutil.ReadFile("./repover")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if string(version) != "18" {
+		t.Error("failed to write new repo version")
+		return
+	}
+
+	if err := m.Down("./", "", false); err != nil {
+		t.Error(err)
+		return
+	}
+	os.RemoveAll("./datastore")
+	os.RemoveAll("./repover")
+}
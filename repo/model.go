@@ -24,15 +24,20 @@ type File struct {
 }
 
 type Thread struct {
-	Id        string      `json:"id"`
-	Key       string      `json:"key"`
-	PrivKey   []byte      `json:"sk"`
-	Name      string      `json:"name"`
-	Schema    string      `json:"schema"`
-	Initiator string      `json:"initiator"`
-	Type      ThreadType  `json:"type"`
-	State     ThreadState `json:"state"`
-	Head      string      `json:"head"`
+	Id            string      `json:"id"`
+	Key           string      `json:"key"`
+	PrivKey       []byte      `json:"sk"`
+	Name          string      `json:"name"`
+	Schema        string      `json:"schema"`
+	Initiator     string      `json:"initiator"`
+	Type          ThreadType  `json:"type"`
+	State         ThreadState `json:"state"`
+	Head          string      `json:"head"`
+	Description   string      `json:"description"`
+	Cover         string      `json:"cover"`
+	Ttl           int64       `json:"ttl"`
+	ReceiptsOff   bool        `json:"receipts_off"`
+	FlagThreshold int         `json:"flag_threshold"`
 }
 
 type ThreadType int
@@ -64,6 +69,10 @@ func ThreadTypeFromString(desc string) (ThreadType, error) {
 	switch strings.ToUpper(strings.TrimSpace(desc)) {
 	case "PRIVATE":
 		return PrivateThread, nil
+	case "READONLY":
+		return ReadOnlyThread, nil
+	case "PUBLIC":
+		return PublicThread, nil
 	case "OPEN":
 		return OpenThread, nil
 	default:
@@ -97,10 +106,124 @@ type ThreadInvite struct {
 	Date    time.Time `json:"date"`
 }
 
+type ThreadKey struct {
+	Id       string    `json:"id"`
+	ThreadId string    `json:"thread_id"`
+	PrivKey  []byte    `json:"sk"`
+	Date     time.Time `json:"date"`
+	BlockId  string    `json:"block_id"` // key block that introduced the key
+}
+
 type ThreadPeer struct {
-	Id       string `json:"id"`
-	ThreadId string `json:"thread_id"`
-	Welcomed bool   `json:"welcomed"`
+	Id       string     `json:"id"`
+	ThreadId string     `json:"thread_id"`
+	Welcomed bool       `json:"welcomed"`
+	Role     ThreadRole `json:"role"`
+}
+
+type ThreadSync struct {
+	Id       string    `json:"id"`
+	ThreadId string    `json:"thread_id"`
+	Date     time.Time `json:"date"`
+	Attempts int       `json:"attempts"`
+}
+
+type ThreadReceipt struct {
+	ThreadId string    `json:"thread_id"`
+	PeerId   string    `json:"peer_id"`
+	BlockId  string    `json:"block_id"`
+	Date     time.Time `json:"date"`
+}
+
+type ThreadExternalInvite struct {
+	Id        string     `json:"id"`
+	ThreadId  string     `json:"thread_id"`
+	InviterId string     `json:"inviter_id"`
+	Expires   *time.Time `json:"expires,omitempty"`
+	MaxUses   int        `json:"max_uses"`
+	Revoked   bool       `json:"revoked"`
+	Date      time.Time  `json:"date"`
+}
+
+type ThreadJoinRequest struct {
+	Id        string            `json:"id"`
+	ThreadId  string            `json:"thread_id"`
+	PeerId    string            `json:"peer_id"`
+	Address   string            `json:"address"`
+	Username  string            `json:"username"`
+	Inboxes   []string          `json:"inboxes"`
+	Note      string            `json:"note,omitempty"`
+	Date      time.Time         `json:"date"`
+	Status    JoinRequestStatus `json:"status"`
+	DeciderId string            `json:"decider_id,omitempty"`
+	Decided   *time.Time        `json:"decided,omitempty"`
+	Outgoing  bool              `json:"outgoing,omitempty"`
+}
+
+type JoinRequestStatus int
+
+const (
+	PendingJoinRequest JoinRequestStatus = iota
+	ApprovedJoinRequest
+	DeniedJoinRequest
+)
+
+func (s JoinRequestStatus) Description() string {
+	switch s {
+	case PendingJoinRequest:
+		return "PENDING"
+	case ApprovedJoinRequest:
+		return "APPROVED"
+	case DeniedJoinRequest:
+		return "DENIED"
+	default:
+		return "INVALID"
+	}
+}
+
+type ThreadRole int
+
+// in order of increasing permissions
+const (
+	DefaultRole   ThreadRole = iota // no role granted, thread type rules apply
+	ReaderRole                      // only flags allowed
+	WriterRole                      // all content writes allowed
+	ModeratorRole                   // may also ignore others' content and remove peers
+	AdminRole                       // may also grant and revoke roles
+)
+
+func (tr ThreadRole) Description() string {
+	switch tr {
+	case DefaultRole:
+		return "DEFAULT"
+	case ReaderRole:
+		return "READER"
+	case WriterRole:
+		return "WRITER"
+	case ModeratorRole:
+		return "MODERATOR"
+	case AdminRole:
+		return "ADMIN"
+	default:
+		return "INVALID"
+	}
+}
+
+func ThreadRoleFromString(desc string) (ThreadRole, error) {
+	switch strings.ToUpper(strings.TrimSpace(desc)) {
+	case "DEFAULT":
+		return DefaultRole, nil
+	case "READER":
+		return ReaderRole, nil
+	case "WRITER":
+		return WriterRole, nil
+	case "MODERATOR":
+		return ModeratorRole, nil
+	case "ADMIN":
+		return AdminRole, nil
+	default:
+		return -1, errors.New("could not parse thread role")
+	}
 }
 
 type ThreadMessage struct {
@@ -111,14 +234,20 @@ type ThreadMessage struct {
 }
 
 type Block struct {
-	Id       string    `json:"id"`
-	ThreadId string    `json:"thread_id"`
-	AuthorId string    `json:"author_id"`
-	Type     BlockType `json:"type"`
-	Date     time.Time `json:"date"`
-	Parents  []string  `json:"parents"`
-	Target   string    `json:"target,omitempty"`
-	Body     string    `json:"body,omitempty"`
+	Id       string     `json:"id"`
+	ThreadId string     `json:"thread_id"`
+	AuthorId string     `json:"author_id"`
+	Address  string     `json:"address,omitempty"` // signed account address, empty if unverified
+	Type     BlockType  `json:"type"`
+	Date     time.Time  `json:"date"`
+	Parents  []string   `json:"parents"`
+	Target   string     `json:"target,omitempty"`
+	Body     string     `json:"body,omitempty"`
+	Verified bool       `json:"verified"`
+	Clock    int64      `json:"clock"`
+	Expires  *time.Time `json:"expires,omitempty"`
+	Hidden   bool       `json:"hidden,omitempty"` // hidden by flags
+	Swept    bool       `json:"swept,omitempty"`  // expired and cleared, kept as a tombstone
 }
 
 type BlockType int
@@ -134,6 +263,15 @@ const (
 	FilesBlock
 	CommentBlock
 	LikeBlock
+	KeyBlock
+	KickBlock
+	RoleBlock
+	EditBlock
+	MetaBlock
+	CheckpointBlock
+	ExternalInviteBlock
+	UndoBlock
+	JoinDecisionBlock
 )
 
 func (b BlockType) Description() string {
@@ -158,11 +296,121 @@ func (b BlockType) Description() string {
 		return "COMMENT"
 	case LikeBlock:
 		return "LIKE"
+	case KeyBlock:
+		return "KEY"
+	case KickBlock:
+		return "KICK"
+	case RoleBlock:
+		return "ROLE"
+	case EditBlock:
+		return "EDIT"
+	case MetaBlock:
+		return "META"
+	case CheckpointBlock:
+		return "CHECKPOINT"
+	case ExternalInviteBlock:
+		return "EXTERNAL_INVITE"
+	case UndoBlock:
+		return "UNDO"
+	case JoinDecisionBlock:
+		return "JOIN_DECISION"
+	default:
+		return "INVALID"
+	}
+}
+
+func BlockTypeFromString(desc string) (BlockType, error) {
+	desc = strings.ToUpper(strings.TrimSpace(desc))
+	for b := MergeBlock; b <= JoinDecisionBlock; b++ {
+		if b.Description() == desc {
+			return b, nil
+		}
+	}
+	return -1, errors.New("could not parse block type")
+}
+
+type BlockOrder int
+
+const (
+	DateOrder   BlockOrder = iota // newest author date first
+	CausalOrder                   // highest lamport clock first, ties broken by date and id
+)
+
+func (o BlockOrder) Description() string {
+	switch o {
+	case DateOrder:
+		return "DATE"
+	case CausalOrder:
+		return "CAUSAL"
 	default:
 		return "INVALID"
 	}
 }
 
+func BlockOrderFromString(desc string) (BlockOrder, error) {
+	switch strings.ToUpper(strings.TrimSpace(desc)) {
+	case "", "DATE":
+		return DateOrder, nil
+	case "CAUSAL":
+		return CausalOrder, nil
+	default:
+		return -1, errors.New("could not parse block order")
+	}
+}
+
+// BlockQuery is a typed block query. Empty fields do not filter.
+type BlockQuery struct {
+	ThreadIds []string    `json:"thread_ids,omitempty"`
+	Types     []BlockType `json:"types,omitempty"`
+	AuthorIds []string    `json:"author_ids,omitempty"`
+	Since     *time.Time  `json:"since,omitempty"`
+	Until     *time.Time  `json:"until,omitempty"`
+	Target    string      `json:"target,omitempty"`
+	Body      string      `json:"body,omitempty"`
+	Parent    string      `json:"parent,omitempty"`    // id of a parent block
+	Verified  bool        `json:"verified,omitempty"`  // only verified blocks
+	Due       *time.Time  `json:"due,omitempty"`       // only unswept blocks expired by this date
+	Offset    string      `json:"offset,omitempty"`    // id of the last block of the previous page
+	Limit     int         `json:"limit,omitempty"`     // zero or less for no limit
+	Order     BlockOrder  `json:"order"`               // date or causal
+	Ascending bool        `json:"ascending,omitempty"` // oldest first
+	Ignored   bool        `json:"ignored,omitempty"`   // include ignored and undone blocks
+	Expired   bool        `json:"expired,omitempty"`   // include expired blocks
+	Hidden    bool        `json:"hidden,omitempty"`    // include blocks hidden by flags
+}
+
+// SearchDoc is a piece of searchable block text, e.g., a message body or a file name
+type SearchDoc struct {
+	BlockId  string    `json:"block_id"`
+	ThreadId string    `json:"thread_id"`
+	AuthorId string    `json:"author_id"`
+	Type     BlockType `json:"type"`
+	Date     time.Time `json:"date"`
+	Field    string    `json:"field"`
+	Text     string    `json:"text"`
+}
+
+// SearchQuery is a full-text search with optional filters
+type SearchQuery struct {
+	Text     string      `json:"text"`
+	ThreadId string      `json:"thread_id,omitempty"`
+	AuthorId string      `json:"author_id,omitempty"`
+	Types    []BlockType `json:"types,omitempty"`
+	Since    *time.Time  `json:"since,omitempty"`
+	Until    *time.Time  `json:"until,omitempty"`
+}
+
+// SearchResult is a block matching a search, best match first
+type SearchResult struct {
+	BlockId  string    `json:"block_id"`
+	ThreadId string    `json:"thread_id"`
+	AuthorId string    `json:"author_id"`
+	Type     BlockType `json:"type"`
+	Date     time.Time `json:"date"`
+	Snippet  string    `json:"snippet"`
+	Rank     float64   `json:"rank"`
+}
+
 type Contact struct {
 	Id       string    `json:"id"`
 	Address  string    `json:"address"`
@@ -195,6 +443,9 @@ const (
 	FilesAddedNotification
 	CommentAddedNotification
 	LikeAddedNotification
+	JoinRequestReceivedNotification
+	JoinRequestDeniedNotification
+	FlagAddedNotification
 )
 
 func (n NotificationType) Description() string {
@@ -215,6 +466,12 @@ func (n NotificationType) Description() string {
 		return "COMMENT_ADDED"
 	case LikeAddedNotification:
 		return "LIKE_ADDED"
+	case JoinRequestReceivedNotification:
+		return "JOIN_REQUEST_RECEIVED"
+	case JoinRequestDeniedNotification:
+		return "JOIN_REQUEST_DENIED"
+	case FlagAddedNotification:
+		return "FLAG_ADDED"
 	default:
 		return "INVALID"
 	}
@@ -23,6 +23,8 @@ const (
 	VersionByteAccountID VersionByte = 0xdd // Base58-encodes to 'P...'
 	// VersionByteSeed is the version byte used for encoded textile seed
 	VersionByteSeed = 0xff // Base58-encodes to 'S...'
+	// VersionByteInvite is the version byte used for encoded external thread invites
+	VersionByteInvite VersionByte = 0x4b // Base58-encodes to '8...'
 )
 
 // Decode decodes the provided StrKey into a raw value, checking the checksum
@@ -127,6 +129,10 @@ func checkValidVersionByte(version VersionByte) error {
 		return nil
 	}
 
+	if version == VersionByteInvite {
+		return nil
+	}
+
 	return ErrInvalidVersionByte
 }
 